@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/a-novel/golib/loggers"
+	"github.com/a-novel/golib/loggers/formatters"
+)
+
+// Job is a background task, periodically executed by the server. It returns a short report of its execution.
+type Job func(ctx context.Context) (string, error)
+
+// runJob executes the job at a fixed interval, until the context is canceled. A failed execution is reported, but
+// does not stop the next ones from running.
+func runJob(ctx context.Context, name string, interval time.Duration, job Job, logger formatters.Formatter) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := job(ctx)
+			if err != nil {
+				logger.Log(formatters.NewError(err, name), loggers.LogLevelError)
+				continue
+			}
+
+			logger.Log(formatters.NewBase(name+": "+report), loggers.LogLevelInfo)
+		}
+	}
+}
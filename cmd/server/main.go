@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/samber/lo"
 	"github.com/uptrace/bun"
 	"google.golang.org/grpc"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -80,19 +82,45 @@ func main() {
 
 	grpcReporter := adapters.NewGRPC(logger)
 
-	createCredentialsDAO := dao.NewCreateCredentials(postgresDB)
+	createCredentialsDAO := dao.NewCreateCredentials(
+		postgresDB, config.App.Roles.Capacity, config.App.Emails.Domains, config.App.Emails.UniqueCanonical,
+	)
 	existsCredentialsDAO := dao.NewExistsCredentials(postgresDB)
 	getCredentialsDAO := dao.NewGetCredentials(postgresDB)
 	listCredentialsDAO := dao.NewListCredentials(postgresDB)
 	searchCredentialsDAO := dao.NewSearchCredentials(postgresDB)
-	updateCredentialsDAO := dao.NewUpdateCredentials(postgresDB)
+	updateCredentialsDAO := dao.NewUpdateCredentials(
+		postgresDB,
+		config.App.Roles.Capacity,
+		config.App.Roles.Privileged,
+		config.App.Roles.Protected,
+		config.App.Roles.Assignment,
+		config.App.Emails.Domains,
+		config.App.Emails.UniqueCanonical,
+	)
+	sweepExpiredTokensDAO := dao.NewSweepExpiredTokens(postgresDB)
+	eraseScheduledCredentialsDAO := dao.NewEraseScheduledCredentials(postgresDB)
+	revertExpiredRoleGrantsDAO := dao.NewRevertExpiredRoleGrants(postgresDB, config.App.Roles.Capacity)
+	promoteWaitlistedCredentialsDAO := dao.NewPromoteWaitlistedCredentials(postgresDB, config.App.Roles.Capacity)
+	normalizeEmailsDAO := dao.NewNormalizeEmails(postgresDB)
+	backfillCanonicalEmailsDAO := dao.NewBackfillCanonicalEmails(postgresDB)
 
-	createCredentialsService := services.NewCreateCredentials(createCredentialsDAO)
+	createCredentialsService := services.NewCreateCredentials(
+		createCredentialsDAO, config.App.Roles.Assignment, config.App.Roles.Privileged,
+	)
 	existsCredentialsService := services.NewExistsCredentials(existsCredentialsDAO)
 	getCredentialsService := services.NewGetCredentials(getCredentialsDAO)
 	listCredentialsService := services.NewListCredentials(listCredentialsDAO)
 	searchCredentialsService := services.NewSearchCredentials(searchCredentialsDAO)
 	updateCredentialsService := services.NewUpdateCredentials(updateCredentialsDAO)
+	sweepExpiredTokensService := services.NewSweepExpiredTokens(sweepExpiredTokensDAO)
+	eraseScheduledCredentialsService := services.NewEraseScheduledCredentials(eraseScheduledCredentialsDAO)
+	revertExpiredRoleGrantsService := services.NewRevertExpiredRoleGrants(revertExpiredRoleGrantsDAO)
+	promoteWaitlistedCredentialsService := services.NewPromoteWaitlistedCredentials(promoteWaitlistedCredentialsDAO)
+	normalizeEmailsService := services.NewNormalizeEmails(normalizeEmailsDAO, config.App.Backfills.BatchSize)
+	backfillCanonicalEmailsService := services.NewBackfillCanonicalEmails(
+		backfillCanonicalEmailsDAO, config.App.Backfills.BatchSize,
+	)
 
 	createCredentialsHandler := handlers.NewCreateCredentials(createCredentialsService, grpcReporter)
 	existsCredentialsHandler := handlers.NewExistsCredentials(existsCredentialsService, grpcReporter)
@@ -103,7 +131,133 @@ func main() {
 
 	logger.Log(loader.SetDescription("Services successfully setup.").SetCompleted(), loggers.LogLevelInfo)
 
-	listener, server, err := anovelgrpc.StartServer(config.App.Server.Port)
+	// Backfills complete the data migrations could not compute, before the server relies on it. Lookups normalize
+	// emails, so stored emails must be normalized before the server starts serving. Both only select the credentials
+	// that still need it, so they are cheap once done.
+	loader = formatters.NewLoader("Normalizing emails...", spinner.Meter)
+	logger.Log(loader, loggers.LogLevelInfo)
+
+	normalizedEmails, err := normalizeEmailsService.Exec(context.Background())
+	if err != nil {
+		logger.Log(formatters.NewError(err, "normalize emails"), loggers.LogLevelFatal)
+	}
+
+	logger.Log(
+		loader.SetDescription(fmt.Sprintf("%d email(s) normalized.", normalizedEmails.Normalized)).SetCompleted(),
+		loggers.LogLevelInfo,
+	)
+
+	for _, conflict := range normalizedEmails.Conflicts {
+		logger.Log(
+			formatters.NewBase(fmt.Sprintf(
+				"email of %s/%s (%s) normalizes to the email of other credentials, merge them to normalize it",
+				conflict.TenantID, conflict.CredentialID, conflict.Email,
+			)),
+			loggers.LogLevelWarning,
+		)
+	}
+
+	loader = formatters.NewLoader("Backfilling canonical emails...", spinner.Meter)
+	logger.Log(loader, loggers.LogLevelInfo)
+
+	backfilledCanonicalEmails, err := backfillCanonicalEmailsService.Exec(context.Background())
+	if err != nil {
+		logger.Log(formatters.NewError(err, "backfill canonical emails"), loggers.LogLevelFatal)
+	}
+
+	logger.Log(
+		loader.SetDescription(
+			fmt.Sprintf("%d canonical email(s) backfilled.", backfilledCanonicalEmails.Backfilled),
+		).SetCompleted(),
+		loggers.LogLevelInfo,
+	)
+
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	go runJob(
+		jobsCtx, "sweep expired tokens", config.App.Jobs.SweepExpiredTokens.Interval,
+		func(ctx context.Context) (string, error) {
+			res, err := sweepExpiredTokensService.Exec(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf("%d expired token(s) cleared", res.Cleared), nil
+		},
+		logger,
+	)
+
+	go runJob(
+		jobsCtx, "erase scheduled credentials", config.App.Jobs.EraseScheduledCredentials.Interval,
+		func(ctx context.Context) (string, error) {
+			res, err := eraseScheduledCredentialsService.Exec(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			erased := lo.Map(
+				res.Deletions,
+				func(item *services.EraseScheduledCredentialsResponseDeletion, _ int) string {
+					return item.TenantID + "/" + item.CredentialID
+				},
+			)
+
+			return fmt.Sprintf("%d credential(s) erased %v", len(erased), erased), nil
+		},
+		logger,
+	)
+
+	go runJob(
+		jobsCtx, "revert expired role grants", config.App.Jobs.RevertExpiredRoleGrants.Interval,
+		func(ctx context.Context) (string, error) {
+			res, err := revertExpiredRoleGrantsService.Exec(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			reverted := lo.Map(
+				res.Changes,
+				func(item *services.RevertExpiredRoleGrantsResponseChange, _ int) string {
+					return fmt.Sprintf(
+						"%s/%s (%s -> %s)",
+						item.TenantID, item.CredentialID, item.FromRole.String(), item.ToRole.String(),
+					)
+				},
+			)
+
+			return fmt.Sprintf("%d role grant(s) reverted %v", len(reverted), reverted), nil
+		},
+		logger,
+	)
+
+	go runJob(
+		jobsCtx, "promote waitlisted credentials", config.App.Jobs.PromoteWaitlistedCredentials.Interval,
+		func(ctx context.Context) (string, error) {
+			res, err := promoteWaitlistedCredentialsService.Exec(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			promoted := lo.Map(
+				res.Promotions,
+				func(item *services.PromoteWaitlistedCredentialsResponsePromotion, _ int) string {
+					return fmt.Sprintf(
+						"%s/%s (%s -> %s)",
+						item.TenantID, item.CredentialID, item.FromRole.String(), item.ToRole.String(),
+					)
+				},
+			)
+
+			return fmt.Sprintf("%d waitlisted credential(s) promoted %v", len(promoted), promoted), nil
+		},
+		logger,
+	)
+
+	listener, server, err := startServer(
+		config.App.Server.Port,
+		grpc.ChainUnaryInterceptor(handlers.NewTenantInterceptor(config.App.Tenancy.DefaultTenant)),
+	)
 	if err != nil {
 		logger.Log(formatters.NewError(err, "start server"), loggers.LogLevelFatal)
 	}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+
+	anovelgrpc "github.com/a-novel/golib/grpc"
+)
+
+// startServer mirrors anovelgrpc.StartServer, with support for server options such as interceptors.
+func startServer(port int, options ...grpc.ServerOption) (net.Listener, *grpc.Server, error) {
+	// Prevent accidental misconfigurations.
+	if port == 0 {
+		return nil, nil, anovelgrpc.ErrPortRequired
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, nil, fmt.Errorf("listen: %w", err)
+	}
+
+	return listener, grpc.NewServer(options...), nil
+}
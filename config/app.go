@@ -2,8 +2,11 @@ package config
 
 import (
 	_ "embed"
+	"time"
 
 	"github.com/a-novel/golib/deploy"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
 )
 
 //go:embed app.yaml
@@ -16,6 +19,45 @@ type AppType struct {
 	Postgres struct {
 		DSN string `yaml:"dsn"`
 	} `yaml:"postgres"`
+	Tenancy struct {
+		// DefaultTenant is used for requests that do not specify a tenant. Leave empty to require one.
+		DefaultTenant string `yaml:"defaultTenant"`
+	} `yaml:"tenancy"`
+	Emails struct {
+		// Domains restricts the email domains credentials can sign up or change their email with.
+		Domains entities.EmailDomainPolicy `yaml:"domains"`
+		// UniqueCanonical rejects emails that are a variant of the email of other credentials, such as plus-tagged
+		// addresses.
+		UniqueCanonical bool `yaml:"uniqueCanonical"`
+	} `yaml:"emails"`
+	Roles struct {
+		// Capacity caps the number of credentials holding each role. Roles left out are not limited.
+		Capacity entities.RoleCapacities `yaml:"capacity"`
+		// Privileged roles can only be set through a role change request, approved by a second actor.
+		Privileged []entities.Role `yaml:"privileged"`
+		// Protected roles must keep at least one user holding them, so the tenant is never left without admins.
+		Protected []entities.Role `yaml:"protected"`
+		// Assignment gives a default role to credentials, based on their email.
+		Assignment entities.RoleRules `yaml:"assignment"`
+	} `yaml:"roles"`
+	Jobs struct {
+		SweepExpiredTokens struct {
+			Interval time.Duration `yaml:"interval"`
+		} `yaml:"sweepExpiredTokens"`
+		EraseScheduledCredentials struct {
+			Interval time.Duration `yaml:"interval"`
+		} `yaml:"eraseScheduledCredentials"`
+		RevertExpiredRoleGrants struct {
+			Interval time.Duration `yaml:"interval"`
+		} `yaml:"revertExpiredRoleGrants"`
+		PromoteWaitlistedCredentials struct {
+			Interval time.Duration `yaml:"interval"`
+		} `yaml:"promoteWaitlistedCredentials"`
+	} `yaml:"jobs"`
+	Backfills struct {
+		// BatchSize is the number of credentials each backfill updates per transaction, when the server starts.
+		BatchSize int `yaml:"batchSize"`
+	} `yaml:"backfills"`
 }
 
 var App = deploy.LoadConfig[AppType](
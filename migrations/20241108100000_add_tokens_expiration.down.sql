@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS credentials_email_validation_token_expires_at_idx;
+
+--bun:split
+
+DROP INDEX IF EXISTS credentials_pending_email_validation_token_expires_at_idx;
+
+--bun:split
+
+DROP INDEX IF EXISTS credentials_password_token_expires_at_idx;
+
+--bun:split
+
+DROP INDEX IF EXISTS credentials_reset_password_token_expires_at_idx;
+
+--bun:split
+
+ALTER TABLE credentials
+    DROP COLUMN IF EXISTS email_validation_token_expires_at,
+    DROP COLUMN IF EXISTS pending_email_validation_token_expires_at,
+    DROP COLUMN IF EXISTS password_token_expires_at,
+    DROP COLUMN IF EXISTS reset_password_token_expires_at;
@@ -0,0 +1,30 @@
+ALTER TABLE credentials
+    ADD COLUMN email_validation_token_expires_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN pending_email_validation_token_expires_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN password_token_expires_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN reset_password_token_expires_at TIMESTAMP WITH TIME ZONE;
+
+--bun:split
+
+-- Speed up the sweeper, that only looks for tokens with an expiration date.
+CREATE INDEX credentials_email_validation_token_expires_at_idx
+    ON credentials (email_validation_token_expires_at)
+    WHERE email_validation_token_expires_at IS NOT NULL;
+
+--bun:split
+
+CREATE INDEX credentials_pending_email_validation_token_expires_at_idx
+    ON credentials (pending_email_validation_token_expires_at)
+    WHERE pending_email_validation_token_expires_at IS NOT NULL;
+
+--bun:split
+
+CREATE INDEX credentials_password_token_expires_at_idx
+    ON credentials (password_token_expires_at)
+    WHERE password_token_expires_at IS NOT NULL;
+
+--bun:split
+
+CREATE INDEX credentials_reset_password_token_expires_at_idx
+    ON credentials (reset_password_token_expires_at)
+    WHERE reset_password_token_expires_at IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS credentials_labels_idx;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS labels;
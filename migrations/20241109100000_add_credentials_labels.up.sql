@@ -0,0 +1,6 @@
+ALTER TABLE credentials ADD COLUMN labels JSONB;
+
+--bun:split
+
+-- jsonb_path_ops only supports the containment operator (@>), which is the one used by label selectors.
+CREATE INDEX credentials_labels_idx ON credentials USING GIN (labels jsonb_path_ops);
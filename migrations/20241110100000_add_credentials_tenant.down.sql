@@ -0,0 +1,22 @@
+DROP POLICY IF EXISTS credentials_tenant_isolation ON credentials;
+
+--bun:split
+
+ALTER TABLE credentials NO FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credentials DISABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credentials DROP CONSTRAINT IF EXISTS credentials_tenant_id_email_key;
+
+--bun:split
+
+-- Fails if the same email is used in multiple tenants.
+ALTER TABLE credentials ADD CONSTRAINT credentials_email_key UNIQUE (email);
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS tenant_id;
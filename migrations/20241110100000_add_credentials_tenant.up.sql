@@ -0,0 +1,38 @@
+-- Existing credentials belong to the default tenant.
+ALTER TABLE credentials ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+
+--bun:split
+
+-- New rows must explicitly set their tenant.
+ALTER TABLE credentials ALTER COLUMN tenant_id DROP DEFAULT;
+
+--bun:split
+
+ALTER TABLE credentials DROP CONSTRAINT credentials_email_key;
+
+--bun:split
+
+ALTER TABLE credentials ADD CONSTRAINT credentials_tenant_id_email_key UNIQUE (tenant_id, email);
+
+--bun:split
+
+-- Defense in depth: rows are only visible to sessions that declared their tenant (app.tenant_id), or explicitly
+-- opted out of isolation (app.all_tenants). Isolation is forced on the table owner as well, but superusers and
+-- roles with BYPASSRLS still ignore it, so the service must not connect with such a role in production.
+ALTER TABLE credentials ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credentials FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credentials_tenant_isolation ON credentials
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,36 @@
+CREATE TABLE credential_identities (
+    tenant_id TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    subject TEXT NOT NULL,
+
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+
+    email_at_link_time TEXT,
+    linked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    PRIMARY KEY (tenant_id, provider, subject)
+);
+
+--bun:split
+
+CREATE INDEX credential_identities_credential_id_idx ON credential_identities (credential_id);
+
+--bun:split
+
+ALTER TABLE credential_identities ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_identities FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_identities_tenant_isolation ON credential_identities
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS credential_factors;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS mfa_required;
@@ -0,0 +1,54 @@
+ALTER TABLE credentials ADD COLUMN mfa_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+--bun:split
+
+CREATE TABLE credential_factors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+
+    type TEXT NOT NULL CHECK (type IN ('totp', 'webauthn', 'recovery_codes')),
+    label TEXT NOT NULL,
+
+    secret_ref TEXT,
+
+    webauthn_credential_id TEXT,
+    webauthn_public_key BYTEA,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+
+    UNIQUE (credential_id, label),
+    -- Each type only carries the data it needs.
+    CHECK (
+        (type = 'webauthn' AND webauthn_credential_id IS NOT NULL AND webauthn_public_key IS NOT NULL AND secret_ref IS NULL)
+        OR (type <> 'webauthn' AND secret_ref IS NOT NULL AND webauthn_credential_id IS NULL AND webauthn_public_key IS NULL)
+    )
+);
+
+--bun:split
+
+-- WebAuthn assertions only carry the credential ID, which must resolve to a single factor.
+CREATE UNIQUE INDEX credential_factors_webauthn_credential_id_idx
+    ON credential_factors (tenant_id, webauthn_credential_id)
+    WHERE webauthn_credential_id IS NOT NULL;
+
+--bun:split
+
+ALTER TABLE credential_factors ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_factors FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_factors_tenant_isolation ON credential_factors
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,33 @@
+DROP TABLE IF EXISTS credential_api_keys;
+
+--bun:split
+
+DELETE FROM credentials WHERE kind = 'service';
+
+--bun:split
+
+DROP INDEX IF EXISTS credentials_tenant_id_service_name_key;
+
+--bun:split
+
+ALTER TABLE credentials DROP CONSTRAINT IF EXISTS credentials_kind_check;
+
+--bun:split
+
+ALTER TABLE credentials ALTER COLUMN email SET NOT NULL;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS owner_id;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS name;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS kind;
+
+--bun:split
+
+DROP TYPE IF EXISTS credentials_kind;
@@ -0,0 +1,76 @@
+CREATE TYPE credentials_kind AS ENUM (
+    'user',
+    'service'
+);
+
+--bun:split
+
+ALTER TABLE credentials ADD COLUMN kind credentials_kind NOT NULL DEFAULT 'user';
+
+--bun:split
+
+ALTER TABLE credentials ADD COLUMN name TEXT;
+
+--bun:split
+
+-- Owners must hand their service accounts over before being deleted.
+ALTER TABLE credentials ADD COLUMN owner_id UUID REFERENCES credentials(id) ON DELETE RESTRICT;
+
+--bun:split
+
+ALTER TABLE credentials ALTER COLUMN email DROP NOT NULL;
+
+--bun:split
+
+ALTER TABLE credentials ADD CONSTRAINT credentials_kind_check CHECK (
+    (kind = 'user' AND email IS NOT NULL AND name IS NULL AND owner_id IS NULL)
+    OR (kind = 'service' AND email IS NULL AND name IS NOT NULL AND owner_id IS NOT NULL)
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX credentials_tenant_id_service_name_key ON credentials (tenant_id, name) WHERE kind = 'service';
+
+--bun:split
+
+CREATE TABLE credential_api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+
+    prefix TEXT NOT NULL,
+    -- Only the hash of the key is stored, the key itself is only known to its holder.
+    secret_hash BYTEA NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    UNIQUE (tenant_id, prefix)
+);
+
+--bun:split
+
+CREATE INDEX credential_api_keys_credential_id_idx ON credential_api_keys (credential_id);
+
+--bun:split
+
+ALTER TABLE credential_api_keys ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_api_keys FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_api_keys_tenant_isolation ON credential_api_keys
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1 @@
+ALTER TABLE credentials ADD COLUMN token_epoch BIGINT NOT NULL DEFAULT 0;
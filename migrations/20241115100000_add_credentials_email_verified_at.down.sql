@@ -0,0 +1,5 @@
+DROP INDEX credentials_email_verified_at_idx;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN email_verified_at;
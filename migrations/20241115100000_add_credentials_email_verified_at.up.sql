@@ -0,0 +1,24 @@
+ALTER TABLE credentials ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+
+--bun:split
+
+-- Let the owner running the migration backfill every tenant at once.
+ALTER TABLE credentials NO FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+-- Backfill: an email validation token is issued when user credentials are created, and cleared once consumed. Users
+-- without a pending validation token are therefore considered verified. The exact time of the verification was not
+-- recorded, so the last update of the credentials is used as the closest approximation.
+UPDATE credentials
+    SET email_verified_at = COALESCE(updated_at, created_at)
+    WHERE kind = 'user'
+    AND (email_validation_token_id IS NULL OR email_validation_token_id = '');
+
+--bun:split
+
+ALTER TABLE credentials FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE INDEX credentials_email_verified_at_idx ON credentials (tenant_id, (email_verified_at IS NULL));
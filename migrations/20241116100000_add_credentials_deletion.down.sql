@@ -0,0 +1,9 @@
+DROP TABLE credential_deletions;
+
+--bun:split
+
+DROP INDEX credentials_deletion_scheduled_at_idx;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN deletion_scheduled_at;
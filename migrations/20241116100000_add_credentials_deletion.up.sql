@@ -0,0 +1,42 @@
+ALTER TABLE credentials ADD COLUMN deletion_scheduled_at TIMESTAMP WITH TIME ZONE;
+
+--bun:split
+
+CREATE INDEX credentials_deletion_scheduled_at_idx ON credentials (deletion_scheduled_at)
+    WHERE deletion_scheduled_at IS NOT NULL;
+
+--bun:split
+
+-- No foreign key: records must outlive the credentials they refer to.
+CREATE TABLE credential_deletions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL,
+
+    scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    erased_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX credential_deletions_credential_id_idx ON credential_deletions (tenant_id, credential_id);
+
+--bun:split
+
+ALTER TABLE credential_deletions ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_deletions FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_deletions_tenant_isolation ON credential_deletions
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
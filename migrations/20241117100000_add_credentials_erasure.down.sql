@@ -0,0 +1,5 @@
+DROP TABLE credential_erasures;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN erased_at;
@@ -0,0 +1,42 @@
+ALTER TABLE credentials ADD COLUMN erased_at TIMESTAMP WITH TIME ZONE;
+
+--bun:split
+
+-- No foreign key: records must outlive the credentials they refer to.
+CREATE TABLE credential_erasures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL,
+
+    reason TEXT,
+    erased_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+--bun:split
+
+COMMENT ON TABLE credential_erasures IS
+    'Proof of GDPR erasures. Excluded from history retention: rows must never be purged.';
+
+--bun:split
+
+CREATE INDEX credential_erasures_credential_id_idx ON credential_erasures (tenant_id, credential_id);
+
+--bun:split
+
+ALTER TABLE credential_erasures ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_erasures FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_erasures_tenant_isolation ON credential_erasures
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,21 @@
+DROP INDEX credentials_legal_hold_idx;
+
+--bun:split
+
+ALTER TABLE credentials DROP CONSTRAINT credentials_legal_hold_check;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN legal_hold_set_at;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN legal_hold_set_by;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN legal_hold_reason;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN legal_hold;
@@ -0,0 +1,25 @@
+ALTER TABLE credentials ADD COLUMN legal_hold BOOLEAN NOT NULL DEFAULT FALSE;
+
+--bun:split
+
+ALTER TABLE credentials ADD COLUMN legal_hold_reason TEXT;
+
+--bun:split
+
+ALTER TABLE credentials ADD COLUMN legal_hold_set_by TEXT;
+
+--bun:split
+
+ALTER TABLE credentials ADD COLUMN legal_hold_set_at TIMESTAMP WITH TIME ZONE;
+
+--bun:split
+
+-- A hold must always be justified, and attributed to whoever set it.
+ALTER TABLE credentials ADD CONSTRAINT credentials_legal_hold_check CHECK (
+    NOT legal_hold
+    OR (legal_hold_reason IS NOT NULL AND legal_hold_set_by IS NOT NULL AND legal_hold_set_at IS NOT NULL)
+);
+
+--bun:split
+
+CREATE INDEX credentials_legal_hold_idx ON credentials (tenant_id) WHERE legal_hold;
@@ -0,0 +1,43 @@
+CREATE TABLE credential_consents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+
+    document TEXT NOT NULL CHECK (document IN ('tos', 'privacy', 'marketing')),
+    version TEXT NOT NULL,
+
+    accepted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    withdrawn_at TIMESTAMP WITH TIME ZONE
+);
+
+--bun:split
+
+-- A version can only be accepted once at a time. The index also serves the lookup of credentials missing a
+-- consent, which must stay fast on large tenants.
+CREATE UNIQUE INDEX credential_consents_active_idx
+    ON credential_consents (tenant_id, document, version, credential_id)
+    WHERE withdrawn_at IS NULL;
+
+--bun:split
+
+CREATE INDEX credential_consents_credential_id_idx ON credential_consents (credential_id);
+
+--bun:split
+
+ALTER TABLE credential_consents ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_consents FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_consents_tenant_isolation ON credential_consents
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
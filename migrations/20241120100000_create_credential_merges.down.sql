@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS credential_merges;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS deleted_at;
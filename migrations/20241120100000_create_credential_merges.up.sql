@@ -0,0 +1,38 @@
+ALTER TABLE credentials ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+--bun:split
+
+-- Merged credentials keep their row, so their ID and email resolve to the surviving credentials.
+CREATE TABLE credential_merges (
+    loser_id UUID PRIMARY KEY REFERENCES credentials(id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    survivor_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+
+    merged_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CHECK (loser_id <> survivor_id)
+);
+
+--bun:split
+
+CREATE INDEX credential_merges_survivor_id_idx ON credential_merges (survivor_id);
+
+--bun:split
+
+ALTER TABLE credential_merges ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_merges FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_merges_tenant_isolation ON credential_merges
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,36 @@
+CREATE TABLE credential_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+
+    author TEXT NOT NULL,
+    body TEXT NOT NULL,
+    pinned BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+--bun:split
+
+CREATE INDEX credential_notes_credential_id_idx ON credential_notes (credential_id);
+
+--bun:split
+
+ALTER TABLE credential_notes ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_notes FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_notes_tenant_isolation ON credential_notes
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS credential_role_changes;
+
+--bun:split
+
+DROP INDEX IF EXISTS credentials_role_expires_at_idx;
+
+--bun:split
+
+ALTER TABLE credentials DROP CONSTRAINT IF EXISTS credentials_role_grant_check;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS fallback_role;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN IF EXISTS role_expires_at;
@@ -0,0 +1,54 @@
+ALTER TABLE credentials ADD COLUMN role_expires_at TIMESTAMP WITH TIME ZONE;
+
+--bun:split
+
+ALTER TABLE credentials ADD COLUMN fallback_role credentials_role;
+
+--bun:split
+
+-- A temporary role always knows what to revert to.
+ALTER TABLE credentials ADD CONSTRAINT credentials_role_grant_check CHECK (
+    (role_expires_at IS NULL) = (fallback_role IS NULL)
+);
+
+--bun:split
+
+CREATE INDEX credentials_role_expires_at_idx ON credentials (role_expires_at) WHERE role_expires_at IS NOT NULL;
+
+--bun:split
+
+CREATE TABLE credential_role_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+
+    from_role credentials_role NOT NULL,
+    to_role credentials_role NOT NULL,
+    reason TEXT NOT NULL,
+
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+--bun:split
+
+CREATE INDEX credential_role_changes_credential_id_idx ON credential_role_changes (credential_id);
+
+--bun:split
+
+ALTER TABLE credential_role_changes ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE credential_role_changes FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY credential_role_changes_tenant_isolation ON credential_role_changes
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS invitation_redemptions;
+
+--bun:split
+
+DROP TABLE IF EXISTS invitations;
@@ -0,0 +1,72 @@
+CREATE TABLE invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+
+    code TEXT NOT NULL,
+    role credentials_role NOT NULL,
+    max_uses INTEGER NOT NULL CHECK (max_uses > 0),
+    uses INTEGER NOT NULL DEFAULT 0 CHECK (uses >= 0 AND uses <= max_uses),
+
+    created_by UUID NOT NULL REFERENCES credentials(id) ON DELETE RESTRICT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    UNIQUE (tenant_id, code)
+);
+
+--bun:split
+
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY invitations_tenant_isolation ON invitations
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
+
+--bun:split
+
+-- A code can only be redeemed once by the same credentials.
+CREATE TABLE invitation_redemptions (
+    invitation_id UUID NOT NULL REFERENCES invitations(id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+
+    redeemed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    PRIMARY KEY (invitation_id, credential_id)
+);
+
+--bun:split
+
+CREATE INDEX invitation_redemptions_credential_id_idx ON invitation_redemptions (credential_id);
+
+--bun:split
+
+ALTER TABLE invitation_redemptions ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE invitation_redemptions FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY invitation_redemptions_tenant_isolation ON invitation_redemptions
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,36 @@
+CREATE TABLE role_waitlist (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+    role credentials_role NOT NULL,
+
+    -- Orders the waitlist: lower values were enqueued first.
+    seq BIGINT GENERATED BY DEFAULT AS IDENTITY,
+    enqueued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    UNIQUE (credential_id, role)
+);
+
+--bun:split
+
+CREATE INDEX role_waitlist_tenant_id_role_seq_idx ON role_waitlist (tenant_id, role, seq);
+
+--bun:split
+
+ALTER TABLE role_waitlist ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE role_waitlist FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY role_waitlist_tenant_isolation ON role_waitlist
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,47 @@
+CREATE TABLE role_change_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+    credential_id UUID NOT NULL REFERENCES credentials(id) ON DELETE CASCADE,
+    role credentials_role NOT NULL,
+
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'expired')),
+
+    proposed_by UUID NOT NULL REFERENCES credentials(id) ON DELETE RESTRICT,
+    decided_by UUID REFERENCES credentials(id) ON DELETE RESTRICT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    decided_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- A request is approved by a different actor than the one who proposed it.
+    CHECK (status <> 'approved' OR decided_by <> proposed_by),
+    CHECK ((status = 'pending') = (decided_at IS NULL))
+);
+
+--bun:split
+
+-- Credentials can only have one pending request at a time.
+CREATE UNIQUE INDEX role_change_requests_pending_idx ON role_change_requests (credential_id) WHERE status = 'pending';
+
+--bun:split
+
+CREATE INDEX role_change_requests_tenant_id_created_at_idx ON role_change_requests (tenant_id, created_at);
+
+--bun:split
+
+ALTER TABLE role_change_requests ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE role_change_requests FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY role_change_requests_tenant_isolation ON role_change_requests
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
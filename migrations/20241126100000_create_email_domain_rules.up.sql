@@ -0,0 +1,37 @@
+CREATE TABLE email_domain_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id TEXT NOT NULL,
+
+    -- Domains are stored in lowercase, so lookups can compare them exactly.
+    domain TEXT NOT NULL CHECK (domain = lower(domain)),
+    action TEXT NOT NULL CHECK (action IN ('allow', 'deny')),
+    reason TEXT,
+
+    set_by TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+--bun:split
+
+CREATE UNIQUE INDEX email_domain_rules_tenant_id_domain_idx ON email_domain_rules (tenant_id, domain);
+
+--bun:split
+
+ALTER TABLE email_domain_rules ENABLE ROW LEVEL SECURITY;
+
+--bun:split
+
+ALTER TABLE email_domain_rules FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+CREATE POLICY email_domain_rules_tenant_isolation ON email_domain_rules
+    USING (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    )
+    WITH CHECK (
+        tenant_id = current_setting('app.tenant_id', true)
+        OR current_setting('app.all_tenants', true) = 'on'
+    );
@@ -0,0 +1,5 @@
+DROP INDEX credentials_canonical_email_idx;
+
+--bun:split
+
+ALTER TABLE credentials DROP COLUMN canonical_email;
@@ -0,0 +1,41 @@
+ALTER TABLE credentials ADD COLUMN canonical_email TEXT;
+
+--bun:split
+
+-- Let the owner running the migration backfill every tenant at once.
+ALTER TABLE credentials NO FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+-- Backfill: mirrors entities.CanonicalEmail. Postgres cannot convert IDN domains to punycode, so emails with non-ASCII
+-- characters are left out, and backfilled by the server when it starts (see dao.BackfillCanonicalEmails). Erased
+-- credentials only keep a placeholder email, and have no canonical form.
+UPDATE credentials
+    SET canonical_email = canonical.local || '@' || canonical.domain
+    FROM (
+        SELECT
+            id,
+            CASE WHEN domain IN ('gmail.com', 'googlemail.com') THEN replace(local, '.', '') ELSE local END AS local,
+            CASE WHEN domain = 'googlemail.com' THEN 'gmail.com' ELSE domain END AS domain
+        FROM (
+            SELECT
+                id,
+                lower(substring(email FROM '[^@]*$')) AS domain,
+                regexp_replace(lower(substring(email FROM '^(.*)@')), '^([^+]+)\+.*$', '\1') AS local
+            FROM credentials
+            WHERE kind = 'user'
+            AND erased_at IS NULL
+            AND email ~ '^[[:ascii:]]+@[[:ascii:]]+$'
+        ) AS parts
+    ) AS canonical
+    WHERE credentials.id = canonical.id;
+
+--bun:split
+
+ALTER TABLE credentials FORCE ROW LEVEL SECURITY;
+
+--bun:split
+
+-- Only active credentials can collide, since erased and merged credentials cannot sign in anymore.
+CREATE INDEX credentials_canonical_email_idx ON credentials (tenant_id, canonical_email)
+    WHERE canonical_email IS NOT NULL AND erased_at IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,10 @@
+ALTER TABLE invitations DROP CONSTRAINT IF EXISTS invitations_created_by_fkey;
+
+--bun:split
+
+ALTER TABLE invitations ADD CONSTRAINT invitations_created_by_fkey
+    FOREIGN KEY (created_by) REFERENCES credentials(id) ON DELETE RESTRICT;
+
+--bun:split
+
+ALTER TABLE invitations ALTER COLUMN created_by SET NOT NULL;
@@ -0,0 +1,11 @@
+-- Invitations outlive the credentials that issued them, so removing the issuer must not be blocked.
+ALTER TABLE invitations ALTER COLUMN created_by DROP NOT NULL;
+
+--bun:split
+
+ALTER TABLE invitations DROP CONSTRAINT invitations_created_by_fkey;
+
+--bun:split
+
+ALTER TABLE invitations ADD CONSTRAINT invitations_created_by_fkey
+    FOREIGN KEY (created_by) REFERENCES credentials(id) ON DELETE SET NULL;
@@ -0,0 +1,19 @@
+ALTER TABLE role_change_requests DROP CONSTRAINT IF EXISTS role_change_requests_decided_by_fkey;
+
+--bun:split
+
+ALTER TABLE role_change_requests ADD CONSTRAINT role_change_requests_decided_by_fkey
+    FOREIGN KEY (decided_by) REFERENCES credentials(id) ON DELETE RESTRICT;
+
+--bun:split
+
+ALTER TABLE role_change_requests DROP CONSTRAINT IF EXISTS role_change_requests_proposed_by_fkey;
+
+--bun:split
+
+ALTER TABLE role_change_requests ADD CONSTRAINT role_change_requests_proposed_by_fkey
+    FOREIGN KEY (proposed_by) REFERENCES credentials(id) ON DELETE RESTRICT;
+
+--bun:split
+
+ALTER TABLE role_change_requests ALTER COLUMN proposed_by SET NOT NULL;
@@ -0,0 +1,20 @@
+-- Requests outlive the credentials that proposed or decided them, so removing those credentials must not be blocked.
+ALTER TABLE role_change_requests ALTER COLUMN proposed_by DROP NOT NULL;
+
+--bun:split
+
+ALTER TABLE role_change_requests DROP CONSTRAINT role_change_requests_proposed_by_fkey;
+
+--bun:split
+
+ALTER TABLE role_change_requests ADD CONSTRAINT role_change_requests_proposed_by_fkey
+    FOREIGN KEY (proposed_by) REFERENCES credentials(id) ON DELETE SET NULL;
+
+--bun:split
+
+ALTER TABLE role_change_requests DROP CONSTRAINT role_change_requests_decided_by_fkey;
+
+--bun:split
+
+ALTER TABLE role_change_requests ADD CONSTRAINT role_change_requests_decided_by_fkey
+    FOREIGN KEY (decided_by) REFERENCES credentials(id) ON DELETE SET NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS credentials_canonical_email_missing_idx;
@@ -0,0 +1,4 @@
+-- The server backfills the canonical email of these credentials when it starts. Indexing only them keeps each start
+-- from scanning every credential once the backfill is done.
+CREATE INDEX credentials_canonical_email_missing_idx ON credentials (id)
+    WHERE kind = 'user' AND erased_at IS NULL AND canonical_email IS NULL;
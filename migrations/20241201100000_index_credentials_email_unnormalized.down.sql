@@ -0,0 +1 @@
+DROP INDEX IF EXISTS credentials_email_unnormalized_idx;
@@ -0,0 +1,5 @@
+-- The server normalizes the email of these credentials when it starts. Indexing only them keeps each start from
+-- scanning every credential once they are normalized. Mirrors dao.unnormalizedEmailCondition.
+CREATE INDEX credentials_email_unnormalized_idx ON credentials (id)
+    WHERE kind = 'user' AND erased_at IS NULL
+    AND (email ~ '[^[:ascii:]]' OR substring(email FROM '[^@]*$') ~ '[A-Z]');
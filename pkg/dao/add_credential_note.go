@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type AddCredentialNoteRequest struct {
+	CredentialID uuid.UUID
+	Author       string
+	Body         string
+	Pinned       bool
+}
+
+type AddCredentialNote interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *AddCredentialNoteRequest,
+	) (*entities.CredentialNote, error)
+}
+
+type addCredentialNoteImpl struct {
+	database bun.IDB
+}
+
+func (dao *addCredentialNoteImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *AddCredentialNoteRequest,
+) (*entities.CredentialNote, error) {
+	model := &entities.CredentialNote{
+		ID:           id,
+		CredentialID: request.CredentialID,
+		Author:       request.Author,
+		Body:         request.Body,
+		Pinned:       request.Pinned,
+		CreatedAt:    now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		// Foreign keys ignore tenant isolation, so the credentials must be checked explicitly. Erased credentials
+		// cannot get new notes, as erasure removed the previous ones.
+		exists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("erased_at IS NULL").
+			For("SHARE").
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check credentials existence: %w", err)
+		}
+
+		if !exists {
+			return ErrCredentialsNotFound
+		}
+
+		_, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewAddCredentialNote(database bun.IDB) AddCredentialNote {
+	return &addCredentialNoteImpl{database: database}
+}
@@ -0,0 +1,175 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestAddCredentialNote(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "refund issued",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "suspected bot",
+			Pinned:       true,
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "other-agent@example.com",
+			Body:         "asked for a callback",
+			CreatedAt:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000004"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Author:       "agent@example.com",
+			Body:         "vip",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:         uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:   "default",
+			Email:      "erased-0003@erased.invalid",
+			TokenEpoch: 1,
+			ErasedAt:   lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.AddCredentialNoteRequest
+
+		expect    *entities.CredentialNote
+		expectErr error
+	}{
+		{
+			name: "Add",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.AddCredentialNoteRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+				Pinned:       true,
+			},
+
+			expect: &entities.CredentialNote{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+				Pinned:       true,
+				CreatedAt:    time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Erased",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.AddCredentialNoteRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.AddCredentialNoteRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.AddCredentialNoteRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			addCredentialNoteDAO := dao.NewAddCredentialNote(transaction)
+
+			note, err := addCredentialNoteDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, note)
+		})
+	}
+}
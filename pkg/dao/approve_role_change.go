@@ -0,0 +1,152 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ApproveRoleChangeRequest struct {
+	ApprovedBy uuid.UUID
+}
+
+type ApproveRoleChange interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *ApproveRoleChangeRequest,
+	) (*entities.RoleChangeRequest, error)
+}
+
+type approveRoleChangeImpl struct {
+	database   bun.IDB
+	capacities entities.RoleCapacities
+	// privileged roles are the only ones allowed to decide role change requests.
+	privileged []entities.Role
+	// protected roles must keep at least one holder.
+	protected []entities.Role
+}
+
+// Exec approves a pending role change request, and applies the role to the credentials in the same transaction. The
+// approver must hold a privileged role, and differ from both the proposer and the credentials concerned. The new role
+// is permanent, and ends any temporary grant.
+func (dao *approveRoleChangeImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *ApproveRoleChangeRequest,
+) (*entities.RoleChangeRequest, error) {
+	var model *entities.RoleChangeRequest
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		var err error
+
+		if model, err = lockPendingRoleChangeRequest(ctx, tx, tenantID, id, now); err != nil {
+			return err
+		}
+
+		if request.ApprovedBy == model.ProposedBy || request.ApprovedBy == model.CredentialID {
+			return ErrRoleChangeSelfApproval
+		}
+
+		if err = checkRoleChangeDecider(ctx, tx, tenantID, dao.privileged, request.ApprovedBy, now); err != nil {
+			return err
+		}
+
+		credential := new(entities.Credential)
+
+		err = tx.NewSelect().
+			Model(credential).
+			Column("id", "kind", "role", "fallback_role").
+			Where("id = ?", model.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		if permanentRole(credential) != model.Role {
+			if err = checkLastRoleHolder(ctx, tx, tenantID, dao.protected, credential); err != nil {
+				return err
+			}
+		}
+
+		err = reassignSeats(ctx, tx, tenantID, dao.capacities, now, heldRoles(credential), []entities.Role{model.Role})
+		if err != nil {
+			return err
+		}
+
+		fromRole := credential.Role
+
+		_, err = tx.NewUpdate().
+			Model((*entities.Credential)(nil)).
+			Set("role = ?", model.Role).
+			Set("role_expires_at = NULL").
+			Set("fallback_role = NULL").
+			Set("updated_at = ?", now).
+			Where("id = ?", model.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("update credentials: %w", err)
+		}
+
+		if fromRole != model.Role {
+			_, err = tx.NewInsert().
+				Model(&entities.CredentialRoleChange{
+					ID:           uuid.New(),
+					TenantID:     tenantID,
+					CredentialID: model.CredentialID,
+					FromRole:     fromRole,
+					ToRole:       model.Role,
+					Reason:       entities.RoleChangeReasonRequestApproved,
+					ChangedAt:    now,
+				}).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("record role change: %w", err)
+			}
+		}
+
+		model.Status = entities.RoleChangeRequestStatusApproved
+		model.DecidedBy = &request.ApprovedBy
+		model.DecidedAt = &now
+
+		_, err = tx.NewUpdate().
+			Model(model).
+			Column("status", "decided_by", "decided_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewApproveRoleChange(
+	database bun.IDB, capacities entities.RoleCapacities, privileged, protected []entities.Role,
+) ApproveRoleChange {
+	return &approveRoleChangeImpl{
+		database:   database,
+		capacities: capacities,
+		privileged: privileged,
+		protected:  protected,
+	}
+}
@@ -0,0 +1,333 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestApproveRoleChange(t *testing.T) {
+	fixtures := []interface{}{
+		// Proposer.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Approver.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Temporary admin, that reverts to the early access program.
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:      "default",
+			Email:         "email-3",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Regular user, without a privileged role.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "email-4",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleCore,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleCore,
+			Status:       entities.RoleChangeRequestStatusRejected,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000002")),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			DecidedAt:    lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Role:         entities.RoleCore,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			ExpiresAt:    time.Date(2021, 1, 25, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000005"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000099"),
+			TenantID:     "tenant-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id        uuid.UUID
+		now       time.Time
+		request   *dao.ApproveRoleChangeRequest
+		protected []entities.Role
+		// credentialID is the credentials whose role is checked. It defaults to the temporary admin.
+		credentialID uuid.UUID
+
+		expect     *entities.RoleChangeRequest
+		expectRole entities.Role
+		expectErr  error
+	}{
+		{
+			name: "Approve",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expect: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Role:         entities.RoleCore,
+				Status:       entities.RoleChangeRequestStatusApproved,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000002")),
+				ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+				DecidedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+			},
+			expectRole: entities.RoleCore,
+		},
+		{
+			// The temporary admin is the last holder of the early access program, its permanent role.
+			name: "LastProtectedRoleHolder",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+			protected: []entities.Role{entities.RoleEarlyAccessProgram},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrLastProtectedRoleHolder,
+		},
+		{
+			name: "NotPrivileged",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrRoleChangeNotPrivileged,
+		},
+		{
+			name: "SelfApproval",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrRoleChangeSelfApproval,
+		},
+		{
+			name: "SelfApproval/Beneficiary",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrRoleChangeSelfApproval,
+		},
+		{
+			name: "Decided",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrRoleChangeRequestClosed,
+		},
+		{
+			name: "Expired",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000003"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrRoleChangeRequestClosed,
+		},
+		{
+			// A legal hold only freezes the deletion and the email of the credentials.
+			name: "LegalHold",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000005"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+
+			expect: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000005"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusApproved,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000002")),
+				ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+				DecidedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+			},
+			expectRole: entities.RoleAdmin,
+		},
+		{
+			name: "ApproverInOtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrRoleChangeRequestNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000099"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectRole: entities.RoleAdmin,
+			expectErr:  dao.ErrRoleChangeRequestNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			approveRoleChangeDAO := dao.NewApproveRoleChange(
+				transaction, nil, []entities.Role{entities.RoleAdmin, entities.RoleCore}, testCase.protected,
+			)
+
+			ctx := entities.ContextWithTenant(context.Background(), "default")
+			request, err := approveRoleChangeDAO.Exec(ctx, testCase.id, testCase.now, testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, request)
+
+			credentialID := testCase.credentialID
+			if credentialID == uuid.Nil {
+				credentialID = uuid.MustParse("00000000-0000-0000-0000-000000000003")
+			}
+
+			credential := new(entities.Credential)
+			require.NoError(t, transaction.NewSelect().
+				Model(credential).
+				Where("id = ?", credentialID).
+				Scan(ctx))
+			require.Equal(t, testCase.expectRole, credential.Role)
+			// An approved role is permanent.
+			require.Equal(t, testCase.expect == nil, credential.RoleExpiresAt != nil)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type BackfillCanonicalEmails interface {
+	Exec(ctx context.Context, limit int) ([]*entities.Credential, error)
+}
+
+type backfillCanonicalEmailsImpl struct {
+	database bun.IDB
+}
+
+// Exec computes the canonical email of up to limit users that have none, and returns them. The migration that added
+// canonical emails could not convert IDN domains to punycode, so it left these users out. Only those users are
+// selected, through the credentials_canonical_email_missing_idx index.
+func (dao *backfillCanonicalEmailsImpl) Exec(ctx context.Context, limit int) ([]*entities.Credential, error) {
+	credentials := make([]*entities.Credential, 0)
+
+	// Canonical emails do not depend on the tenant, so every tenant is processed at once.
+	err := runAcrossTenants(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		// Rows locked by a concurrent update are picked up on the next batch, unless the update already set them.
+		err := tx.NewSelect().
+			Model(&credentials).
+			Column("id", "tenant_id", "email").
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where("canonical_email IS NULL").
+			Order("id ASC").
+			Limit(limit).
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		if len(credentials) == 0 {
+			return nil
+		}
+
+		for _, credential := range credentials {
+			credential.CanonicalEmail = entities.CanonicalEmail(credential.Email)
+		}
+
+		if _, err = tx.NewUpdate().Model(&credentials).Column("canonical_email").Bulk().Exec(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func NewBackfillCanonicalEmails(database bun.IDB) BackfillCanonicalEmails {
+	return &backfillCanonicalEmailsImpl{database: database}
+}
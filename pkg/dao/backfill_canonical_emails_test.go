@@ -0,0 +1,133 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestBackfillCanonicalEmails(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "Jane.Doe+news@Bücher.example",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "tenant-2",
+			Email:     "jöhn@gmail.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Already canonicalized.
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:       "default",
+			Email:          "john@example.com",
+			CanonicalEmail: "john@example.com",
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "erased-0004@erased.invalid",
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		limit int
+
+		expect          []*entities.Credential
+		expectCanonical map[uuid.UUID]string
+		expectErr       error
+	}{
+		{
+			name: "Backfill",
+
+			limit: 10,
+
+			expect: []*entities.Credential{
+				{
+					ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TenantID:       "default",
+					Email:          "Jane.Doe+news@Bücher.example",
+					CanonicalEmail: "jane.doe@xn--bcher-kva.example",
+				},
+				{
+					ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					TenantID:       "tenant-2",
+					Email:          "jöhn@gmail.com",
+					CanonicalEmail: "jöhn@gmail.com",
+				},
+			},
+			expectCanonical: map[uuid.UUID]string{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"): "jane.doe@xn--bcher-kva.example",
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"): "jöhn@gmail.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"): "john@example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"): "",
+			},
+		},
+		{
+			name: "Batch",
+
+			limit: 1,
+
+			expect: []*entities.Credential{
+				{
+					ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TenantID:       "default",
+					Email:          "Jane.Doe+news@Bücher.example",
+					CanonicalEmail: "jane.doe@xn--bcher-kva.example",
+				},
+			},
+			expectCanonical: map[uuid.UUID]string{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"): "jane.doe@xn--bcher-kva.example",
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"): "",
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"): "john@example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"): "",
+			},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			backfillCanonicalEmailsDAO := dao.NewBackfillCanonicalEmails(transaction)
+
+			credentials, err := backfillCanonicalEmailsDAO.Exec(context.Background(), testCase.limit)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credentials)
+
+			stored := make([]*entities.Credential, 0)
+			require.NoError(t, transaction.NewSelect().Model(&stored).Column("id", "canonical_email").Scan(
+				context.Background(),
+			))
+			require.Equal(t, testCase.expectCanonical, lo.SliceToMap(
+				stored,
+				func(item *entities.Credential) (uuid.UUID, string) { return item.ID, item.CanonicalEmail },
+			))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type CancelAccountDeletion interface {
+	Exec(ctx context.Context, id uuid.UUID, now time.Time) (*entities.Credential, error)
+}
+
+type cancelAccountDeletionImpl struct {
+	database bun.IDB
+}
+
+// Exec cancels a pending deletion. Once the grace period is over, the deletion can no longer be canceled, even if
+// the credentials have not been erased yet.
+func (dao *cancelAccountDeletionImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time,
+) (*entities.Credential, error) {
+	model := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		res, err := tx.
+			NewUpdate().
+			Model(model).
+			Set("deletion_scheduled_at = NULL").
+			Set("updated_at = ?", now).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Where("deletion_scheduled_at > ?", now).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows > 0 {
+			return nil
+		}
+
+		exists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check credentials existence: %w", err)
+		}
+
+		if !exists {
+			return ErrCredentialsNotFound
+		}
+
+		return ErrDeletionNotScheduled
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewCancelAccountDeletion(database bun.IDB) CancelAccountDeletion {
+	return &cancelAccountDeletionImpl{database: database}
+}
@@ -0,0 +1,44 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// checkCanonicalEmail ensures no other active credentials of the tenant share the canonical email, when uniqueness
+// is enforced. The canonical email stays locked until the end of the transaction, so it cannot be taken concurrently.
+func checkCanonicalEmail(
+	ctx context.Context, tx bun.Tx, tenantID string, unique bool, id uuid.UUID, canonical string,
+) error {
+	if !unique || canonical == "" {
+		return nil
+	}
+
+	lockKey := tenantID + "/canonical_email/" + canonical
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", lockKey); err != nil {
+		return fmt.Errorf("lock canonical email: %w", err)
+	}
+
+	exists, err := tx.NewSelect().
+		Model((*entities.Credential)(nil)).
+		Where("tenant_id = ?", tenantID).
+		Where("canonical_email = ?", canonical).
+		Where("id <> ?", id).
+		Where("erased_at IS NULL").
+		Where("deleted_at IS NULL").
+		Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("select canonical email: %w", err)
+	}
+
+	if exists {
+		return fmt.Errorf("%w: '%s'", ErrCanonicalEmailTaken, canonical)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ConsumeTokenRequest struct {
+	Field entities.TokenField
+	Value string
+}
+
+type ConsumeToken interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *ConsumeTokenRequest,
+	) (*entities.Credential, error)
+}
+
+type consumeTokenImpl struct {
+	database bun.IDB
+}
+
+func (dao *consumeTokenImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *ConsumeTokenRequest,
+) (*entities.Credential, error) {
+	column, ok := getTokenColumn(request.Field)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", entities.ErrUnknownTokenField, request.Field)
+	}
+
+	model := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		// The token is only cleared if it still holds the expected value, so concurrent requests cannot consume
+		// the same token twice. Tokens left on erased or merged credentials cannot be consumed.
+		query := tx.
+			NewUpdate().
+			Model(model).
+			Set("? = NULL", column.id).
+			Set("? = NULL", column.expiresAt).
+			Set("updated_at = ?", now).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Where("deleted_at IS NULL").
+			Where("erased_at IS NULL").
+			Where("? = ?", column.id, request.Value).
+			Where("(? IS NULL OR ? > ?)", column.expiresAt, column.expiresAt, now)
+
+		// Consuming the email validation token proves the ownership of the email.
+		if request.Field == entities.TokenFieldEmailValidation {
+			query = query.Set("email_verified_at = COALESCE(email_verified_at, ?)", now)
+		}
+
+		res, err := query.Returning("*").Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows > 0 {
+			return nil
+		}
+
+		exists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Where("deleted_at IS NULL").
+			Where("erased_at IS NULL").
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check credentials existence: %w", err)
+		}
+
+		if !exists {
+			return ErrCredentialsNotFound
+		}
+
+		return ErrTokenMismatch
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewConsumeToken(database bun.IDB) ConsumeToken {
+	return &consumeTokenImpl{database: database}
+}
@@ -0,0 +1,276 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestConsumeToken(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:                      "default",
+			Email:                         "email-1",
+			Role:                          entities.RoleCore,
+			EmailValidationTokenID:        "email-validation-token-id",
+			PendingEmailValidationTokenID: "pending-email-validation-token-id",
+			PasswordTokenID:               "password-token-id",
+			ResetPasswordTokenID:          "reset-password-token-id",
+			ResetPasswordTokenExpiresAt:   lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:                     uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:               "default",
+			Email:                  "email-deleted",
+			EmailValidationTokenID: "deleted-email-validation-token-id",
+			DeletedAt:              lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:                     uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:               "default",
+			Email:                  "erased-0004@erased.invalid",
+			EmailValidationTokenID: "erased-email-validation-token-id",
+			ErasedAt:               lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.ConsumeTokenRequest
+
+		expect    *entities.Credential
+		expectErr error
+	}{
+		{
+			name: "ResetPassword",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldResetPassword,
+				Value: "reset-password-token-id",
+			},
+
+			expect: &entities.Credential{
+				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                      "default",
+				Email:                         "email-1",
+				Role:                          entities.RoleCore,
+				EmailValidationTokenID:        "email-validation-token-id",
+				PendingEmailValidationTokenID: "pending-email-validation-token-id",
+				PasswordTokenID:               "password-token-id",
+				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "PendingEmailValidation",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldPendingEmailValidation,
+				Value: "pending-email-validation-token-id",
+			},
+
+			expect: &entities.Credential{
+				ID:                          uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                    "default",
+				Email:                       "email-1",
+				Role:                        entities.RoleCore,
+				EmailValidationTokenID:      "email-validation-token-id",
+				PasswordTokenID:             "password-token-id",
+				ResetPasswordTokenID:        "reset-password-token-id",
+				ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                   lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "EmailValidation",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldEmailValidation,
+				Value: "email-validation-token-id",
+			},
+
+			expect: &entities.Credential{
+				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                      "default",
+				Email:                         "email-1",
+				EmailVerifiedAt:               lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+				Role:                          entities.RoleCore,
+				PendingEmailValidationTokenID: "pending-email-validation-token-id",
+				PasswordTokenID:               "password-token-id",
+				ResetPasswordTokenID:          "reset-password-token-id",
+				ResetPasswordTokenExpiresAt:   lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Mismatch",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldEmailValidation,
+				Value: "reset-password-token-id",
+			},
+
+			expectErr: dao.ErrTokenMismatch,
+		},
+		{
+			name: "Expired",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldResetPassword,
+				Value: "reset-password-token-id",
+			},
+
+			expectErr: dao.ErrTokenMismatch,
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldResetPassword,
+				Value: "reset-password-token-id",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "Deleted",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldEmailValidation,
+				Value: "deleted-email-validation-token-id",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "Erased",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldEmailValidation,
+				Value: "erased-email-validation-token-id",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenFieldResetPassword,
+				Value: "reset-password-token-id",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "UnknownField",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.ConsumeTokenRequest{
+				Field: entities.TokenField("fake-field"),
+				Value: "reset-password-token-id",
+			},
+
+			expectErr: entities.ErrUnknownTokenField,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			consumeTokenDAO := dao.NewConsumeToken(transaction)
+
+			credential, err := consumeTokenDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+		})
+	}
+}
+
+func TestConsumeTokenTwice(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:                   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:             "default",
+			Email:                "email-1",
+			ResetPasswordTokenID: "reset-password-token-id",
+			CreatedAt:            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	consumeTokenDAO := dao.NewConsumeToken(transaction)
+
+	request := &dao.ConsumeTokenRequest{
+		Field: entities.TokenFieldResetPassword,
+		Value: "reset-password-token-id",
+	}
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	_, err = consumeTokenDAO.Exec(entities.ContextWithTenant(context.Background(), "default"), id, now, request)
+	require.NoError(t, err)
+
+	_, err = consumeTokenDAO.Exec(entities.ContextWithTenant(context.Background(), "default"), id, now, request)
+	require.ErrorIs(t, err, dao.ErrTokenMismatch)
+}
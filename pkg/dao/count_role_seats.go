@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type CountRoleSeats interface {
+	Exec(ctx context.Context) ([]*entities.RoleSeats, error)
+}
+
+type countRoleSeatsImpl struct {
+	database   bun.IDB
+	capacities entities.RoleCapacities
+}
+
+// Exec returns the occupation of every limited role, and of any other role credentials are still waiting for. Roles
+// are sorted from the lowest to the highest.
+func (dao *countRoleSeatsImpl) Exec(ctx context.Context) ([]*entities.RoleSeats, error) {
+	seats := make([]*entities.RoleSeats, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		var waitlisted []entities.Role
+
+		err := tx.NewSelect().
+			Model((*entities.RoleWaitlistEntry)(nil)).
+			Column("role").
+			Distinct().
+			Where("tenant_id = ?", tenantID).
+			Scan(ctx, &waitlisted)
+		if err != nil {
+			return fmt.Errorf("select waitlisted roles: %w", err)
+		}
+
+		for _, role := range entities.RolesSorted {
+			capacity, limited := dao.capacities.Limit(role)
+			if !limited && !lo.Contains(waitlisted, role) {
+				continue
+			}
+
+			used, err := countRoleHolders(ctx, tx, tenantID, role)
+			if err != nil {
+				return err
+			}
+
+			waiting, err := tx.NewSelect().
+				Model((*entities.RoleWaitlistEntry)(nil)).
+				Where("tenant_id = ?", tenantID).
+				Where("role = ?", role).
+				Count(ctx)
+			if err != nil {
+				return fmt.Errorf("count waitlist: %w", err)
+			}
+
+			seats = append(seats, &entities.RoleSeats{
+				Role:     role,
+				Capacity: capacity,
+				Used:     used,
+				Waiting:  waiting,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return seats, nil
+}
+
+func NewCountRoleSeats(database bun.IDB, capacities entities.RoleCapacities) CountRoleSeats {
+	return &countRoleSeatsImpl{database: database, capacities: capacities}
+}
@@ -0,0 +1,95 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestCountRoleSeats(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleEarlyAccessProgram,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Keeps the seat of its fallback role.
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:      "default",
+			Email:         "email-2",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Erased credentials release their seat.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "erased-0004@erased.invalid",
+			Role:      entities.RoleEarlyAccessProgram,
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleEarlyAccessProgram,
+			EnqueuedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleCore,
+			EnqueuedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	countRoleSeatsDAO := dao.NewCountRoleSeats(transaction, entities.RoleCapacities{
+		entities.RoleEarlyAccessProgram: 2,
+		entities.RoleAdmin:              5,
+	})
+
+	seats, err := countRoleSeatsDAO.Exec(entities.ContextWithTenant(context.Background(), "default"))
+	require.NoError(t, err)
+	require.Equal(t, []*entities.RoleSeats{
+		{Role: entities.RoleEarlyAccessProgram, Capacity: 2, Used: 2, Waiting: 1},
+		{Role: entities.RoleAdmin, Capacity: 5, Used: 1},
+		{Role: entities.RoleCore, Waiting: 1},
+	}, seats)
+}
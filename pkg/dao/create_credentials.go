@@ -14,11 +14,21 @@ import (
 )
 
 type CreateCredentialsRequest struct {
-	Email                  string
-	Role                   entities.Role
+	Email string
+	Role  entities.Role
+	// RoleFromRule tells the role was assigned by a role rule, rather than requested. Such a role falls back to no
+	// role once it is at capacity, instead of failing the signup.
+	RoleFromRule bool
+
+	Labels                 map[string]string
+	MFARequired            bool
 	EmailValidationTokenID string
 	PasswordTokenID        string
 	ResetPasswordTokenID   string
+
+	EmailValidationTokenExpiresAt *time.Time
+	PasswordTokenExpiresAt        *time.Time
+	ResetPasswordTokenExpiresAt   *time.Time
 }
 
 type CreateCredentials interface {
@@ -28,7 +38,11 @@ type CreateCredentials interface {
 }
 
 type createCredentialsImpl struct {
-	database bun.IDB
+	database   bun.IDB
+	capacities entities.RoleCapacities
+	domains    entities.EmailDomainPolicy
+	// uniqueCanonical rejects emails that are a variant of the email of other credentials.
+	uniqueCanonical bool
 }
 
 func (dao *createCredentialsImpl) Exec(
@@ -37,26 +51,67 @@ func (dao *createCredentialsImpl) Exec(
 	model := &entities.Credential{
 		ID:                     id,
 		Email:                  request.Email,
+		CanonicalEmail:         entities.CanonicalEmail(request.Email),
 		Role:                   request.Role,
+		Labels:                 request.Labels,
+		MFARequired:            request.MFARequired,
 		EmailValidationTokenID: request.EmailValidationTokenID,
 		PasswordTokenID:        request.PasswordTokenID,
 		ResetPasswordTokenID:   request.ResetPasswordTokenID,
-		CreatedAt:              now,
+
+		EmailValidationTokenExpiresAt: request.EmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:        request.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:   request.ResetPasswordTokenExpiresAt,
+
+		CreatedAt: now,
 	}
 
-	_, err := dao.database.NewInsert().Model(model).Returning("*").Exec(ctx)
-	if err != nil {
-		var pgErr pgdriver.Error
-		if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
-			return nil, ErrCredentialsAlreadyExist
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		if err := checkEmailDomain(ctx, tx, tenantID, dao.domains, model.Email); err != nil {
+			return err
+		}
+
+		err := checkCanonicalEmail(ctx, tx, tenantID, dao.uniqueCanonical, model.ID, model.CanonicalEmail)
+		if err != nil {
+			return err
 		}
 
-		return nil, fmt.Errorf("exec query: %w", err)
+		err = checkRoleCapacity(ctx, tx, tenantID, dao.capacities, model.Role)
+		switch {
+		case errors.Is(err, ErrRoleCapacityReached) && request.RoleFromRule:
+			model.Role = entities.RoleNone
+		case err != nil:
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx)
+		if err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrCredentialsAlreadyExist
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return model, nil
 }
 
-func NewCreateCredentials(database bun.IDB) CreateCredentials {
-	return &createCredentialsImpl{database: database}
+func NewCreateCredentials(
+	database bun.IDB, capacities entities.RoleCapacities, domains entities.EmailDomainPolicy, uniqueCanonical bool,
+) CreateCredentials {
+	return &createCredentialsImpl{
+		database:        database,
+		capacities:      capacities,
+		domains:         domains,
+		uniqueCanonical: uniqueCanonical,
+	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
 	anoveldb "github.com/a-novel/golib/database"
@@ -19,6 +20,7 @@ func TestCreateCredentials(t *testing.T) {
 	fixtures := []interface{}{
 		&entities.Credential{
 			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:                      "default",
 			Email:                         "email-1",
 			Role:                          entities.RoleCore,
 			EmailValidationTokenID:        "email-validation-token-id",
@@ -27,6 +29,36 @@ func TestCreateCredentials(t *testing.T) {
 			ResetPasswordTokenID:          "reset-password-token-id",
 			CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 		},
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:       "default",
+			Email:          "John.Doe@gmail.com",
+			CanonicalEmail: "johndoe@gmail.com",
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+			TenantID:  "default",
+			Domain:    "partner.mailinator.com",
+			Action:    entities.EmailDomainActionAllow,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000099"),
+			TenantID:  "tenant-2",
+			Domain:    "example.com",
+			Action:    entities.EmailDomainActionDeny,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	testCases := []struct {
@@ -36,6 +68,9 @@ func TestCreateCredentials(t *testing.T) {
 		now time.Time
 
 		request *dao.CreateCredentialsRequest
+		domains entities.EmailDomainPolicy
+		// uniqueCanonical enforces the uniqueness of canonical emails.
+		uniqueCanonical bool
 
 		expect    *entities.Credential
 		expectErr error
@@ -56,7 +91,9 @@ func TestCreateCredentials(t *testing.T) {
 
 			expect: &entities.Credential{
 				ID:                     uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:               "default",
 				Email:                  "email-2",
+				CanonicalEmail:         "email-2",
 				Role:                   entities.RoleAdmin,
 				EmailValidationTokenID: "email-validation-token-id",
 				PasswordTokenID:        "password-token-id",
@@ -64,6 +101,36 @@ func TestCreateCredentials(t *testing.T) {
 				CreatedAt:              time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
 			},
 		},
+		{
+			name: "Create/TokensExpiration",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email:                         "email-2",
+				Role:                          entities.RoleAdmin,
+				EmailValidationTokenID:        "email-validation-token-id",
+				PasswordTokenID:               "password-token-id",
+				ResetPasswordTokenID:          "reset-password-token-id",
+				EmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				ResetPasswordTokenExpiresAt:   lo.ToPtr(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &entities.Credential{
+				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:                      "default",
+				Email:                         "email-2",
+				CanonicalEmail:                "email-2",
+				Role:                          entities.RoleAdmin,
+				EmailValidationTokenID:        "email-validation-token-id",
+				PasswordTokenID:               "password-token-id",
+				ResetPasswordTokenID:          "reset-password-token-id",
+				EmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				ResetPasswordTokenExpiresAt:   lo.ToPtr(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                     time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
 		{
 			name: "Create/Minimal",
 
@@ -75,10 +142,54 @@ func TestCreateCredentials(t *testing.T) {
 			},
 
 			expect: &entities.Credential{
-				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
-				Email:     "email-2",
-				Role:      entities.RoleNone,
-				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:       "default",
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleNone,
+				CreatedAt:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/Labels",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email:  "email-2",
+				Labels: map[string]string{"source": "ads", "cohort": "beta-1"},
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:       "default",
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleNone,
+				Labels:         map[string]string{"source": "ads", "cohort": "beta-1"},
+				CreatedAt:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/MFARequired",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email:       "email-2",
+				MFARequired: true,
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:       "default",
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleNone,
+				MFARequired:    true,
+				CreatedAt:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
 			},
 		},
 		{
@@ -93,6 +204,170 @@ func TestCreateCredentials(t *testing.T) {
 
 			expectErr: dao.ErrCredentialsAlreadyExist,
 		},
+		{
+			name: "Create/RoleCapacityReached",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "email-2",
+				Role:  entities.RoleCore,
+			},
+
+			expectErr: dao.ErrRoleCapacityReached,
+		},
+		{
+			name: "Create/RoleCapacityReached/FromRule",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email:        "email-2",
+				Role:         entities.RoleCore,
+				RoleFromRule: true,
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:       "default",
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleNone,
+				CreatedAt:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/CanonicalEmailTaken",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "johndoe+promo@googlemail.com",
+			},
+			uniqueCanonical: true,
+
+			expectErr: dao.ErrCanonicalEmailTaken,
+		},
+		{
+			name: "Create/CanonicalEmailTaken/NotEnforced",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "johndoe+promo@googlemail.com",
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:       "default",
+				Email:          "johndoe+promo@googlemail.com",
+				CanonicalEmail: "johndoe@gmail.com",
+				Role:           entities.RoleNone,
+				CreatedAt:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/EmailDomainDenied",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "jane@spam.example.com",
+			},
+			domains: entities.EmailDomainPolicy{Deny: []string{"example.com"}},
+
+			expectErr: dao.ErrEmailDomainNotAllowed,
+		},
+		{
+			name: "Create/EmailDomainNotAllowed",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "jane@example.org",
+			},
+			domains: entities.EmailDomainPolicy{AllowlistOnly: true, Allow: []string{"example.com"}},
+
+			expectErr: dao.ErrEmailDomainNotAllowed,
+		},
+		{
+			name: "Create/EmailDomainDisposable",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "jane@Mailinator.com",
+			},
+			domains: entities.EmailDomainPolicy{BlockDisposable: true},
+
+			expectErr: dao.ErrEmailDomainNotAllowed,
+		},
+		{
+			// Custom rules of the tenant take part in the policy.
+			name: "Create/EmailDomainAllowedByRule",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "jane@partner.mailinator.com",
+			},
+			domains: entities.EmailDomainPolicy{BlockDisposable: true},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:       "default",
+				Email:          "jane@partner.mailinator.com",
+				CanonicalEmail: "jane@partner.mailinator.com",
+				Role:           entities.RoleNone,
+				CreatedAt:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/EmailDomainDeniedInOtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "jane@example.com",
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:       "default",
+				Email:          "jane@example.com",
+				CanonicalEmail: "jane@example.com",
+				Role:           entities.RoleNone,
+				CreatedAt:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Create/EmailExistsInOtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			request: &dao.CreateCredentialsRequest{
+				Email: "email-other",
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:       "default",
+				Email:          "email-other",
+				CanonicalEmail: "email-other",
+				Role:           entities.RoleNone,
+				CreatedAt:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
 	}
 
 	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
@@ -104,10 +379,13 @@ func TestCreateCredentials(t *testing.T) {
 			transaction := anoveldb.BeginTestTX(database, fixtures)
 			defer anoveldb.RollbackTestTX(transaction)
 
-			createCredentialsDAO := dao.NewCreateCredentials(transaction)
+			createCredentialsDAO := dao.NewCreateCredentials(
+				transaction, entities.RoleCapacities{entities.RoleCore: 1}, testCase.domains, testCase.uniqueCanonical,
+			)
 
-			credential, err := createCredentialsDAO.
-				Exec(context.Background(), testCase.id, testCase.now, testCase.request)
+			credential, err := createCredentialsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
 
 			require.ErrorIs(t, err, testCase.expectErr)
 			require.Equal(t, testCase.expect, credential)
@@ -0,0 +1,96 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type CreateInvitationRequest struct {
+	Code      string
+	Role      entities.Role
+	MaxUses   int
+	CreatedBy uuid.UUID
+	ExpiresAt *time.Time
+}
+
+type CreateInvitation interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *CreateInvitationRequest,
+	) (*entities.Invitation, error)
+}
+
+type createInvitationImpl struct {
+	database bun.IDB
+	// privileged roles can only be set through an approved role change request, so invitations cannot grant them.
+	privileged []entities.Role
+}
+
+func (dao *createInvitationImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *CreateInvitationRequest,
+) (*entities.Invitation, error) {
+	if lo.Contains(dao.privileged, request.Role) {
+		return nil, ErrPrivilegedRoleChange
+	}
+
+	model := &entities.Invitation{
+		ID:        id,
+		Code:      request.Code,
+		Role:      request.Role,
+		MaxUses:   request.MaxUses,
+		CreatedBy: request.CreatedBy,
+		ExpiresAt: request.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		// Foreign keys ignore tenant isolation, so the creator must be checked explicitly.
+		exists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", request.CreatedBy).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			For("SHARE").
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check credentials existence: %w", err)
+		}
+
+		if !exists {
+			return ErrCredentialsNotFound
+		}
+
+		_, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx)
+		if err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrInvitationAlreadyExists
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewCreateInvitation(database bun.IDB, privileged []entities.Role) CreateInvitation {
+	return &createInvitationImpl{database: database, privileged: privileged}
+}
@@ -0,0 +1,180 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestCreateInvitation(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-2",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+			TenantID:  "default",
+			Code:      "TAKEN",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.CreateInvitationRequest
+
+		expect    *entities.Invitation
+		expectErr error
+	}{
+		{
+			name: "Create",
+
+			id:  uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateInvitationRequest{
+				Code:      "CODE",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   10,
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &entities.Invitation{
+				ID:        uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+				TenantID:  "default",
+				Code:      "CODE",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   10,
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "PrivilegedRole",
+
+			id:  uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateInvitationRequest{
+				Code:      "CODE",
+				Role:      entities.RoleAdmin,
+				MaxUses:   1,
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expectErr: dao.ErrPrivilegedRoleChange,
+		},
+		{
+			name: "CodeTaken",
+
+			id:  uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateInvitationRequest{
+				Code:      "TAKEN",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   1,
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expectErr: dao.ErrInvitationAlreadyExists,
+		},
+		{
+			name: "CreatorServiceAccount",
+
+			id:  uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateInvitationRequest{
+				Code:      "CODE",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   1,
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "CreatorNotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateInvitationRequest{
+				Code:      "CODE",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   1,
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "CreatorOtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateInvitationRequest{
+				Code:      "CODE",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   1,
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			createInvitationDAO := dao.NewCreateInvitation(
+				transaction, []entities.Role{entities.RoleAdmin, entities.RoleCore},
+			)
+
+			invitation, err := createInvitationDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, invitation)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type CreateServiceAccountRequest struct {
+	Name    string
+	OwnerID uuid.UUID
+	Role    entities.Role
+	Labels  map[string]string
+}
+
+type CreateServiceAccount interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *CreateServiceAccountRequest,
+	) (*entities.Credential, error)
+}
+
+type createServiceAccountImpl struct {
+	database bun.IDB
+}
+
+func (dao *createServiceAccountImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *CreateServiceAccountRequest,
+) (*entities.Credential, error) {
+	model := &entities.Credential{
+		ID:        id,
+		Kind:      entities.CredentialKindService,
+		Name:      request.Name,
+		OwnerID:   request.OwnerID,
+		Role:      request.Role,
+		Labels:    request.Labels,
+		CreatedAt: now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		// Foreign keys ignore tenant isolation, so the owner must be checked explicitly.
+		ownerExists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", request.OwnerID).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			For("SHARE").
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check owner existence: %w", err)
+		}
+
+		if !ownerExists {
+			return ErrInvalidOwner
+		}
+
+		_, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx)
+		if err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrCredentialsAlreadyExist
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewCreateServiceAccount(database bun.IDB) CreateServiceAccount {
+	return &createServiceAccountImpl{database: database}
+}
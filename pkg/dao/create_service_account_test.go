@@ -0,0 +1,146 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestCreateServiceAccount(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-1",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.CreateServiceAccountRequest
+
+		expect    *entities.Credential
+		expectErr error
+	}{
+		{
+			name: "Create",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateServiceAccountRequest{
+				Name:    "service-2",
+				OwnerID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:    entities.RoleCore,
+				Labels:  map[string]string{"team": "billing"},
+			},
+
+			expect: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+				TenantID:  "default",
+				Kind:      entities.CredentialKindService,
+				Name:      "service-2",
+				OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:      entities.RoleCore,
+				Labels:    map[string]string{"team": "billing"},
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "NameTaken",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateServiceAccountRequest{
+				Name:    "service-1",
+				OwnerID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expectErr: dao.ErrCredentialsAlreadyExist,
+		},
+		{
+			name: "OwnerNotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateServiceAccountRequest{
+				Name:    "service-2",
+				OwnerID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+
+			expectErr: dao.ErrInvalidOwner,
+		},
+		{
+			name: "OwnerIsServiceAccount",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateServiceAccountRequest{
+				Name:    "service-2",
+				OwnerID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrInvalidOwner,
+		},
+		{
+			name: "OwnerInOtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.CreateServiceAccountRequest{
+				Name:    "service-2",
+				OwnerID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			},
+
+			expectErr: dao.ErrInvalidOwner,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			createServiceAccountDAO := dao.NewCreateServiceAccount(transaction)
+
+			credential, err := createServiceAccountDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type DeleteCredentialNoteRequest struct {
+	CredentialID uuid.UUID
+}
+
+type DeleteCredentialNote interface {
+	Exec(ctx context.Context, id uuid.UUID, request *DeleteCredentialNoteRequest) error
+}
+
+type deleteCredentialNoteImpl struct {
+	database bun.IDB
+}
+
+func (dao *deleteCredentialNoteImpl) Exec(
+	ctx context.Context, id uuid.UUID, request *DeleteCredentialNoteRequest,
+) error {
+	return runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		res, err := tx.NewDelete().
+			Model((*entities.CredentialNote)(nil)).
+			Where("id = ?", id).
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return ErrNoteNotFound
+		}
+
+		return nil
+	})
+}
+
+func NewDeleteCredentialNote(database bun.IDB) DeleteCredentialNote {
+	return &deleteCredentialNoteImpl{database: database}
+}
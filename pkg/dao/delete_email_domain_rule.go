@@ -0,0 +1,47 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type DeleteEmailDomainRule interface {
+	Exec(ctx context.Context, domain string) error
+}
+
+type deleteEmailDomainRuleImpl struct {
+	database bun.IDB
+}
+
+// Exec removes the custom rule of a domain. The configured policy still applies to the domain afterward.
+func (dao *deleteEmailDomainRuleImpl) Exec(ctx context.Context, domain string) error {
+	return runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		res, err := tx.NewDelete().
+			Model((*entities.EmailDomainRule)(nil)).
+			Where("domain = ?", domain).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return ErrEmailDomainRuleNotFound
+		}
+
+		return nil
+	})
+}
+
+func NewDeleteEmailDomainRule(database bun.IDB) DeleteEmailDomainRule {
+	return &deleteEmailDomainRuleImpl{database: database}
+}
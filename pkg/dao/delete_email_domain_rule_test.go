@@ -0,0 +1,84 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestDeleteEmailDomainRule(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+			TenantID:  "default",
+			Domain:    "spam.example.com",
+			Action:    entities.EmailDomainActionDeny,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000099"),
+			TenantID:  "tenant-2",
+			Domain:    "partner.example.com",
+			Action:    entities.EmailDomainActionDeny,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		domain string
+
+		expectErr error
+	}{
+		{
+			name: "Delete",
+
+			domain: "spam.example.com",
+		},
+		{
+			name: "NotFound",
+
+			domain: "example.com",
+
+			expectErr: dao.ErrEmailDomainRuleNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			domain: "partner.example.com",
+
+			expectErr: dao.ErrEmailDomainRuleNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			deleteEmailDomainRuleDAO := dao.NewDeleteEmailDomainRule(transaction)
+
+			err := deleteEmailDomainRuleDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.domain,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type EditCredentialNoteRequest struct {
+	CredentialID uuid.UUID
+	Body         string
+	Pinned       bool
+}
+
+type EditCredentialNote interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *EditCredentialNoteRequest,
+	) (*entities.CredentialNote, error)
+}
+
+type editCredentialNoteImpl struct {
+	database bun.IDB
+}
+
+func (dao *editCredentialNoteImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *EditCredentialNoteRequest,
+) (*entities.CredentialNote, error) {
+	model := new(entities.CredentialNote)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		res, err := tx.NewUpdate().
+			Model(model).
+			Set("body = ?", request.Body).
+			Set("pinned = ?", request.Pinned).
+			Set("updated_at = ?", now).
+			Where("id = ?", id).
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return ErrNoteNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewEditCredentialNote(database bun.IDB) EditCredentialNote {
+	return &editCredentialNoteImpl{database: database}
+}
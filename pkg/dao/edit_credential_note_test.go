@@ -0,0 +1,152 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestEditCredentialNote(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "refund issued",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "suspected bot",
+			Pinned:       true,
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "other-agent@example.com",
+			Body:         "asked for a callback",
+			CreatedAt:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000004"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Author:       "agent@example.com",
+			Body:         "vip",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.EditCredentialNoteRequest
+
+		expect    *entities.CredentialNote
+		expectErr error
+	}{
+		{
+			name: "Edit",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EditCredentialNoteRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Body:         "refund issued twice",
+				Pinned:       true,
+			},
+
+			expect: &entities.CredentialNote{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Author:       "agent@example.com",
+				Body:         "refund issued twice",
+				Pinned:       true,
+				CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:    lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "OtherCredentials",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000004"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EditCredentialNoteRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Body:         "not a vip",
+			},
+
+			expectErr: dao.ErrNoteNotFound,
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EditCredentialNoteRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Body:         "refund issued",
+			},
+
+			expectErr: dao.ErrNoteNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			editCredentialNoteDAO := dao.NewEditCredentialNote(transaction)
+
+			note, err := editCredentialNoteDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, note)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// checkEmailDomain ensures the email domain policy accepts the email, once combined with the custom rules of the
+// tenant.
+func checkEmailDomain(
+	ctx context.Context, tx bun.Tx, tenantID string, policy entities.EmailDomainPolicy, email string,
+) error {
+	domain := entities.EmailDomain(email)
+	rules := make([]*entities.EmailDomainRule, 0)
+
+	err := tx.NewSelect().
+		Model(&rules).
+		Where("tenant_id = ?", tenantID).
+		Where("domain IN (?)", bun.In(entities.DomainSuffixes(domain))).
+		Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("select email domain rules: %w", err)
+	}
+
+	if !policy.Accepts(domain, rules) {
+		return fmt.Errorf("%w: '%s'", ErrEmailDomainNotAllowed, domain)
+	}
+
+	return nil
+}
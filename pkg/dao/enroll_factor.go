@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type EnrollFactorRequest struct {
+	CredentialID uuid.UUID
+	Type         entities.FactorType
+	Label        string
+
+	SecretRef string
+
+	WebAuthnCredentialID string
+	WebAuthnPublicKey    []byte
+}
+
+type EnrollFactor interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *EnrollFactorRequest,
+	) (*entities.CredentialFactor, error)
+}
+
+type enrollFactorImpl struct {
+	database bun.IDB
+}
+
+func (dao *enrollFactorImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *EnrollFactorRequest,
+) (*entities.CredentialFactor, error) {
+	model := &entities.CredentialFactor{
+		ID:                   id,
+		CredentialID:         request.CredentialID,
+		Type:                 request.Type,
+		Label:                request.Label,
+		SecretRef:            request.SecretRef,
+		WebAuthnCredentialID: request.WebAuthnCredentialID,
+		WebAuthnPublicKey:    request.WebAuthnPublicKey,
+		CreatedAt:            now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		// Foreign keys ignore tenant isolation, so the credentials must be checked explicitly.
+		exists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			For("SHARE").
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check credentials existence: %w", err)
+		}
+
+		if !exists {
+			return ErrCredentialsNotFound
+		}
+
+		_, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx)
+		if err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrFactorAlreadyExists
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewEnrollFactor(database bun.IDB) EnrollFactor {
+	return &enrollFactorImpl{database: database}
+}
@@ -0,0 +1,148 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type EraseCredentialsRequest struct {
+	// ErasureID identifies the record of the erasure.
+	ErasureID uuid.UUID
+	// Placeholder replaces the email of users, or the name of service accounts.
+	Placeholder string
+	Reason      string
+}
+
+type EraseCredentials interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *EraseCredentialsRequest,
+	) (*entities.Credential, error)
+}
+
+type eraseCredentialsImpl struct {
+	database bun.IDB
+	// protected roles must keep at least one holder.
+	protected []entities.Role
+}
+
+// Exec erases the personal data of the credentials, and keeps them as a tombstone. Erasing credentials twice is a
+// no-op, that returns the existing tombstone.
+func (dao *eraseCredentialsImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *EraseCredentialsRequest,
+) (*entities.Credential, error) {
+	model := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.NewSelect().
+			Model(model).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("lock credentials: %w", err)
+		}
+
+		if model.ErasedAt != nil {
+			return nil
+		}
+
+		if model.LegalHold {
+			return ErrCredentialsOnLegalHold
+		}
+
+		// Credentials pending deletion were already checked when their deletion was requested.
+		if model.DeletionScheduledAt == nil && model.DeletedAt == nil {
+			if err = checkLastRoleHolder(ctx, tx, tenantID, dao.protected, model); err != nil {
+				return err
+			}
+		}
+
+		return eraseCredential(ctx, tx, model, now, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// eraseCredential erases the personal data of the locked credentials, turns them into a tombstone, and records the
+// erasure.
+func eraseCredential(
+	ctx context.Context, tx bun.Tx, model *entities.Credential, now time.Time, request *EraseCredentialsRequest,
+) error {
+	// Linked identities, factors, API keys and notes all hold personal data or secrets.
+	for _, child := range []interface{}{
+		(*entities.CredentialIdentity)(nil),
+		(*entities.CredentialFactor)(nil),
+		(*entities.APIKey)(nil),
+		(*entities.CredentialNote)(nil),
+	} {
+		_, err := tx.NewDelete().
+			Model(child).
+			Where("credential_id = ?", model.ID).
+			Where("tenant_id = ?", model.TenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete %T: %w", child, err)
+		}
+	}
+
+	query := tx.NewUpdate().
+		Model(model).
+		Set("email = CASE WHEN kind = 'user' THEN ? END", request.Placeholder+"@"+entities.ErasedEmailDomain).
+		Set("name = CASE WHEN kind = 'service' THEN ? END", request.Placeholder).
+		Set("canonical_email = NULL").
+		Set("email_verified_at = NULL").
+		Set("labels = NULL").
+		Set("mfa_required = FALSE").
+		Set("deletion_scheduled_at = NULL").
+		// Invalidate every session issued for the credentials.
+		Set("token_epoch = token_epoch + 1").
+		Set("erased_at = ?", now).
+		Set("updated_at = ?", now)
+
+	for _, column := range tokenColumns {
+		query = query.Set("? = NULL", column.id).Set("? = NULL", column.expiresAt)
+	}
+
+	err := query.
+		Where("id = ?", model.ID).
+		Where("tenant_id = ?", model.TenantID).
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("exec query: %w", err)
+	}
+
+	erasure := &entities.CredentialErasure{
+		ID:           request.ErasureID,
+		TenantID:     model.TenantID,
+		CredentialID: model.ID,
+		Reason:       request.Reason,
+		ErasedAt:     now,
+	}
+
+	if _, err = tx.NewInsert().Model(erasure).Exec(ctx); err != nil {
+		return fmt.Errorf("record erasure: %w", err)
+	}
+
+	return nil
+}
+
+func NewEraseCredentials(database bun.IDB, protected []entities.Role) EraseCredentials {
+	return &eraseCredentialsImpl{database: database, protected: protected}
+}
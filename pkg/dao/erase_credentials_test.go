@@ -0,0 +1,288 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestEraseCredentials(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:                     uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:               "default",
+			Email:                  "email-1",
+			Role:                   entities.RoleCore,
+			EmailVerifiedAt:        lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			Labels:                 map[string]string{"team": "core"},
+			MFARequired:            true,
+			TokenEpoch:             2,
+			PasswordTokenID:        "password-token-1",
+			ResetPasswordTokenID:   "reset-password-token-1",
+			EmailValidationTokenID: "email-validation-token-1",
+			DeletionScheduledAt:    lo.ToPtr(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "github",
+			Subject:      "subject-1",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:         entities.FactorTypeTOTP,
+			Label:        "phone",
+			SecretRef:    "secret-ref-1",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("00000000-0000-0000-0004-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "refund issued",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-2",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.APIKey{
+			ID:           uuid.MustParse("00000000-0000-0000-0002-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Prefix:       "prefix-1",
+			SecretHash:   []byte("secret-hash-1"),
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Already erased.
+		&entities.Credential{
+			ID:         uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:   "default",
+			Email:      "erased-0003@erased.invalid",
+			TokenEpoch: 1,
+			ErasedAt:   lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:  lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			TenantID:  "default",
+			Email:     "email-admin",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id        uuid.UUID
+		now       time.Time
+		request   *dao.EraseCredentialsRequest
+		protected []entities.Role
+
+		expect         *entities.Credential
+		expectErasures int
+		expectErr      error
+	}{
+		{
+			name: "User",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EraseCredentialsRequest{
+				ErasureID:   uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+				Placeholder: "erased-0001",
+				Reason:      "ticket-1",
+			},
+			// Credentials pending deletion no longer count as holders of their role.
+			protected: []entities.Role{entities.RoleCore},
+
+			expect: &entities.Credential{
+				ID:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:   "default",
+				Email:      "erased-0001@erased.invalid",
+				Role:       entities.RoleCore,
+				TokenEpoch: 3,
+				ErasedAt:   lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:  lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			expectErasures: 1,
+		},
+		{
+			name: "ServiceAccount",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EraseCredentialsRequest{
+				ErasureID:   uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+				Placeholder: "erased-0002",
+			},
+
+			expect: &entities.Credential{
+				ID:         uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:   "default",
+				Kind:       entities.CredentialKindService,
+				Name:       "erased-0002",
+				OwnerID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TokenEpoch: 1,
+				ErasedAt:   lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:  lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			expectErasures: 1,
+		},
+		{
+			name: "AlreadyErased",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EraseCredentialsRequest{
+				ErasureID:   uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+				Placeholder: "erased-new",
+			},
+
+			expect: &entities.Credential{
+				ID:         uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				TenantID:   "default",
+				Email:      "erased-0003@erased.invalid",
+				TokenEpoch: 1,
+				ErasedAt:   lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:  lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EraseCredentialsRequest{
+				ErasureID:   uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+				Placeholder: "erased-0004",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "LegalHold",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EraseCredentialsRequest{
+				ErasureID:   uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+				Placeholder: "erased-0005",
+			},
+
+			expectErr: dao.ErrCredentialsOnLegalHold,
+		},
+		{
+			name: "LastProtectedRoleHolder",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EraseCredentialsRequest{
+				ErasureID:   uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+				Placeholder: "erased-0006",
+			},
+			protected: []entities.Role{entities.RoleAdmin},
+
+			expectErr: dao.ErrLastProtectedRoleHolder,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.EraseCredentialsRequest{
+				ErasureID:   uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+				Placeholder: "erased-0099",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			eraseCredentialsDAO := dao.NewEraseCredentials(transaction, testCase.protected)
+
+			credential, err := eraseCredentialsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+
+			erasures, err := transaction.NewSelect().
+				Model((*entities.CredentialErasure)(nil)).
+				Where("credential_id = ?", testCase.id).
+				Count(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectErasures, erasures)
+
+			if testCase.expectErasures == 0 {
+				return
+			}
+
+			// Erased credentials keep no linked data.
+			for _, child := range []interface{}{
+				(*entities.CredentialIdentity)(nil),
+				(*entities.CredentialFactor)(nil),
+				(*entities.APIKey)(nil),
+				(*entities.CredentialNote)(nil),
+			} {
+				remaining, err := transaction.NewSelect().
+					Model(child).
+					Where("credential_id = ?", testCase.id).
+					Count(context.Background())
+				require.NoError(t, err)
+				require.Zero(t, remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type EraseScheduledCredentials interface {
+	Exec(ctx context.Context, now time.Time) ([]*entities.CredentialDeletion, error)
+}
+
+type eraseScheduledCredentialsImpl struct {
+	database bun.IDB
+}
+
+// ScheduledErasureReason is recorded on the erasures of credentials whose deletion was due.
+const ScheduledErasureReason = "scheduled deletion"
+
+// Exec erases the credentials whose deletion is due, the same way EraseCredentials does, and records each deletion.
+// Credentials that still own service accounts are kept until their service accounts are handed over or erased.
+func (dao *eraseScheduledCredentialsImpl) Exec(
+	ctx context.Context, now time.Time,
+) ([]*entities.CredentialDeletion, error) {
+	deletions := make([]*entities.CredentialDeletion, 0)
+
+	// Schedules do not depend on the tenant, so every tenant is processed at once.
+	err := runAcrossTenants(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		due := make([]*entities.Credential, 0)
+
+		// Credentials locked by a concurrent update, such as a cancellation, are picked up on the next run.
+		err := tx.NewSelect().
+			Model(&due).
+			Where("deletion_scheduled_at <= ?", now).
+			Where("erased_at IS NULL").
+			// Held credentials stay pending, and are erased once the hold is released.
+			Where("NOT legal_hold").
+			Where(
+				"NOT EXISTS (SELECT 1 FROM credentials AS owned " +
+					"WHERE owned.owner_id = credentials.id AND owned.erased_at IS NULL)",
+			).
+			Order("id ASC").
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		if len(due) == 0 {
+			return nil
+		}
+
+		for _, credential := range due {
+			// Erasure clears the schedule, so it is kept for the deletion record.
+			scheduledAt := lo.FromPtr(credential.DeletionScheduledAt)
+
+			placeholder, err := entities.NewErasurePlaceholder(credential.ID)
+			if err != nil {
+				return fmt.Errorf("generate placeholder: %w", err)
+			}
+
+			err = eraseCredential(ctx, tx, credential, now, &EraseCredentialsRequest{
+				ErasureID:   uuid.New(),
+				Placeholder: placeholder,
+				Reason:      ScheduledErasureReason,
+			})
+			if err != nil {
+				return fmt.Errorf("erase credentials %s: %w", credential.ID, err)
+			}
+
+			deletions = append(deletions, &entities.CredentialDeletion{
+				ID:           uuid.New(),
+				TenantID:     credential.TenantID,
+				CredentialID: credential.ID,
+				ScheduledAt:  scheduledAt,
+				ErasedAt:     now,
+			})
+		}
+
+		if _, err = tx.NewInsert().Model(&deletions).Exec(ctx); err != nil {
+			return fmt.Errorf("record deletions: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deletions, nil
+}
+
+func NewEraseScheduledCredentials(database bun.IDB) EraseScheduledCredentials {
+	return &eraseScheduledCredentialsImpl{database: database}
+}
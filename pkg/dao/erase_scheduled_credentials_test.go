@@ -0,0 +1,282 @@
+package dao_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestEraseScheduledCredentials(t *testing.T) {
+	fixtures := []interface{}{
+		// Due.
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:            "default",
+			Email:               "email-1",
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Not due yet.
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:            "default",
+			Email:               "email-2",
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 30, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Due, but still owns a service account.
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:            "default",
+			Email:               "email-3",
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-1",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Due, but on legal hold.
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:            "default",
+			Email:               "email-5",
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			LegalHold:           true,
+			LegalHoldReason:     "dispute",
+			LegalHoldSetBy:      "legal@example.com",
+			LegalHoldSetAt:      lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Due, and only owns an erased service account.
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			TenantID:            "default",
+			Email:               "email-6",
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000007"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "erased-0007",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Due, in another tenant.
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:            "tenant-2",
+			Email:               "email-other",
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	eraseScheduledCredentialsDAO := dao.NewEraseScheduledCredentials(transaction)
+
+	now := time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	deletions, err := eraseScheduledCredentialsDAO.Exec(context.Background(), now)
+	require.NoError(t, err)
+
+	// Records are identified by random IDs.
+	require.ElementsMatch(
+		t,
+		[]*entities.CredentialDeletion{
+			{
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ScheduledAt:  time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+				ErasedAt:     now,
+			},
+			{
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+				ScheduledAt:  time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+				ErasedAt:     now,
+			},
+			{
+				TenantID:     "tenant-2",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				ScheduledAt:  time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+				ErasedAt:     now,
+			},
+		},
+		lo.Map(deletions, func(item *entities.CredentialDeletion, _ int) *entities.CredentialDeletion {
+			require.NotEqual(t, uuid.Nil, item.ID)
+			item.ID = uuid.Nil
+
+			return item
+		}),
+	)
+
+	// Due credentials are kept as tombstones, with no personal data left.
+	erased := make([]*entities.Credential, 0)
+	require.NoError(
+		t,
+		transaction.NewSelect().Model(&erased).Where("erased_at IS NOT NULL").Order("id ASC").Scan(context.Background()),
+	)
+	require.Equal(
+		t,
+		[]uuid.UUID{
+			uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			uuid.MustParse("00000000-0000-0000-0000-000000000007"),
+			uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+		},
+		lo.Map(erased, func(item *entities.Credential, _ int) uuid.UUID { return item.ID }),
+	)
+
+	for _, credential := range erased {
+		if credential.Kind == entities.CredentialKindService {
+			continue
+		}
+
+		require.True(t, strings.HasSuffix(credential.Email, "@"+entities.ErasedEmailDomain), credential.Email)
+		require.Nil(t, credential.DeletionScheduledAt)
+		require.Equal(t, now, lo.FromPtr(credential.ErasedAt))
+	}
+
+	// Each erasure is recorded, for the exports of the credentials.
+	erasures := make([]*entities.CredentialErasure, 0)
+	require.NoError(t, transaction.NewSelect().Model(&erasures).Order("credential_id ASC").Scan(context.Background()))
+	require.Equal(
+		t,
+		[]uuid.UUID{
+			uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+		},
+		lo.Map(erasures, func(item *entities.CredentialErasure, _ int) uuid.UUID { return item.CredentialID }),
+	)
+	require.Equal(t, dao.ScheduledErasureReason, erasures[0].Reason)
+
+	recorded, err := transaction.NewSelect().Model((*entities.CredentialDeletion)(nil)).Count(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, recorded)
+}
+
+// TestEraseScheduledCredentialsReferences erases credentials that other records refer to, as the issuer of an
+// invitation, and as the actor of role change requests.
+func TestEraseScheduledCredentialsReferences(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:            "default",
+			Email:               "email-1",
+			Role:                entities.RoleAdmin,
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			Role:      entities.RoleEarlyAccessProgram,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+			TenantID:  "default",
+			Code:      "EAP",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Decided by the erased credentials.
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleEarlyAccessProgram,
+			Status:       entities.RoleChangeRequestStatusApproved,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000001")),
+			ExpiresAt:    time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC),
+			DecidedAt:    lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Proposed by the erased credentials.
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusRejected,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000002")),
+			ExpiresAt:    time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC),
+			DecidedAt:    lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	eraseScheduledCredentialsDAO := dao.NewEraseScheduledCredentials(transaction)
+
+	deletions, err := eraseScheduledCredentialsDAO.Exec(
+		context.Background(), time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+	)
+	require.NoError(t, err)
+	require.Len(t, deletions, 1)
+
+	// The tombstone keeps the references.
+	invitation := new(entities.Invitation)
+	require.NoError(t, transaction.NewSelect().Model(invitation).Scan(context.Background()))
+	require.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000001"), invitation.CreatedBy)
+
+	// Purging the tombstone clears the references, without removing the records.
+	_, err = transaction.NewDelete().
+		Model((*entities.Credential)(nil)).
+		Where("id = ?", uuid.MustParse("00000000-0000-0000-0000-000000000001")).
+		Exec(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, transaction.NewSelect().Model(invitation).WherePK().Scan(context.Background()))
+	require.Equal(t, uuid.Nil, invitation.CreatedBy)
+
+	requests := make([]*entities.RoleChangeRequest, 0)
+	require.NoError(t, transaction.NewSelect().Model(&requests).Order("id ASC").Scan(context.Background()))
+	require.Len(t, requests, 2)
+	require.Nil(t, requests[0].DecidedBy)
+	require.Equal(t, uuid.Nil, requests[1].ProposedBy)
+}
@@ -5,3 +5,88 @@ import "errors"
 var ErrCredentialsNotFound = errors.New("credentials not found")
 
 var ErrCredentialsAlreadyExist = errors.New("credentials already exist")
+
+// ErrTokenMismatch is returned when a token cannot be consumed, because the credentials hold a different (or expired)
+// value for it. It maps to codes.FailedPrecondition, once the credentials proto defines the ConsumeToken RPC.
+var ErrTokenMismatch = errors.New("token mismatch")
+
+var ErrIdentityNotFound = errors.New("identity not found")
+
+var ErrIdentityAlreadyLinked = errors.New("identity already linked")
+
+// ErrLastLoginMethod is returned when unlinking an identity would leave the credentials with no way to sign in.
+var ErrLastLoginMethod = errors.New("cannot remove the last login method")
+
+var ErrFactorNotFound = errors.New("factor not found")
+
+var ErrFactorAlreadyExists = errors.New("factor already exists")
+
+// ErrLastFactor is returned when removing a factor would leave credentials that require MFA with no factor.
+var ErrLastFactor = errors.New("cannot remove the last factor of credentials that require MFA")
+
+// ErrInvalidOwner is returned when the owner of a service account is not a user credential of the tenant.
+var ErrInvalidOwner = errors.New("invalid service account owner")
+
+// ErrNotServiceAccount is returned when an operation reserved to service accounts targets user credentials.
+var ErrNotServiceAccount = errors.New("credentials are not a service account")
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// ErrDeletionNotScheduled is returned when canceling the deletion of credentials that are not pending deletion, or
+// whose grace period is over.
+var ErrDeletionNotScheduled = errors.New("credentials deletion is not scheduled")
+
+// ErrCredentialsOnLegalHold is returned when deleting credentials that are on legal hold, or changing their email.
+var ErrCredentialsOnLegalHold = errors.New("credentials are on legal hold")
+
+var ErrConsentNotFound = errors.New("consent not found")
+
+var ErrNoteNotFound = errors.New("note not found")
+
+var ErrInvitationNotFound = errors.New("invitation not found")
+
+var ErrInvitationAlreadyExists = errors.New("invitation already exists")
+
+// ErrInvitationUnavailable is returned when redeeming an invitation that expired, or has no use left.
+var ErrInvitationUnavailable = errors.New("invitation is no longer available")
+
+var ErrInvitationAlreadyRedeemed = errors.New("invitation already redeemed by these credentials")
+
+// ErrRoleCapacityReached is returned when assigning a role that has no seat left.
+var ErrRoleCapacityReached = errors.New("role capacity reached")
+
+var ErrAlreadyWaitlisted = errors.New("credentials are already waiting for this role")
+
+// ErrRoleAlreadyGranted is returned when joining the waitlist of a role the credentials already hold, or exceed.
+var ErrRoleAlreadyGranted = errors.New("credentials already hold this role")
+
+var ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+
+// ErrPrivilegedRoleChange is returned when setting a privileged role directly, instead of through an approved role
+// change request.
+var ErrPrivilegedRoleChange = errors.New("privileged roles require an approved role change request")
+
+var ErrRoleChangeRequestNotFound = errors.New("role change request not found")
+
+var ErrRoleChangeRequestAlreadyPending = errors.New("credentials already have a pending role change request")
+
+// ErrRoleChangeRequestClosed is returned when deciding a role change request that was already decided, or expired.
+var ErrRoleChangeRequestClosed = errors.New("role change request is no longer pending")
+
+// ErrRoleChangeSelfApproval is returned when the proposer of a role change request tries to approve it.
+var ErrRoleChangeSelfApproval = errors.New("role change requests must be approved by another actor")
+
+// ErrRoleChangeNotPrivileged is returned when an actor without a privileged role decides a role change request.
+var ErrRoleChangeNotPrivileged = errors.New("role change requests must be decided by a privileged user")
+
+// ErrLastProtectedRoleHolder is returned when demoting or deleting the last user holding a protected role.
+var ErrLastProtectedRoleHolder = errors.New("cannot remove the last holder of a protected role")
+
+// ErrEmailDomainNotAllowed is returned when the email domain policy rejects the email of credentials.
+var ErrEmailDomainNotAllowed = errors.New("email domain is not allowed")
+
+var ErrEmailDomainRuleNotFound = errors.New("email domain rule not found")
+
+// ErrCanonicalEmailTaken is returned when other credentials already use a variant of the same email, while canonical
+// emails are required to be unique.
+var ErrCanonicalEmailTaken = errors.New("canonical email already in use")
@@ -24,22 +24,32 @@ type existsCredentialsImpl struct {
 }
 
 func (dao *existsCredentialsImpl) Exec(ctx context.Context, request *ExistsCredentialsRequest) (bool, error) {
-	query := dao.database.NewSelect().Model((*entities.Credential)(nil))
-
 	if request.Email == "" && request.ID == uuid.Nil {
 		return false, ErrCredentialsNotFound
 	}
 
-	if request.Email != "" {
-		query.Where("email = ?", request.Email)
-	}
-	if request.ID != uuid.Nil {
-		query.Where("id = ?", request.ID)
-	}
+	var ok bool
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		query := tx.NewSelect().Model((*entities.Credential)(nil)).Where("tenant_id = ?", tenantID)
+
+		if request.Email != "" {
+			// Credentials pending deletion keep their email until they are erased, so it cannot be registered again.
+			query.Where("email = ?", request.Email)
+		}
+		if request.ID != uuid.Nil {
+			query.Where("id = ?", request.ID)
+		}
+
+		var err error
+		if ok, err = query.Exists(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
 
-	ok, err := query.Exists(ctx)
+		return nil
+	})
 	if err != nil {
-		return false, fmt.Errorf("exec query: %w", err)
+		return false, err
 	}
 
 	return ok, nil
@@ -20,6 +20,7 @@ func TestExistsCredentials(t *testing.T) {
 	fixtures := []interface{}{
 		&entities.Credential{
 			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:                      "default",
 			Email:                         "email-1",
 			Role:                          entities.RoleCore,
 			EmailValidationTokenID:        "email-validation-token-id",
@@ -29,6 +30,21 @@ func TestExistsCredentials(t *testing.T) {
 			CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 			UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 		},
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:            "default",
+			Email:               "email-2",
+			Role:                entities.RoleCore,
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	testCases := []struct {
@@ -58,12 +74,39 @@ func TestExistsCredentials(t *testing.T) {
 			expect: true,
 		},
 		{
-			name: "Exists/NotFound",
+			name: "Exists/Email/PendingDeletion",
 
 			request: &dao.ExistsCredentialsRequest{
 				Email: "email-2",
 			},
 
+			expect: true,
+		},
+		{
+			name: "Exists/ID/PendingDeletion",
+
+			request: &dao.ExistsCredentialsRequest{
+				ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expect: true,
+		},
+		{
+			name: "Exists/NotFound",
+
+			request: &dao.ExistsCredentialsRequest{
+				Email: "email-unknown",
+			},
+
+			expect: false,
+		},
+		{
+			name: "Exists/OtherTenant",
+
+			request: &dao.ExistsCredentialsRequest{
+				Email: "email-other",
+			},
+
 			expect: false,
 		},
 		{
@@ -86,7 +129,9 @@ func TestExistsCredentials(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			existsCredentialsDAO := dao.NewExistsCredentials(transaction)
 
-			credential, err := existsCredentialsDAO.Exec(context.Background(), testCase.request)
+			credential, err := existsCredentialsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
 
 			require.ErrorIs(t, err, testCase.expectErr)
 			require.Equal(t, testCase.expect, credential)
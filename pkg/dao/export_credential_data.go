@@ -0,0 +1,224 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// ExportCredentialDataResult holds everything the service stores about a single credential.
+type ExportCredentialDataResult struct {
+	Credential *entities.Credential
+	Identities []*entities.CredentialIdentity
+	Factors    []*entities.CredentialFactor
+	APIKeys    []*entities.APIKey
+	Consents   []*entities.CredentialConsent
+	Notes      []*entities.CredentialNote
+	Erasures   []*entities.CredentialErasure
+
+	RoleChanges []*entities.CredentialRoleChange
+	// Merges the credentials took part in, either as the merged or as the surviving credentials.
+	Merges    []*entities.CredentialMerge
+	Deletions []*entities.CredentialDeletion
+
+	// Invitations issued by the credentials.
+	Invitations           []*entities.Invitation
+	InvitationRedemptions []*entities.InvitationRedemption
+	WaitlistEntries       []*entities.RoleWaitlistEntry
+	// RoleChangeRequests that target the credentials.
+	RoleChangeRequests []*entities.RoleChangeRequest
+}
+
+type ExportCredentialData interface {
+	Exec(ctx context.Context, id uuid.UUID) (*ExportCredentialDataResult, error)
+}
+
+type exportCredentialDataImpl struct {
+	database bun.IDB
+}
+
+func (dao *exportCredentialDataImpl) Exec(ctx context.Context, id uuid.UUID) (*ExportCredentialDataResult, error) {
+	result := &ExportCredentialDataResult{
+		Credential: new(entities.Credential),
+		Identities: make([]*entities.CredentialIdentity, 0),
+		Factors:    make([]*entities.CredentialFactor, 0),
+		APIKeys:    make([]*entities.APIKey, 0),
+		Consents:   make([]*entities.CredentialConsent, 0),
+		Notes:      make([]*entities.CredentialNote, 0),
+		Erasures:   make([]*entities.CredentialErasure, 0),
+
+		RoleChanges: make([]*entities.CredentialRoleChange, 0),
+		Merges:      make([]*entities.CredentialMerge, 0),
+		Deletions:   make([]*entities.CredentialDeletion, 0),
+
+		Invitations:           make([]*entities.Invitation, 0),
+		InvitationRedemptions: make([]*entities.InvitationRedemption, 0),
+		WaitlistEntries:       make([]*entities.RoleWaitlistEntry, 0),
+		RoleChangeRequests:    make([]*entities.RoleChangeRequest, 0),
+	}
+
+	// Every read happens in the same transaction, so the export is a consistent snapshot.
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.NewSelect().
+			Model(result.Credential).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.Identities).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("linked_at ASC", "provider ASC", "subject ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select identities: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.Factors).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("created_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select factors: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.APIKeys).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("created_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select api keys: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.Consents).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("accepted_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select consents: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.Notes).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("created_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select notes: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.Erasures).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("erased_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select erasures: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.RoleChanges).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("changed_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select role changes: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.Merges).
+			WhereGroup(" AND ", func(query *bun.SelectQuery) *bun.SelectQuery {
+				return query.Where("loser_id = ?", id).WhereOr("survivor_id = ?", id)
+			}).
+			Where("tenant_id = ?", tenantID).
+			Order("merged_at ASC", "loser_id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select merges: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.Deletions).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("erased_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select deletions: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.Invitations).
+			Where("created_by = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("created_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select invitations: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.InvitationRedemptions).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("redeemed_at ASC", "invitation_id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select invitation redemptions: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.WaitlistEntries).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("seq ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select waitlist entries: %w", err)
+		}
+
+		err = tx.NewSelect().
+			Model(&result.RoleChangeRequests).
+			Where("credential_id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Order("created_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select role change requests: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func NewExportCredentialData(database bun.IDB) ExportCredentialData {
+	return &exportCredentialDataImpl{database: database}
+}
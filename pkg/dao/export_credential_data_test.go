@@ -0,0 +1,383 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestExportCredentialData(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:        "default",
+			Email:           "email-1",
+			Role:            entities.RoleCore,
+			PasswordTokenID: "password-token-1",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "github",
+			Subject:      "subject-1",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			LinkedAt:     time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:         entities.FactorTypeTOTP,
+			Label:        "phone",
+			SecretRef:    "secret-ref-1",
+			CreatedAt:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0004-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Document:     entities.ConsentDocumentTOS,
+			Version:      "v1",
+			AcceptedAt:   time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "refund issued",
+			CreatedAt:    time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "erased-0002",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.APIKey{
+			ID:           uuid.MustParse("00000000-0000-0000-0002-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Prefix:       "prefix-1",
+			SecretHash:   []byte("secret-hash-1"),
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialErasure{
+			ID:           uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Reason:       "ticket-1",
+			ErasedAt:     time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialDeletion{
+			ID:           uuid.MustParse("00000000-0000-0000-0009-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			ScheduledAt:  time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC),
+			ErasedAt:     time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		// Merged into the first credentials.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			DeletedAt: lo.ToPtr(time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialMerge{
+			LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:   "default",
+			SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			MergedAt:   time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialRoleChange{
+			ID:           uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			FromRole:     entities.RoleEarlyAccessProgram,
+			ToRole:       entities.RoleCore,
+			Reason:       entities.RoleChangeReasonInvitationRedeemed,
+			ChangedAt:    time.Date(2021, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+			TenantID:  "default",
+			Code:      "CODE1",
+			Role:      entities.RoleCore,
+			MaxUses:   1,
+			Uses:      1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			CreatedAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			TenantID:  "default",
+			Code:      "CODE2",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   3,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.InvitationRedemption{
+			InvitationID: uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			RedeemedAt:   time.Date(2021, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-000a-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Role:         entities.RoleAdmin,
+			Seq:          1,
+			EnqueuedAt:   time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-000b-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id uuid.UUID
+
+		expect    *dao.ExportCredentialDataResult
+		expectErr error
+	}{
+		{
+			name: "User",
+
+			id: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+
+			expect: &dao.ExportCredentialDataResult{
+				Credential: &entities.Credential{
+					ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TenantID:        "default",
+					Email:           "email-1",
+					Role:            entities.RoleCore,
+					PasswordTokenID: "password-token-1",
+					CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				Identities: []*entities.CredentialIdentity{
+					{
+						TenantID:     "default",
+						Provider:     "github",
+						Subject:      "subject-1",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						LinkedAt:     time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Factors: []*entities.CredentialFactor{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						Type:         entities.FactorTypeTOTP,
+						Label:        "phone",
+						SecretRef:    "secret-ref-1",
+						CreatedAt:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				APIKeys: []*entities.APIKey{},
+				Consents: []*entities.CredentialConsent{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-0004-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						Document:     entities.ConsentDocumentTOS,
+						Version:      "v1",
+						AcceptedAt:   time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Notes: []*entities.CredentialNote{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						Author:       "agent@example.com",
+						Body:         "refund issued",
+						CreatedAt:    time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Erasures: []*entities.CredentialErasure{},
+				RoleChanges: []*entities.CredentialRoleChange{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						FromRole:     entities.RoleEarlyAccessProgram,
+						ToRole:       entities.RoleCore,
+						Reason:       entities.RoleChangeReasonInvitationRedeemed,
+						ChangedAt:    time.Date(2021, 1, 7, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Merges: []*entities.CredentialMerge{
+					{
+						LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+						TenantID:   "default",
+						SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						MergedAt:   time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Deletions: []*entities.CredentialDeletion{},
+				Invitations: []*entities.Invitation{
+					{
+						ID:        uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+						TenantID:  "default",
+						Code:      "CODE2",
+						Role:      entities.RoleEarlyAccessProgram,
+						MaxUses:   3,
+						CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						CreatedAt: time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				InvitationRedemptions: []*entities.InvitationRedemption{
+					{
+						InvitationID: uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						RedeemedAt:   time.Date(2021, 1, 7, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				WaitlistEntries: []*entities.RoleWaitlistEntry{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-000a-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						Role:         entities.RoleAdmin,
+						Seq:          1,
+						EnqueuedAt:   time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				RoleChangeRequests: []*entities.RoleChangeRequest{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-000b-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						Role:         entities.RoleAdmin,
+						Status:       entities.RoleChangeRequestStatusPending,
+						ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						ExpiresAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+						CreatedAt:    time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "ServiceAccount",
+
+			id: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+
+			expect: &dao.ExportCredentialDataResult{
+				Credential: &entities.Credential{
+					ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					TenantID:  "default",
+					Kind:      entities.CredentialKindService,
+					Name:      "erased-0002",
+					OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					ErasedAt:  lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				Identities: []*entities.CredentialIdentity{},
+				Factors:    []*entities.CredentialFactor{},
+				APIKeys: []*entities.APIKey{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-0002-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+						Prefix:       "prefix-1",
+						SecretHash:   []byte("secret-hash-1"),
+						CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Consents: []*entities.CredentialConsent{},
+				Notes:    []*entities.CredentialNote{},
+				Erasures: []*entities.CredentialErasure{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-0003-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+						Reason:       "ticket-1",
+						ErasedAt:     time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				RoleChanges: []*entities.CredentialRoleChange{},
+				Merges:      []*entities.CredentialMerge{},
+				Deletions: []*entities.CredentialDeletion{
+					{
+						ID:           uuid.MustParse("00000000-0000-0000-0009-000000000001"),
+						TenantID:     "default",
+						CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+						ScheduledAt:  time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC),
+						ErasedAt:     time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+					},
+				},
+				Invitations:           []*entities.Invitation{},
+				InvitationRedemptions: []*entities.InvitationRedemption{},
+				WaitlistEntries:       []*entities.RoleWaitlistEntry{},
+				RoleChangeRequests:    []*entities.RoleChangeRequest{},
+			},
+		},
+		{
+			name: "NotFound",
+
+			id: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			exportCredentialDataDAO := dao.NewExportCredentialData(transaction)
+
+			result, err := exportCredentialDataDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, result)
+		})
+	}
+}
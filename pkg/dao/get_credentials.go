@@ -28,31 +28,81 @@ type getCredentialsImpl struct {
 func (dao *getCredentialsImpl) Exec(ctx context.Context, request *GetCredentialsRequest) (*entities.Credential, error) {
 	credential := new(entities.Credential)
 
-	query := dao.database.NewSelect().Model(credential)
-
 	if request.Email == "" && request.ID == uuid.Nil {
 		return nil, ErrCredentialsNotFound
 	}
 
-	if request.Email != "" {
-		query.Where("email = ?", request.Email)
-	}
-	if request.ID != uuid.Nil {
-		query.Where("id = ?", request.ID)
-	}
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		query := tx.NewSelect().
+			Model(credential).
+			ColumnExpr("credentials.*").
+			ColumnExpr("EXISTS (?) AS has_notes", selectCredentialNotes(tx)).
+			Where("tenant_id = ?", tenantID)
 
-	err := query.Scan(ctx)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, ErrCredentialsNotFound
+		if request.Email != "" {
+			query.Where("email = ?", request.Email)
+		}
+		if request.ID != uuid.Nil {
+			query.Where("id = ?", request.ID)
 		}
 
-		return nil, fmt.Errorf("exec query: %w", err)
+		err := query.Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		if credential.DeletedAt == nil {
+			return nil
+		}
+
+		// Merged credentials redirect to the credentials that absorbed them.
+		loserID := credential.ID
+		*credential = entities.Credential{}
+
+		err = tx.NewSelect().
+			Model(credential).
+			ColumnExpr("credentials.*").
+			ColumnExpr("EXISTS (?) AS has_notes", selectCredentialNotes(tx)).
+			Where("tenant_id = ?", tenantID).
+			Where("deleted_at IS NULL").
+			Where(
+				"id = (?)",
+				tx.NewSelect().
+					Model((*entities.CredentialMerge)(nil)).
+					Column("survivor_id").
+					Where("loser_id = ?", loserID),
+			).
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("follow merge: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return credential, nil
 }
 
+// selectCredentialNotes selects the notes of the credentials in the outer query.
+func selectCredentialNotes(tx bun.Tx) *bun.SelectQuery {
+	return tx.NewSelect().
+		Model((*entities.CredentialNote)(nil)).
+		ColumnExpr("1").
+		Where("credential_notes.credential_id = credentials.id").
+		Where("credential_notes.tenant_id = credentials.tenant_id")
+}
+
 func NewGetCredentials(database bun.IDB) GetCredentials {
 	return &getCredentialsImpl{database: database}
 }
@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type GetCredentialsByIdentityRequest struct {
+	Provider string
+	Subject  string
+}
+
+type GetCredentialsByIdentity interface {
+	Exec(ctx context.Context, request *GetCredentialsByIdentityRequest) (*entities.Credential, error)
+}
+
+type getCredentialsByIdentityImpl struct {
+	database bun.IDB
+}
+
+func (dao *getCredentialsByIdentityImpl) Exec(
+	ctx context.Context, request *GetCredentialsByIdentityRequest,
+) (*entities.Credential, error) {
+	credential := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.NewSelect().
+			Model(credential).
+			Join("JOIN credential_identities ON credential_identities.credential_id = credentials.id").
+			Where("credentials.tenant_id = ?", tenantID).
+			Where("credential_identities.tenant_id = ?", tenantID).
+			Where("credential_identities.provider = ?", request.Provider).
+			Where("credential_identities.subject = ?", request.Subject).
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+func NewGetCredentialsByIdentity(database bun.IDB) GetCredentialsByIdentity {
+	return &getCredentialsByIdentityImpl{database: database}
+}
@@ -0,0 +1,114 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestGetCredentialsByIdentity(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "google",
+			Subject:      "subject-1",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "tenant-2",
+			Provider:     "github",
+			Subject:      "subject-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.GetCredentialsByIdentityRequest
+
+		expect    *entities.Credential
+		expectErr error
+	}{
+		{
+			name: "Get",
+
+			request: &dao.GetCredentialsByIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-1",
+			},
+
+			expect: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:  "default",
+				Email:     "email-1",
+				Role:      entities.RoleCore,
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "NotFound/Provider",
+
+			request: &dao.GetCredentialsByIdentityRequest{
+				Provider: "github",
+				Subject:  "subject-1",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "NotFound/OtherTenant",
+
+			request: &dao.GetCredentialsByIdentityRequest{
+				Provider: "github",
+				Subject:  "subject-2",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			getCredentialsByIdentityDAO := dao.NewGetCredentialsByIdentity(transaction)
+
+			credential, err := getCredentialsByIdentityDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+		})
+	}
+}
@@ -20,6 +20,7 @@ func TestGetCredentials(t *testing.T) {
 	fixtures := []interface{}{
 		&entities.Credential{
 			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:                      "default",
 			Email:                         "email-1",
 			Role:                          entities.RoleCore,
 			EmailValidationTokenID:        "email-validation-token-id",
@@ -29,6 +30,35 @@ func TestGetCredentials(t *testing.T) {
 			CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 			UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Merged into credentials 1.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-merged",
+			DeletedAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "refund issued",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialMerge{
+			LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:   "default",
+			SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			MergedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	testCases := []struct {
@@ -48,12 +78,14 @@ func TestGetCredentials(t *testing.T) {
 
 			expect: &entities.Credential{
 				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                      "default",
 				Email:                         "email-1",
 				Role:                          entities.RoleCore,
 				EmailValidationTokenID:        "email-validation-token-id",
 				PendingEmailValidationTokenID: "pending-email-validation-token-id",
 				PasswordTokenID:               "password-token-id",
 				ResetPasswordTokenID:          "reset-password-token-id",
+				HasNotes:                      true,
 				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
@@ -67,12 +99,56 @@ func TestGetCredentials(t *testing.T) {
 
 			expect: &entities.Credential{
 				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                      "default",
+				Email:                         "email-1",
+				Role:                          entities.RoleCore,
+				EmailValidationTokenID:        "email-validation-token-id",
+				PendingEmailValidationTokenID: "pending-email-validation-token-id",
+				PasswordTokenID:               "password-token-id",
+				ResetPasswordTokenID:          "reset-password-token-id",
+				HasNotes:                      true,
+				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Get/Merged/ID",
+
+			request: &dao.GetCredentialsRequest{
+				ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expect: &entities.Credential{
+				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                      "default",
 				Email:                         "email-1",
 				Role:                          entities.RoleCore,
 				EmailValidationTokenID:        "email-validation-token-id",
 				PendingEmailValidationTokenID: "pending-email-validation-token-id",
 				PasswordTokenID:               "password-token-id",
 				ResetPasswordTokenID:          "reset-password-token-id",
+				HasNotes:                      true,
+				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Get/Merged/Email",
+
+			request: &dao.GetCredentialsRequest{
+				Email: "email-merged",
+			},
+
+			expect: &entities.Credential{
+				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                      "default",
+				Email:                         "email-1",
+				Role:                          entities.RoleCore,
+				EmailValidationTokenID:        "email-validation-token-id",
+				PendingEmailValidationTokenID: "pending-email-validation-token-id",
+				PasswordTokenID:               "password-token-id",
+				ResetPasswordTokenID:          "reset-password-token-id",
+				HasNotes:                      true,
 				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
@@ -86,6 +162,15 @@ func TestGetCredentials(t *testing.T) {
 
 			expectErr: dao.ErrCredentialsNotFound,
 		},
+		{
+			name: "Get/OtherTenant",
+
+			request: &dao.GetCredentialsRequest{
+				ID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
 		{
 			name: "Get/NoParameters",
 
@@ -106,7 +191,9 @@ func TestGetCredentials(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			getCredentialsDAO := dao.NewGetCredentials(transaction)
 
-			credential, err := getCredentialsDAO.Exec(context.Background(), testCase.request)
+			credential, err := getCredentialsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
 
 			require.ErrorIs(t, err, testCase.expectErr)
 			require.Equal(t, testCase.expect, credential)
@@ -0,0 +1,46 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type GetRoleWaitlistPositionRequest struct {
+	CredentialID uuid.UUID
+	Role         entities.Role
+}
+
+type GetRoleWaitlistPosition interface {
+	Exec(ctx context.Context, request *GetRoleWaitlistPositionRequest) (*entities.RoleWaitlistPosition, error)
+}
+
+type getRoleWaitlistPositionImpl struct {
+	database bun.IDB
+}
+
+func (dao *getRoleWaitlistPositionImpl) Exec(
+	ctx context.Context, request *GetRoleWaitlistPositionRequest,
+) (*entities.RoleWaitlistPosition, error) {
+	var position *entities.RoleWaitlistPosition
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		var err error
+
+		position, err = waitlistPosition(ctx, tx, tenantID, request.CredentialID, request.Role)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
+
+func NewGetRoleWaitlistPosition(database bun.IDB) GetRoleWaitlistPosition {
+	return &getRoleWaitlistPositionImpl{database: database}
+}
@@ -0,0 +1,145 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestGetRoleWaitlistPosition(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Role:         entities.RoleCore,
+			Seq:          1,
+			EnqueuedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Role:         entities.RoleCore,
+			Seq:          2,
+			EnqueuedAt:   time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000099"),
+			TenantID:     "tenant-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			Role:         entities.RoleCore,
+			Seq:          3,
+			EnqueuedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.GetRoleWaitlistPositionRequest
+
+		expect    *entities.RoleWaitlistPosition
+		expectErr error
+	}{
+		{
+			name: "First",
+
+			request: &dao.GetRoleWaitlistPositionRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleCore,
+			},
+
+			expect: &entities.RoleWaitlistPosition{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleCore,
+				Position:     1,
+				Waiting:      2,
+				EnqueuedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Second",
+
+			request: &dao.GetRoleWaitlistPositionRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleCore,
+			},
+
+			expect: &entities.RoleWaitlistPosition{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleCore,
+				Position:     2,
+				Waiting:      2,
+				EnqueuedAt:   time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OtherRole",
+
+			request: &dao.GetRoleWaitlistPositionRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleAdmin,
+			},
+
+			expectErr: dao.ErrWaitlistEntryNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			request: &dao.GetRoleWaitlistPositionRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				Role:         entities.RoleCore,
+			},
+
+			expectErr: dao.ErrWaitlistEntryNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			getRoleWaitlistPositionDAO := dao.NewGetRoleWaitlistPosition(transaction)
+
+			position, err := getRoleWaitlistPositionDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, position)
+		})
+	}
+}
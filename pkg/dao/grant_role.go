@@ -0,0 +1,117 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type GrantRoleRequest struct {
+	Role      entities.Role
+	ExpiresAt time.Time
+	// FallbackRole is the role restored once the grant expires. A nil value keeps the fallback of the current grant,
+	// or the current role if there is none, so stacked grants always revert to the original role.
+	FallbackRole *entities.Role
+}
+
+type GrantRole interface {
+	Exec(ctx context.Context, id uuid.UUID, now time.Time, request *GrantRoleRequest) (*entities.Credential, error)
+}
+
+type grantRoleImpl struct {
+	database   bun.IDB
+	capacities entities.RoleCapacities
+	// privileged roles can only be set through an approved role change request.
+	privileged []entities.Role
+	// protected roles must keep at least one holder.
+	protected []entities.Role
+}
+
+func (dao *grantRoleImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *GrantRoleRequest,
+) (*entities.Credential, error) {
+	model := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		current := new(entities.Credential)
+
+		err := tx.NewSelect().
+			Model(current).
+			Column("id", "kind", "role", "fallback_role").
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		// Stacked grants keep the fallback of the current grant, so they always revert to the original role.
+		fallbackRole := lo.FromPtr(lo.CoalesceOrEmpty(request.FallbackRole, current.FallbackRole, &current.Role))
+
+		// A privileged fallback would become permanent once the grant expires, without any approval. Falling back to
+		// the privileged role the credentials already hold is not a change.
+		if lo.Contains(dao.privileged, request.Role) ||
+			(fallbackRole != permanentRole(current) && lo.Contains(dao.privileged, fallbackRole)) {
+			return ErrPrivilegedRoleChange
+		}
+
+		// The credentials keep their permanent role during the grant, unless the fallback changes it.
+		if permanentRole(current) != fallbackRole {
+			if err = checkLastRoleHolder(ctx, tx, tenantID, dao.protected, current); err != nil {
+				return err
+			}
+		}
+
+		err = reassignSeats(
+			ctx, tx, tenantID, dao.capacities, now,
+			heldRoles(current), lo.Uniq([]entities.Role{request.Role, fallbackRole}),
+		)
+		if err != nil {
+			return err
+		}
+
+		err = tx.
+			NewUpdate().
+			Model(model).
+			Set("role = ?", request.Role).
+			Set("role_expires_at = ?", request.ExpiresAt).
+			Set("fallback_role = ?", fallbackRole).
+			Set("updated_at = ?", now).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewGrantRole(
+	database bun.IDB, capacities entities.RoleCapacities, privileged, protected []entities.Role,
+) GrantRole {
+	return &grantRoleImpl{database: database, capacities: capacities, privileged: privileged, protected: protected}
+}
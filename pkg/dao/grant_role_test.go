@@ -0,0 +1,286 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestGrantRole(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleEarlyAccessProgram,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Already holds a temporary role.
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:      "default",
+			Email:         "email-2",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleNone),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-3",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id         uuid.UUID
+		now        time.Time
+		request    *dao.GrantRoleRequest
+		privileged []entities.Role
+		protected  []entities.Role
+
+		expect    *entities.Credential
+		expectErr error
+	}{
+		{
+			name: "Grant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:      entities.RoleAdmin,
+				ExpiresAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+			// The credentials keep their permanent role as fallback.
+			protected: []entities.Role{entities.RoleEarlyAccessProgram},
+
+			expect: &entities.Credential{
+				ID:            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:      "default",
+				Email:         "email-1",
+				Role:          entities.RoleAdmin,
+				RoleExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Grant/FallbackRole",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:         entities.RoleAdmin,
+				ExpiresAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				FallbackRole: lo.ToPtr(entities.RoleNone),
+			},
+
+			expect: &entities.Credential{
+				ID:            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:      "default",
+				Email:         "email-1",
+				Role:          entities.RoleAdmin,
+				RoleExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:  lo.ToPtr(entities.RoleNone),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "PrivilegedRole",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:      entities.RoleCore,
+				ExpiresAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+			privileged: []entities.Role{entities.RoleAdmin, entities.RoleCore},
+
+			expectErr: dao.ErrPrivilegedRoleChange,
+		},
+		{
+			// The fallback would make the privileged role permanent once the grant expires.
+			name: "PrivilegedRole/Fallback",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:         entities.RoleEarlyAccessProgram,
+				ExpiresAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				FallbackRole: lo.ToPtr(entities.RoleAdmin),
+			},
+			privileged: []entities.Role{entities.RoleAdmin, entities.RoleCore},
+
+			expectErr: dao.ErrPrivilegedRoleChange,
+		},
+		{
+			name: "LastProtectedRoleHolder",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:         entities.RoleAdmin,
+				ExpiresAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				FallbackRole: lo.ToPtr(entities.RoleNone),
+			},
+			protected: []entities.Role{entities.RoleEarlyAccessProgram},
+
+			expectErr: dao.ErrLastProtectedRoleHolder,
+		},
+		{
+			name: "Extend",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:      entities.RoleCore,
+				ExpiresAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			// The original role is kept as fallback.
+			expect: &entities.Credential{
+				ID:            uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:      "default",
+				Email:         "email-2",
+				Role:          entities.RoleCore,
+				RoleExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:  lo.ToPtr(entities.RoleNone),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "RoleCapacityReached",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:         entities.RoleAdmin,
+				ExpiresAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				FallbackRole: lo.ToPtr(entities.RoleEarlyAccessProgram),
+			},
+
+			expectErr: dao.ErrRoleCapacityReached,
+		},
+		{
+			name: "ServiceAccount",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:      entities.RoleAdmin,
+				ExpiresAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			// A legal hold only freezes the deletion and the email of the credentials.
+			name: "LegalHold",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:      entities.RoleAdmin,
+				ExpiresAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				TenantID:        "default",
+				Email:           "email-held",
+				Role:            entities.RoleAdmin,
+				RoleExpiresAt:   lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:    lo.ToPtr(entities.RoleNone),
+				LegalHold:       true,
+				LegalHoldReason: "dispute",
+				LegalHoldSetBy:  "legal@example.com",
+				LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:      entities.RoleAdmin,
+				ExpiresAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.GrantRoleRequest{
+				Role:      entities.RoleAdmin,
+				ExpiresAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			grantRoleDAO := dao.NewGrantRole(
+				transaction,
+				entities.RoleCapacities{entities.RoleEarlyAccessProgram: 1},
+				testCase.privileged,
+				testCase.protected,
+			)
+
+			credential, err := grantRoleDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+		})
+	}
+}
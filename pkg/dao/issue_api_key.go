@@ -0,0 +1,94 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type IssueAPIKeyRequest struct {
+	CredentialID uuid.UUID
+	Prefix       string
+	SecretHash   []byte
+	Scopes       []string
+	ExpiresAt    *time.Time
+}
+
+type IssueAPIKey interface {
+	Exec(ctx context.Context, id uuid.UUID, now time.Time, request *IssueAPIKeyRequest) (*entities.APIKey, error)
+}
+
+type issueAPIKeyImpl struct {
+	database bun.IDB
+}
+
+func (dao *issueAPIKeyImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *IssueAPIKeyRequest,
+) (*entities.APIKey, error) {
+	model := &entities.APIKey{
+		ID:           id,
+		CredentialID: request.CredentialID,
+		Prefix:       request.Prefix,
+		SecretHash:   request.SecretHash,
+		Scopes:       request.Scopes,
+		ExpiresAt:    request.ExpiresAt,
+		CreatedAt:    now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		if err := checkServiceAccount(ctx, tx, tenantID, request.CredentialID); err != nil {
+			return err
+		}
+
+		if _, err := tx.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// checkServiceAccount ensures the credentials exist in the tenant, and are a service account. The credentials are
+// locked until the end of the transaction.
+func checkServiceAccount(ctx context.Context, tx bun.Tx, tenantID string, id uuid.UUID) error {
+	credential := new(entities.Credential)
+
+	err := tx.NewSelect().
+		Model(credential).
+		Column("kind").
+		Where("id = ?", id).
+		Where("tenant_id = ?", tenantID).
+		For("SHARE").
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCredentialsNotFound
+		}
+
+		return fmt.Errorf("check credentials: %w", err)
+	}
+
+	if credential.Kind != entities.CredentialKindService {
+		return ErrNotServiceAccount
+	}
+
+	return nil
+}
+
+func NewIssueAPIKey(database bun.IDB) IssueAPIKey {
+	return &issueAPIKeyImpl{database: database}
+}
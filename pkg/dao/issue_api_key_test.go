@@ -0,0 +1,137 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestIssueAPIKey(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-1",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.IssueAPIKeyRequest
+
+		expect    *entities.APIKey
+		expectErr error
+	}{
+		{
+			name: "Issue",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.IssueAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Prefix:       "prefix-1",
+				SecretHash:   []byte("hash-1"),
+				Scopes:       []string{"books:read"},
+				ExpiresAt:    lo.ToPtr(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &entities.APIKey{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Prefix:       "prefix-1",
+				SecretHash:   []byte("hash-1"),
+				Scopes:       []string{"books:read"},
+				ExpiresAt:    lo.ToPtr(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "NotServiceAccount",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.IssueAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Prefix:       "prefix-1",
+				SecretHash:   []byte("hash-1"),
+			},
+
+			expectErr: dao.ErrNotServiceAccount,
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.IssueAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Prefix:       "prefix-1",
+				SecretHash:   []byte("hash-1"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.IssueAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				Prefix:       "prefix-1",
+				SecretHash:   []byte("hash-1"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			issueAPIKeyDAO := dao.NewIssueAPIKey(transaction)
+
+			apiKey, err := issueAPIKeyDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, apiKey)
+		})
+	}
+}
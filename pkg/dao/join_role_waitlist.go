@@ -0,0 +1,154 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type JoinRoleWaitlistRequest struct {
+	CredentialID uuid.UUID
+	Role         entities.Role
+}
+
+type JoinRoleWaitlist interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *JoinRoleWaitlistRequest,
+	) (*entities.RoleWaitlistPosition, error)
+}
+
+type joinRoleWaitlistImpl struct {
+	database   bun.IDB
+	capacities entities.RoleCapacities
+}
+
+// Exec adds the credentials at the end of the waitlist of the role. If a seat is free, they are promoted right away,
+// and their position is 0.
+func (dao *joinRoleWaitlistImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *JoinRoleWaitlistRequest,
+) (*entities.RoleWaitlistPosition, error) {
+	var position *entities.RoleWaitlistPosition
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		credential := new(entities.Credential)
+
+		err := tx.NewSelect().
+			Model(credential).
+			Column("role", "fallback_role").
+			Where("id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			For("SHARE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		// Temporary roles do not count, as the credentials lose them once the grant expires.
+		if roleRank(*lo.CoalesceOrEmpty(credential.FallbackRole, &credential.Role)) >= roleRank(request.Role) {
+			return ErrRoleAlreadyGranted
+		}
+
+		entry := &entities.RoleWaitlistEntry{
+			ID:           id,
+			TenantID:     tenantID,
+			CredentialID: request.CredentialID,
+			Role:         request.Role,
+			EnqueuedAt:   now,
+		}
+
+		if _, err = tx.NewInsert().Model(entry).Returning("*").Exec(ctx); err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrAlreadyWaitlisted
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		if _, err = promoteWaitlisted(ctx, tx, tenantID, dao.capacities, request.Role, now); err != nil {
+			return err
+		}
+
+		position, err = waitlistPosition(ctx, tx, tenantID, request.CredentialID, request.Role)
+		if errors.Is(err, ErrWaitlistEntryNotFound) {
+			position = &entities.RoleWaitlistPosition{
+				CredentialID: request.CredentialID,
+				Role:         request.Role,
+				EnqueuedAt:   now,
+			}
+
+			return nil
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
+
+// waitlistPosition returns the place of the credentials in the waitlist of the role.
+func waitlistPosition(
+	ctx context.Context, tx bun.Tx, tenantID string, credentialID uuid.UUID, role entities.Role,
+) (*entities.RoleWaitlistPosition, error) {
+	entry := new(entities.RoleWaitlistEntry)
+
+	err := tx.NewSelect().
+		Model(entry).
+		Where("credential_id = ?", credentialID).
+		Where("role = ?", role).
+		Where("tenant_id = ?", tenantID).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWaitlistEntryNotFound
+		}
+
+		return nil, fmt.Errorf("select waitlist entry: %w", err)
+	}
+
+	queue := tx.NewSelect().
+		Model((*entities.RoleWaitlistEntry)(nil)).
+		Where("tenant_id = ?", tenantID).
+		Where("role = ?", role)
+
+	waiting, err := queue.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count waitlist: %w", err)
+	}
+
+	position, err := queue.Where("seq <= ?", entry.Seq).Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count waitlist position: %w", err)
+	}
+
+	return &entities.RoleWaitlistPosition{
+		CredentialID: entry.CredentialID,
+		Role:         entry.Role,
+		Position:     position,
+		Waiting:      waiting,
+		EnqueuedAt:   entry.EnqueuedAt,
+	}, nil
+}
+
+func NewJoinRoleWaitlist(database bun.IDB, capacities entities.RoleCapacities) JoinRoleWaitlist {
+	return &joinRoleWaitlistImpl{database: database, capacities: capacities}
+}
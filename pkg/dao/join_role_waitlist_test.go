@@ -0,0 +1,225 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestJoinRoleWaitlist(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Temporary admin, that reverts to the early access program.
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:      "default",
+			Email:         "email-4",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Role:         entities.RoleCore,
+			EnqueuedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.JoinRoleWaitlistRequest
+
+		expect    *entities.RoleWaitlistPosition
+		expectErr error
+	}{
+		{
+			name: "Join",
+
+			id:  uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Role:         entities.RoleCore,
+			},
+
+			expect: &entities.RoleWaitlistPosition{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Role:         entities.RoleCore,
+				Position:     2,
+				Waiting:      2,
+				EnqueuedAt:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Join/TemporaryRole",
+
+			id:  uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Role:         entities.RoleCore,
+			},
+
+			expect: &entities.RoleWaitlistPosition{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Role:         entities.RoleCore,
+				Position:     2,
+				Waiting:      2,
+				EnqueuedAt:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Join/Promoted",
+
+			id:  uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+
+			expect: &entities.RoleWaitlistPosition{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Role:         entities.RoleEarlyAccessProgram,
+				EnqueuedAt:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "AlreadyGranted",
+
+			id:  uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+
+			expectErr: dao.ErrRoleAlreadyGranted,
+		},
+		{
+			name: "AlreadyWaitlisted",
+
+			id:  uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleCore,
+			},
+
+			expectErr: dao.ErrAlreadyWaitlisted,
+		},
+		{
+			// A legal hold only freezes the deletion and the email of the credentials.
+			name: "LegalHold",
+
+			id:  uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Role:         entities.RoleCore,
+			},
+
+			expect: &entities.RoleWaitlistPosition{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Role:         entities.RoleCore,
+				Position:     2,
+				Waiting:      2,
+				EnqueuedAt:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+				Role:         entities.RoleCore,
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				Role:         entities.RoleCore,
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			joinRoleWaitlistDAO := dao.NewJoinRoleWaitlist(transaction, entities.RoleCapacities{entities.RoleCore: 1})
+
+			position, err := joinRoleWaitlistDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, position)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// checkLegalHold is called when an update guarded against legal holds matched no row. It returns
+// ErrCredentialsOnLegalHold if the credentials are held, and ErrCredentialsNotFound otherwise.
+func checkLegalHold(ctx context.Context, tx bun.Tx, tenantID string, id uuid.UUID) error {
+	held, err := tx.NewSelect().
+		Model((*entities.Credential)(nil)).
+		Where("id = ?", id).
+		Where("tenant_id = ?", tenantID).
+		Where("legal_hold").
+		Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("check legal hold: %w", err)
+	}
+
+	if held {
+		return ErrCredentialsOnLegalHold
+	}
+
+	return ErrCredentialsNotFound
+}
@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type LinkIdentityRequest struct {
+	Provider        string
+	Subject         string
+	EmailAtLinkTime string
+}
+
+type LinkIdentity interface {
+	Exec(
+		ctx context.Context, credentialID uuid.UUID, now time.Time, request *LinkIdentityRequest,
+	) (*entities.CredentialIdentity, error)
+}
+
+type linkIdentityImpl struct {
+	database bun.IDB
+}
+
+func (dao *linkIdentityImpl) Exec(
+	ctx context.Context, credentialID uuid.UUID, now time.Time, request *LinkIdentityRequest,
+) (*entities.CredentialIdentity, error) {
+	model := &entities.CredentialIdentity{
+		Provider:        request.Provider,
+		Subject:         request.Subject,
+		CredentialID:    credentialID,
+		EmailAtLinkTime: request.EmailAtLinkTime,
+		LinkedAt:        now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		// Foreign keys ignore tenant isolation, so the credentials must be checked explicitly. The lock prevents them
+		// from being deleted before the identity is inserted.
+		exists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", credentialID).
+			Where("tenant_id = ?", tenantID).
+			For("SHARE").
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check credentials existence: %w", err)
+		}
+
+		if !exists {
+			return ErrCredentialsNotFound
+		}
+
+		_, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx)
+		if err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrIdentityAlreadyLinked
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewLinkIdentity(database bun.IDB) LinkIdentity {
+	return &linkIdentityImpl{database: database}
+}
@@ -0,0 +1,154 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestLinkIdentity(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "google",
+			Subject:      "subject-1",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "tenant-2",
+			Provider:     "github",
+			Subject:      "subject-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		credentialID uuid.UUID
+		now          time.Time
+		request      *dao.LinkIdentityRequest
+
+		expect    *entities.CredentialIdentity
+		expectErr error
+	}{
+		{
+			name: "Link",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.LinkIdentityRequest{
+				Provider:        "github",
+				Subject:         "subject-1",
+				EmailAtLinkTime: "user@github.com",
+			},
+
+			expect: &entities.CredentialIdentity{
+				TenantID:        "default",
+				Provider:        "github",
+				Subject:         "subject-1",
+				CredentialID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				EmailAtLinkTime: "user@github.com",
+				LinkedAt:        time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Link/SubjectUsedInOtherTenant",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.LinkIdentityRequest{
+				Provider: "github",
+				Subject:  "subject-2",
+			},
+
+			expect: &entities.CredentialIdentity{
+				TenantID:     "default",
+				Provider:     "github",
+				Subject:      "subject-2",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				LinkedAt:     time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "AlreadyLinked",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.LinkIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-1",
+			},
+
+			expectErr: dao.ErrIdentityAlreadyLinked,
+		},
+		{
+			name: "CredentialsNotFound",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.LinkIdentityRequest{
+				Provider: "github",
+				Subject:  "subject-1",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "CredentialsInOtherTenant",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.LinkIdentityRequest{
+				Provider: "github",
+				Subject:  "subject-1",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			linkIdentityDAO := dao.NewLinkIdentity(transaction)
+
+			identity, err := linkIdentityDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				testCase.credentialID, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, identity)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ListCanonicalEmailClustersRequest struct {
+	// Limit and Offset paginate the clusters, not the credentials.
+	Limit  int
+	Offset int
+}
+
+type ListCanonicalEmailClusters interface {
+	Exec(ctx context.Context, request *ListCanonicalEmailClustersRequest) ([]*entities.CanonicalEmailCluster, error)
+}
+
+type listCanonicalEmailClustersImpl struct {
+	database bun.IDB
+}
+
+// Exec returns the canonical emails shared by more than one active credentials, sorted by canonical email. Erased and
+// merged credentials are left out, since they cannot sign in anymore.
+func (dao *listCanonicalEmailClustersImpl) Exec(
+	ctx context.Context, request *ListCanonicalEmailClustersRequest,
+) ([]*entities.CanonicalEmailCluster, error) {
+	clusters := make([]*entities.CanonicalEmailCluster, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		active := func(query *bun.SelectQuery) *bun.SelectQuery {
+			return query.
+				Where("tenant_id = ?", tenantID).
+				Where("canonical_email IS NOT NULL").
+				Where("erased_at IS NULL").
+				Where("deleted_at IS NULL")
+		}
+
+		var canonicalEmails []string
+
+		err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Column("canonical_email").
+			Apply(active).
+			Group("canonical_email").
+			Having("COUNT(*) > 1").
+			Order("canonical_email ASC").
+			Limit(request.Limit).
+			Offset(request.Offset).
+			Scan(ctx, &canonicalEmails)
+		if err != nil {
+			return fmt.Errorf("select canonical emails: %w", err)
+		}
+
+		if len(canonicalEmails) == 0 {
+			return nil
+		}
+
+		credentials := make([]*entities.Credential, 0)
+
+		err = tx.NewSelect().
+			Model(&credentials).
+			Column("id", "email", "canonical_email", "role", "email_verified_at", "created_at").
+			Apply(active).
+			Where("canonical_email IN (?)", bun.In(canonicalEmails)).
+			Order("canonical_email ASC", "created_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		for _, credential := range credentials {
+			if len(clusters) == 0 || clusters[len(clusters)-1].CanonicalEmail != credential.CanonicalEmail {
+				clusters = append(clusters, &entities.CanonicalEmailCluster{CanonicalEmail: credential.CanonicalEmail})
+			}
+
+			cluster := clusters[len(clusters)-1]
+			cluster.Credentials = append(cluster.Credentials, credential)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+func NewListCanonicalEmailClusters(database bun.IDB) ListCanonicalEmailClusters {
+	return &listCanonicalEmailClustersImpl{database: database}
+}
@@ -0,0 +1,181 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestListCanonicalEmailClusters(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:        "default",
+			Email:           "John.Doe@gmail.com",
+			CanonicalEmail:  "johndoe@gmail.com",
+			EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:       "default",
+			Email:          "johndoe+promo@googlemail.com",
+			CanonicalEmail: "johndoe@gmail.com",
+			Role:           entities.RoleEarlyAccessProgram,
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Alone with its canonical email.
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:       "default",
+			Email:          "jane@example.com",
+			CanonicalEmail: "jane@example.com",
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:       "default",
+			Email:          "alice@example.com",
+			CanonicalEmail: "alice@example.com",
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:       "default",
+			Email:          "Alice+1@example.com",
+			CanonicalEmail: "alice@example.com",
+			CreatedAt:      time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		// Merged credentials do not count.
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			TenantID:       "default",
+			Email:          "jane+old@example.com",
+			CanonicalEmail: "jane@example.com",
+			DeletedAt:      lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Erased credentials do not count.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000007"),
+			TenantID:  "default",
+			Email:     "erased-0007@erased.invalid",
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Belongs to another tenant.
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:       "tenant-2",
+			Email:          "jane@example.com",
+			CanonicalEmail: "jane@example.com",
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	aliceCluster := &entities.CanonicalEmailCluster{
+		CanonicalEmail: "alice@example.com",
+		Credentials: []*entities.Credential{
+			{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:          "alice@example.com",
+				CanonicalEmail: "alice@example.com",
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Email:          "Alice+1@example.com",
+				CanonicalEmail: "alice@example.com",
+				CreatedAt:      time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	johnCluster := &entities.CanonicalEmailCluster{
+		CanonicalEmail: "johndoe@gmail.com",
+		Credentials: []*entities.Credential{
+			{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Email:          "johndoe+promo@googlemail.com",
+				CanonicalEmail: "johndoe@gmail.com",
+				Role:           entities.RoleEarlyAccessProgram,
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:           "John.Doe@gmail.com",
+				CanonicalEmail:  "johndoe@gmail.com",
+				EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.ListCanonicalEmailClustersRequest
+
+		expect    []*entities.CanonicalEmailCluster
+		expectErr error
+	}{
+		{
+			name: "All",
+
+			request: &dao.ListCanonicalEmailClustersRequest{Limit: 10},
+
+			expect: []*entities.CanonicalEmailCluster{aliceCluster, johnCluster},
+		},
+		{
+			name: "Limit",
+
+			request: &dao.ListCanonicalEmailClustersRequest{Limit: 1},
+
+			expect: []*entities.CanonicalEmailCluster{aliceCluster},
+		},
+		{
+			name: "Offset",
+
+			request: &dao.ListCanonicalEmailClustersRequest{Limit: 10, Offset: 1},
+
+			expect: []*entities.CanonicalEmailCluster{johnCluster},
+		},
+		{
+			name: "OffsetTooHigh",
+
+			request: &dao.ListCanonicalEmailClustersRequest{Limit: 10, Offset: 2},
+
+			expect: []*entities.CanonicalEmailCluster{},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			listCanonicalEmailClustersDAO := dao.NewListCanonicalEmailClusters(transaction)
+
+			clusters, err := listCanonicalEmailClustersDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, clusters)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ListCredentialNotes interface {
+	Exec(ctx context.Context, credentialID uuid.UUID) ([]*entities.CredentialNote, error)
+}
+
+type listCredentialNotesImpl struct {
+	database bun.IDB
+}
+
+// Exec returns the notes of a credential, pinned notes first, then the most recent ones.
+func (dao *listCredentialNotesImpl) Exec(
+	ctx context.Context, credentialID uuid.UUID,
+) ([]*entities.CredentialNote, error) {
+	notes := make([]*entities.CredentialNote, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.NewSelect().
+			Model(&notes).
+			Where("credential_id = ?", credentialID).
+			Where("tenant_id = ?", tenantID).
+			Order("pinned DESC", "created_at DESC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+func NewListCredentialNotes(database bun.IDB) ListCredentialNotes {
+	return &listCredentialNotesImpl{database: database}
+}
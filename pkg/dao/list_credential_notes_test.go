@@ -0,0 +1,142 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestListCredentialNotes(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "refund issued",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "agent@example.com",
+			Body:         "suspected bot",
+			Pinned:       true,
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Author:       "other-agent@example.com",
+			Body:         "asked for a callback",
+			CreatedAt:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000004"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Author:       "agent@example.com",
+			Body:         "vip",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		credentialID uuid.UUID
+
+		expect    []*entities.CredentialNote
+		expectErr error
+	}{
+		{
+			name: "List",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+
+			expect: []*entities.CredentialNote{
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Author:       "agent@example.com",
+					Body:         "suspected bot",
+					Pinned:       true,
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Author:       "other-agent@example.com",
+					Body:         "asked for a callback",
+					CreatedAt:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Author:       "agent@example.com",
+					Body:         "refund issued",
+					CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "NoNotes",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+
+			expect: []*entities.CredentialNote{},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listCredentialNotesDAO := dao.NewListCredentialNotes(transaction)
+
+			notes, err := listCredentialNotesDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.credentialID,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, notes)
+		})
+	}
+}
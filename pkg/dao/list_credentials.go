@@ -21,9 +21,32 @@ type listCredentialsImpl struct {
 func (dao *listCredentialsImpl) Exec(ctx context.Context, ids []uuid.UUID) ([]*entities.Credential, error) {
 	credentials := make([]*entities.Credential, 0)
 
-	err := dao.database.NewSelect().Model(&credentials).Where("id IN (?)", bun.In(ids)).Scan(ctx)
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.NewSelect().
+			Model(&credentials).
+			Where("tenant_id = ?", tenantID).
+			Where("deleted_at IS NULL").
+			// Merged credentials redirect to the credentials that absorbed them.
+			WhereGroup(" AND ", func(query *bun.SelectQuery) *bun.SelectQuery {
+				return query.
+					Where("id IN (?)", bun.In(ids)).
+					WhereOr(
+						"id IN (?)",
+						tx.NewSelect().
+							Model((*entities.CredentialMerge)(nil)).
+							Column("survivor_id").
+							Where("loser_id IN (?)", bun.In(ids)),
+					)
+			}).
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("exec query: %w", err)
+		return nil, err
 	}
 
 	return credentials, nil
@@ -20,6 +20,7 @@ func TestListCredentials(t *testing.T) {
 	fixtures := []interface{}{
 		&entities.Credential{
 			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:                      "default",
 			Email:                         "email-1",
 			Role:                          entities.RoleCore,
 			EmailValidationTokenID:        "email-validation-token-id-1",
@@ -31,6 +32,7 @@ func TestListCredentials(t *testing.T) {
 		},
 		&entities.Credential{
 			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:                      "default",
 			Email:                         "email-2",
 			Role:                          entities.RoleAdmin,
 			EmailValidationTokenID:        "email-validation-token-id-2",
@@ -42,6 +44,7 @@ func TestListCredentials(t *testing.T) {
 		},
 		&entities.Credential{
 			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:                      "default",
 			Email:                         "email-3",
 			Role:                          entities.RoleNone,
 			EmailValidationTokenID:        "email-validation-token-id-3",
@@ -51,6 +54,27 @@ func TestListCredentials(t *testing.T) {
 			CreatedAt:                     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
 			UpdatedAt:                     lo.ToPtr(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
 		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Merged into credentials 2.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "email-merged",
+			DeletedAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialMerge{
+			LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:   "default",
+			SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			MergedAt:   time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	testCases := []struct {
@@ -72,6 +96,7 @@ func TestListCredentials(t *testing.T) {
 			expect: []*entities.Credential{
 				{
 					ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TenantID:                      "default",
 					Email:                         "email-1",
 					Role:                          entities.RoleCore,
 					EmailValidationTokenID:        "email-validation-token-id-1",
@@ -83,6 +108,7 @@ func TestListCredentials(t *testing.T) {
 				},
 				{
 					ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					TenantID:                      "default",
 					Email:                         "email-3",
 					Role:                          entities.RoleNone,
 					EmailValidationTokenID:        "email-validation-token-id-3",
@@ -94,6 +120,28 @@ func TestListCredentials(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "FollowMerges",
+
+			ids: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			},
+
+			expect: []*entities.Credential{
+				{
+					ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					TenantID:                      "default",
+					Email:                         "email-2",
+					Role:                          entities.RoleAdmin,
+					EmailValidationTokenID:        "email-validation-token-id-2",
+					PendingEmailValidationTokenID: "pending-email-validation-token-id-2",
+					PasswordTokenID:               "password-token-id-2",
+					ResetPasswordTokenID:          "reset-password-token-id-2",
+					CreatedAt:                     time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt:                     lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
 		{
 			name: "IgnoreMissingIDs",
 
@@ -106,6 +154,7 @@ func TestListCredentials(t *testing.T) {
 			expect: []*entities.Credential{
 				{
 					ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TenantID:                      "default",
 					Email:                         "email-1",
 					Role:                          entities.RoleCore,
 					EmailValidationTokenID:        "email-validation-token-id-1",
@@ -117,6 +166,7 @@ func TestListCredentials(t *testing.T) {
 				},
 				{
 					ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					TenantID:                      "default",
 					Email:                         "email-3",
 					Role:                          entities.RoleNone,
 					EmailValidationTokenID:        "email-validation-token-id-3",
@@ -135,6 +185,15 @@ func TestListCredentials(t *testing.T) {
 				uuid.MustParse("00000000-0000-0000-0000-000000000004"),
 			},
 
+			expect: []*entities.Credential{},
+		},
+		{
+			name: "OtherTenant",
+
+			ids: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			},
+
 			expect: []*entities.Credential{},
 		},
 	}
@@ -150,7 +209,9 @@ func TestListCredentials(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			listCredentialsDAO := dao.NewListCredentials(transaction)
 
-			credential, err := listCredentialsDAO.Exec(context.Background(), testCase.ids)
+			credential, err := listCredentialsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.ids,
+			)
 
 			require.ErrorIs(t, err, testCase.expectErr)
 			require.Equal(t, testCase.expect, credential)
@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ListEmailDomainRulesRequest struct {
+	// Action only returns the rules with the given action, when set.
+	Action entities.EmailDomainAction
+	Limit  int
+	Offset int
+}
+
+type ListEmailDomainRules interface {
+	Exec(ctx context.Context, request *ListEmailDomainRulesRequest) ([]*entities.EmailDomainRule, error)
+}
+
+type listEmailDomainRulesImpl struct {
+	database bun.IDB
+}
+
+// Exec returns the custom rules of the tenant, sorted by domain.
+func (dao *listEmailDomainRulesImpl) Exec(
+	ctx context.Context, request *ListEmailDomainRulesRequest,
+) ([]*entities.EmailDomainRule, error) {
+	rules := make([]*entities.EmailDomainRule, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		query := tx.NewSelect().
+			Model(&rules).
+			Where("tenant_id = ?", tenantID).
+			Order("domain ASC").
+			Limit(request.Limit).
+			Offset(request.Offset)
+
+		if request.Action != "" {
+			query = query.Where("action = ?", request.Action)
+		}
+
+		if err := query.Scan(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func NewListEmailDomainRules(database bun.IDB) ListEmailDomainRules {
+	return &listEmailDomainRulesImpl{database: database}
+}
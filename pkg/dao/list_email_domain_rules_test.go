@@ -0,0 +1,126 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestListEmailDomainRules(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+			TenantID:  "default",
+			Domain:    "spam.example.com",
+			Action:    entities.EmailDomainActionDeny,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000002"),
+			TenantID:  "default",
+			Domain:    "partner.example.com",
+			Action:    entities.EmailDomainActionAllow,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000003"),
+			TenantID:  "default",
+			Domain:    "mailinator.com",
+			Action:    entities.EmailDomainActionDeny,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000099"),
+			TenantID:  "tenant-2",
+			Domain:    "example.com",
+			Action:    entities.EmailDomainActionDeny,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.ListEmailDomainRulesRequest
+
+		expect    []uuid.UUID
+		expectErr error
+	}{
+		{
+			name: "List",
+
+			request: &dao.ListEmailDomainRulesRequest{
+				Limit: 10,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0008-000000000003"),
+				uuid.MustParse("00000000-0000-0000-0008-000000000002"),
+				uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+			},
+		},
+		{
+			name: "List/Action",
+
+			request: &dao.ListEmailDomainRulesRequest{
+				Action: entities.EmailDomainActionDeny,
+				Limit:  10,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0008-000000000003"),
+				uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+			},
+		},
+		{
+			name: "List/Paginate",
+
+			request: &dao.ListEmailDomainRulesRequest{
+				Limit:  1,
+				Offset: 1,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0008-000000000002"),
+			},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			listEmailDomainRulesDAO := dao.NewListEmailDomainRules(transaction)
+
+			rules, err := listEmailDomainRulesDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(
+				t,
+				testCase.expect,
+				lo.Map(rules, func(item *entities.EmailDomainRule, _ int) uuid.UUID { return item.ID }),
+			)
+		})
+	}
+}
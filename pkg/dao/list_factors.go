@@ -0,0 +1,46 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ListFactors interface {
+	Exec(ctx context.Context, credentialID uuid.UUID) ([]*entities.CredentialFactor, error)
+}
+
+type listFactorsImpl struct {
+	database bun.IDB
+}
+
+func (dao *listFactorsImpl) Exec(ctx context.Context, credentialID uuid.UUID) ([]*entities.CredentialFactor, error) {
+	factors := make([]*entities.CredentialFactor, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.NewSelect().
+			Model(&factors).
+			Where("credential_id = ?", credentialID).
+			Where("tenant_id = ?", tenantID).
+			Order("created_at ASC", "id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return factors, nil
+}
+
+func NewListFactors(database bun.IDB) ListFactors {
+	return &listFactorsImpl{database: database}
+}
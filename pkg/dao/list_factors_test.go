@@ -0,0 +1,111 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestListFactors(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:    "default",
+			Email:       "email-1",
+			MFARequired: true,
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:         entities.FactorTypeTOTP,
+			Label:        "phone",
+			SecretRef:    "totp-secret-ref",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:                   uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			TenantID:             "default",
+			CredentialID:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:                 entities.FactorTypeWebAuthn,
+			Label:                "security key",
+			WebAuthnCredentialID: "webauthn-credential-id",
+			WebAuthnPublicKey:    []byte("public-key"),
+			CreatedAt:            time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			LastUsedAt:           lo.ToPtr(time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Type:         entities.FactorTypeRecoveryCodes,
+			Label:        "recovery codes",
+			SecretRef:    "recovery-codes-ref",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		credentialID uuid.UUID
+
+		expect    []*entities.CredentialFactor
+		expectErr error
+	}{
+		{
+			name: "List",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+
+			expect: []*entities.CredentialFactor{
+				fixtures[2].(*entities.CredentialFactor),
+				fixtures[3].(*entities.CredentialFactor),
+			},
+		},
+		{
+			name: "NoResults",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+
+			expect: []*entities.CredentialFactor{},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listFactorsDAO := dao.NewListFactors(transaction)
+
+			factors, err := listFactorsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.credentialID,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, factors)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ListInvitationsRequest struct {
+	// CreatedBy only returns the invitations issued by the given credentials, when set.
+	CreatedBy uuid.UUID
+	Limit     int
+	Offset    int
+}
+
+type ListInvitations interface {
+	Exec(ctx context.Context, request *ListInvitationsRequest) ([]*entities.Invitation, error)
+}
+
+type listInvitationsImpl struct {
+	database bun.IDB
+}
+
+// Exec returns the most recent invitations first, along with the credentials that redeemed them.
+func (dao *listInvitationsImpl) Exec(
+	ctx context.Context, request *ListInvitationsRequest,
+) ([]*entities.Invitation, error) {
+	invitations := make([]*entities.Invitation, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		query := tx.NewSelect().
+			Model(&invitations).
+			Where("tenant_id = ?", tenantID).
+			Order("created_at DESC", "id ASC").
+			Limit(request.Limit).
+			Offset(request.Offset)
+
+		if request.CreatedBy != uuid.Nil {
+			query = query.Where("created_by = ?", request.CreatedBy)
+		}
+
+		if err := query.Scan(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		if len(invitations) == 0 {
+			return nil
+		}
+
+		redemptions := make([]*entities.InvitationRedemption, 0)
+
+		err := tx.NewSelect().
+			Model(&redemptions).
+			Where("tenant_id = ?", tenantID).
+			Where("invitation_id IN (?)", bun.In(lo.Map(invitations, func(item *entities.Invitation, _ int) uuid.UUID {
+				return item.ID
+			}))).
+			Order("redeemed_at ASC", "credential_id ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select redemptions: %w", err)
+		}
+
+		byInvitation := lo.GroupBy(redemptions, func(item *entities.InvitationRedemption) uuid.UUID {
+			return item.InvitationID
+		})
+
+		for _, invitation := range invitations {
+			invitation.Redemptions = lo.ValueOr(byInvitation, invitation.ID, []*entities.InvitationRedemption{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func NewListInvitations(database bun.IDB) ListInvitations {
+	return &listInvitationsImpl{database: database}
+}
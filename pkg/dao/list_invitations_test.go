@@ -0,0 +1,185 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestListInvitations(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			Role:      entities.RoleEarlyAccessProgram,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+			TenantID:  "default",
+			Code:      "CODE-1",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   5,
+			Uses:      2,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+			TenantID:  "default",
+			Code:      "CODE-2",
+			Role:      entities.RoleAdmin,
+			MaxUses:   1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			CreatedAt: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.InvitationRedemption{
+			InvitationID: uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			RedeemedAt:   time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.InvitationRedemption{
+			InvitationID: uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			RedeemedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000099"),
+			TenantID:  "tenant-2",
+			Code:      "OTHER",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			CreatedAt: time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	invitation1 := &entities.Invitation{
+		ID:        uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+		TenantID:  "default",
+		Code:      "CODE-1",
+		Role:      entities.RoleEarlyAccessProgram,
+		MaxUses:   5,
+		Uses:      2,
+		CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Redemptions: []*entities.InvitationRedemption{
+			{
+				InvitationID: uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				RedeemedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				InvitationID: uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				RedeemedAt:   time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	invitation2 := &entities.Invitation{
+		ID:          uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+		TenantID:    "default",
+		Code:        "CODE-2",
+		Role:        entities.RoleAdmin,
+		MaxUses:     1,
+		CreatedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+		CreatedAt:   time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+		Redemptions: []*entities.InvitationRedemption{},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.ListInvitationsRequest
+
+		expect    []*entities.Invitation
+		expectErr error
+	}{
+		{
+			name: "List",
+
+			request: &dao.ListInvitationsRequest{Limit: 10},
+
+			expect: []*entities.Invitation{invitation2, invitation1},
+		},
+		{
+			name: "List/Paginated",
+
+			request: &dao.ListInvitationsRequest{Limit: 1, Offset: 1},
+
+			expect: []*entities.Invitation{invitation1},
+		},
+		{
+			name: "List/CreatedBy",
+
+			request: &dao.ListInvitationsRequest{
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Limit:     10,
+			},
+
+			expect: []*entities.Invitation{invitation1},
+		},
+		{
+			name: "List/Empty",
+
+			request: &dao.ListInvitationsRequest{Limit: 10, Offset: 10},
+
+			expect: []*entities.Invitation{},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			listInvitationsDAO := dao.NewListInvitations(transaction)
+
+			invitations, err := listInvitationsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, invitations)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ListMissingConsentsRequest struct {
+	Document entities.ConsentDocument
+	Version  string
+	// After is the last ID of the previous page. Pages are keyed on the ID rather than offset, so walking through a
+	// large tenant does not get slower with every page.
+	After uuid.UUID
+	Limit int
+}
+
+type ListMissingConsents interface {
+	Exec(ctx context.Context, request *ListMissingConsentsRequest) (uuid.UUIDs, error)
+}
+
+type listMissingConsentsImpl struct {
+	database bun.IDB
+}
+
+// Exec lists the users that have not accepted the given version of a document, or have withdrawn their consent to
+// it, sorted by ID.
+func (dao *listMissingConsentsImpl) Exec(
+	ctx context.Context, request *ListMissingConsentsRequest,
+) (uuid.UUIDs, error) {
+	credentials := make([]*entities.Credential, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		// The anti-join is resolved on the partial index of active consents.
+		accepted := tx.NewSelect().
+			Model((*entities.CredentialConsent)(nil)).
+			ColumnExpr("1").
+			Where("credential_consents.tenant_id = credentials.tenant_id").
+			Where("credential_consents.credential_id = credentials.id").
+			Where("credential_consents.document = ?", request.Document).
+			Where("credential_consents.version = ?", request.Version).
+			Where("credential_consents.withdrawn_at IS NULL")
+
+		query := tx.NewSelect().
+			Model(&credentials).
+			Column("id").
+			Where("credentials.tenant_id = ?", tenantID).
+			Where("credentials.kind = ?", entities.CredentialKindUser).
+			Where("credentials.erased_at IS NULL").
+			Where("credentials.deleted_at IS NULL").
+			Where("NOT EXISTS (?)", accepted).
+			Order("credentials.id ASC").
+			Limit(request.Limit)
+
+		if request.After != uuid.Nil {
+			query = query.Where("credentials.id > ?", request.After)
+		}
+
+		if err := query.Scan(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(credentials, func(item *entities.Credential, _ int) uuid.UUID { return item.ID }), nil
+}
+
+func NewListMissingConsents(database bun.IDB) ListMissingConsents {
+	return &listMissingConsentsImpl{database: database}
+}
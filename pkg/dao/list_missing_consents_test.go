@@ -0,0 +1,191 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestListMissingConsents(t *testing.T) {
+	fixtures := []interface{}{
+		// Accepted the latest version.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Document:     entities.ConsentDocumentTOS,
+			Version:      "v2",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		// Only accepted a previous version.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Document:     entities.ConsentDocumentTOS,
+			Version:      "v1",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		// Withdrew the latest version.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Document:     entities.ConsentDocumentTOS,
+			Version:      "v2",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			WithdrawnAt:  lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+		},
+		// Accepted the latest version of another document.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "email-4",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000004"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			Document:     entities.ConsentDocumentPrivacy,
+			Version:      "v2",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		// Service accounts and erased credentials never accept documents.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-5",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			TenantID:  "default",
+			Email:     "erased-0006@erased.invalid",
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.ListMissingConsentsRequest
+
+		expect    uuid.UUIDs
+		expectErr error
+	}{
+		{
+			name: "Missing",
+
+			request: &dao.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				Limit:    10,
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			},
+		},
+		{
+			name: "Paginate",
+
+			request: &dao.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				After:    uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Limit:    1,
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+		},
+		{
+			name: "OtherDocument",
+
+			request: &dao.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentPrivacy,
+				Version:  "v2",
+				Limit:    10,
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+		},
+		{
+			name: "NoneMissing",
+
+			request: &dao.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				After:    uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Limit:    10,
+			},
+
+			expect: uuid.UUIDs{},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listMissingConsentsDAO := dao.NewListMissingConsents(transaction)
+
+			ids, err := listMissingConsentsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, ids)
+		})
+	}
+}
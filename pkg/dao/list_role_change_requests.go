@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ListRoleChangeRequestsRequest struct {
+	// CredentialID only returns the requests concerning the given credentials, when set.
+	CredentialID uuid.UUID
+	// Pending only returns the requests still awaiting a decision.
+	Pending bool
+	Limit   int
+	Offset  int
+}
+
+type ListRoleChangeRequests interface {
+	Exec(
+		ctx context.Context, now time.Time, request *ListRoleChangeRequestsRequest,
+	) ([]*entities.RoleChangeRequest, error)
+}
+
+type listRoleChangeRequestsImpl struct {
+	database bun.IDB
+}
+
+// Exec returns the most recent role change requests first.
+func (dao *listRoleChangeRequestsImpl) Exec(
+	ctx context.Context, now time.Time, request *ListRoleChangeRequestsRequest,
+) ([]*entities.RoleChangeRequest, error) {
+	requests := make([]*entities.RoleChangeRequest, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		query := tx.NewSelect().
+			Model(&requests).
+			Where("tenant_id = ?", tenantID).
+			Order("created_at DESC", "id ASC").
+			Limit(request.Limit).
+			Offset(request.Offset)
+
+		if request.CredentialID != uuid.Nil {
+			query = query.Where("credential_id = ?", request.CredentialID)
+		}
+
+		if request.Pending {
+			query = query.
+				Where("status = ?", entities.RoleChangeRequestStatusPending).
+				Where("expires_at > ?", now)
+		}
+
+		if err := query.Scan(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func NewListRoleChangeRequests(database bun.IDB) ListRoleChangeRequests {
+	return &listRoleChangeRequestsImpl{database: database}
+}
@@ -0,0 +1,176 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestListRoleChangeRequests(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Role:         entities.RoleCore,
+			Status:       entities.RoleChangeRequestStatusRejected,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000003")),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			DecidedAt:    lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		// Still stored as pending, but expired.
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt:    time.Date(2021, 1, 25, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 18, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000099"),
+			TenantID:     "tenant-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 30, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.ListRoleChangeRequestsRequest
+
+		expect    []uuid.UUID
+		expectErr error
+	}{
+		{
+			name: "List",
+
+			request: &dao.ListRoleChangeRequestsRequest{
+				Limit: 10,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0007-000000000003"),
+				uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+			},
+		},
+		{
+			name: "List/Pending",
+
+			request: &dao.ListRoleChangeRequestsRequest{
+				Pending: true,
+				Limit:   10,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			},
+		},
+		{
+			name: "List/CredentialID",
+
+			request: &dao.ListRoleChangeRequestsRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Limit:        10,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+			},
+		},
+		{
+			name: "List/Paginate",
+
+			request: &dao.ListRoleChangeRequestsRequest{
+				Limit:  1,
+				Offset: 1,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0007-000000000003"),
+			},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			listRoleChangeRequestsDAO := dao.NewListRoleChangeRequests(transaction)
+
+			requests, err := listRoleChangeRequestsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(
+				t,
+				testCase.expect,
+				lo.Map(requests, func(item *entities.RoleChangeRequest, _ int) uuid.UUID { return item.ID }),
+			)
+		})
+	}
+}
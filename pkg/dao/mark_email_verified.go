@@ -0,0 +1,61 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type MarkEmailVerified interface {
+	Exec(ctx context.Context, id uuid.UUID, now time.Time) (*entities.Credential, error)
+}
+
+type markEmailVerifiedImpl struct {
+	database bun.IDB
+}
+
+// Exec marks the email of user credentials as verified. Credentials that are already verified keep their original
+// verification date. The email validation token is no longer needed, and is cleared.
+func (dao *markEmailVerifiedImpl) Exec(ctx context.Context, id uuid.UUID, now time.Time) (*entities.Credential, error) {
+	model := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.
+			NewUpdate().
+			Model(model).
+			Set("email_verified_at = COALESCE(email_verified_at, ?)", now).
+			Set("email_validation_token_id = NULL").
+			Set("email_validation_token_expires_at = NULL").
+			Set("updated_at = ?", now).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewMarkEmailVerified(database bun.IDB) MarkEmailVerified {
+	return &markEmailVerifiedImpl{database: database}
+}
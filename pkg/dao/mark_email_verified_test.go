@@ -0,0 +1,143 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestMarkEmailVerified(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:                      "default",
+			Email:                         "email-1",
+			Role:                          entities.RoleCore,
+			EmailValidationTokenID:        "email-validation-token-id",
+			EmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			PasswordTokenID:               "password-token-id",
+			CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:        "default",
+			Email:           "email-2",
+			EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			Role:            entities.RoleCore,
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-1",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id  uuid.UUID
+		now time.Time
+
+		expect    *entities.Credential
+		expectErr error
+	}{
+		{
+			name: "Verify",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+
+			expect: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:        "default",
+				Email:           "email-1",
+				EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+				Role:            entities.RoleCore,
+				PasswordTokenID: "password-token-id",
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "AlreadyVerified",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+
+			expect: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:        "default",
+				Email:           "email-2",
+				EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				Role:            entities.RoleCore,
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "ServiceAccount",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			markEmailVerifiedDAO := dao.NewMarkEmailVerified(transaction)
+
+			credential, err := markEmailVerifiedDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+		})
+	}
+}
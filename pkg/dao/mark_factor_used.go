@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type MarkFactorUsedRequest struct {
+	CredentialID uuid.UUID
+}
+
+type MarkFactorUsed interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *MarkFactorUsedRequest,
+	) (*entities.CredentialFactor, error)
+}
+
+type markFactorUsedImpl struct {
+	database bun.IDB
+}
+
+func (dao *markFactorUsedImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *MarkFactorUsedRequest,
+) (*entities.CredentialFactor, error) {
+	model := new(entities.CredentialFactor)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		res, err := tx.NewUpdate().
+			Model(model).
+			Set("last_used_at = ?", now).
+			Where("id = ?", id).
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return ErrFactorNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewMarkFactorUsed(database bun.IDB) MarkFactorUsed {
+	return &markFactorUsedImpl{database: database}
+}
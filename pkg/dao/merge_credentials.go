@@ -0,0 +1,205 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type MergeCredentialsRequest struct {
+	SurvivorID uuid.UUID
+	LoserID    uuid.UUID
+}
+
+type MergeCredentials interface {
+	Exec(ctx context.Context, now time.Time, request *MergeCredentialsRequest) (*entities.Credential, error)
+}
+
+type mergeCredentialsImpl struct {
+	database bun.IDB
+	// protected roles must keep at least one holder.
+	protected []entities.Role
+}
+
+// Exec moves the linked data of the loser to the survivor, redirects the loser to the survivor, and soft-deletes
+// the loser. It returns the updated survivor.
+//
+// Identities, factors, notes and owned service accounts are moved. Labels are combined, the survivor winning on
+// conflicts. Factors whose label is already used by the survivor are renamed. Consents stay on the loser, as proof of
+// what its owner accepted.
+func (dao *mergeCredentialsImpl) Exec(
+	ctx context.Context, now time.Time, request *MergeCredentialsRequest,
+) (*entities.Credential, error) {
+	survivor := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		// Rows are locked in a fixed order, so concurrent merges of the same pair cannot deadlock.
+		locked := make([]*entities.Credential, 0)
+
+		err := tx.NewSelect().
+			Model(&locked).
+			Where("id IN (?)", bun.In([]uuid.UUID{request.SurvivorID, request.LoserID})).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			Order("id ASC").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("lock credentials: %w", err)
+		}
+
+		if len(locked) != 2 {
+			return ErrCredentialsNotFound
+		}
+
+		for _, credential := range locked {
+			if credential.LegalHold {
+				return ErrCredentialsOnLegalHold
+			}
+		}
+
+		// The survivor keeps its own role, so the loser stops holding its role.
+		loser, _ := lo.Find(locked, func(item *entities.Credential) bool { return item.ID == request.LoserID })
+		if loser.DeletionScheduledAt == nil {
+			if err = checkLastRoleHolder(ctx, tx, tenantID, dao.protected, loser); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*entities.CredentialIdentity)(nil)).
+			Set("credential_id = ?", request.SurvivorID).
+			Where("credential_id = ?", request.LoserID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("move identities: %w", err)
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*entities.CredentialNote)(nil)).
+			Set("credential_id = ?", request.SurvivorID).
+			Where("credential_id = ?", request.LoserID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("move notes: %w", err)
+		}
+
+		// The condition is evaluated on the snapshot taken before the update, so moved factors are only compared to
+		// the original factors of the survivor.
+		_, err = tx.NewUpdate().
+			Model((*entities.CredentialFactor)(nil)).
+			Set("credential_id = ?", request.SurvivorID).
+			Set(
+				"label = CASE WHEN EXISTS (?) THEN label || ' (' || left(id::text, 8) || ')' ELSE label END",
+				tx.NewSelect().
+					TableExpr("credential_factors AS survivor_factors").
+					ColumnExpr("1").
+					Where("survivor_factors.credential_id = ?", request.SurvivorID).
+					Where("survivor_factors.label = credential_factors.label"),
+			).
+			Where("credential_id = ?", request.LoserID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("move factors: %w", err)
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*entities.Credential)(nil)).
+			Set("owner_id = ?", request.SurvivorID).
+			Set("updated_at = ?", now).
+			Where("owner_id = ?", request.LoserID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("move service accounts: %w", err)
+		}
+
+		// Keep redirects to a single hop.
+		_, err = tx.NewUpdate().
+			Model((*entities.CredentialMerge)(nil)).
+			Set("survivor_id = ?", request.SurvivorID).
+			Where("survivor_id = ?", request.LoserID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("update redirects: %w", err)
+		}
+
+		_, err = tx.NewInsert().
+			Model(&entities.CredentialMerge{
+				LoserID:    request.LoserID,
+				TenantID:   tenantID,
+				SurvivorID: request.SurvivorID,
+				MergedAt:   now,
+			}).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("record merge: %w", err)
+		}
+
+		loserColumn := func(column string) *bun.SelectQuery {
+			return tx.NewSelect().
+				TableExpr("credentials AS loser").
+				ColumnExpr("loser.?", bun.Ident(column)).
+				Where("loser.id = ?", request.LoserID)
+		}
+
+		err = tx.NewUpdate().
+			Model(survivor).
+			Set(
+				"labels = NULLIF(COALESCE((?), '{}'::jsonb) || COALESCE(labels, '{}'::jsonb), '{}'::jsonb)",
+				loserColumn("labels"),
+			).
+			Set("mfa_required = mfa_required OR (?)", loserColumn("mfa_required")).
+			Set("updated_at = ?", now).
+			Where("id = ?", request.SurvivorID).
+			Where("tenant_id = ?", tenantID).
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("update survivor: %w", err)
+		}
+
+		// The loser can no longer sign in, nor be deleted on its own schedule.
+		deleteLoser := tx.NewUpdate().
+			Model((*entities.Credential)(nil)).
+			Set("deleted_at = ?", now).
+			Set("deletion_scheduled_at = NULL").
+			Set("token_epoch = token_epoch + 1").
+			Set("updated_at = ?", now)
+
+		for _, column := range tokenColumns {
+			deleteLoser = deleteLoser.Set("? = NULL", column.id).Set("? = NULL", column.expiresAt)
+		}
+
+		_, err = deleteLoser.
+			Where("id = ?", request.LoserID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("delete loser: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return survivor, nil
+}
+
+func NewMergeCredentials(database bun.IDB, protected []entities.Role) MergeCredentials {
+	return &mergeCredentialsImpl{database: database, protected: protected}
+}
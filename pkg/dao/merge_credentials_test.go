@@ -0,0 +1,311 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestMergeCredentials(t *testing.T) {
+	fixtures := []interface{}{
+		// Survivor.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleCore,
+			Labels:    map[string]string{"team": "core"},
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:         entities.FactorTypeTOTP,
+			Label:        "phone",
+			SecretRef:    "secret-ref-1",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Loser.
+		&entities.Credential{
+			ID:                   uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:             "default",
+			Email:                "email-2",
+			Labels:               map[string]string{"team": "growth", "source": "ads"},
+			MFARequired:          true,
+			TokenEpoch:           1,
+			PasswordTokenID:      "password-token-2",
+			ResetPasswordTokenID: "reset-password-token-2",
+			DeletionScheduledAt:  lo.ToPtr(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "github",
+			Subject:      "subject-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Type:         entities.FactorTypeTOTP,
+			Label:        "phone",
+			SecretRef:    "secret-ref-2",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Type:         entities.FactorTypeTOTP,
+			Label:        "tablet",
+			SecretRef:    "secret-ref-3",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialNote{
+			ID:           uuid.MustParse("00000000-0000-0000-0004-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Author:       "agent@example.com",
+			Body:         "duplicate of email-1",
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-3",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Previously merged into the loser.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "email-4",
+			DeletedAt: lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialMerge{
+			LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:   "default",
+			SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			MergedAt:   time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now       time.Time
+		request   *dao.MergeCredentialsRequest
+		protected []entities.Role
+
+		expect    *entities.Credential
+		expectErr error
+	}{
+		{
+			name: "Merge",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.MergeCredentialsRequest{
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expect: &entities.Credential{
+				ID:          uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:    "default",
+				Email:       "email-1",
+				Role:        entities.RoleCore,
+				Labels:      map[string]string{"team": "core", "source": "ads"},
+				MFARequired: true,
+				CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:   lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "NotFound",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.MergeCredentialsRequest{
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "AlreadyMerged",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.MergeCredentialsRequest{
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "ServiceAccount",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.MergeCredentialsRequest{
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "LegalHold",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.MergeCredentialsRequest{
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			},
+
+			expectErr: dao.ErrCredentialsOnLegalHold,
+		},
+		{
+			name: "LastProtectedRoleHolder",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.MergeCredentialsRequest{
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+			protected: []entities.Role{entities.RoleCore},
+
+			expectErr: dao.ErrLastProtectedRoleHolder,
+		},
+		{
+			name: "OtherTenant",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.MergeCredentialsRequest{
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			mergeCredentialsDAO := dao.NewMergeCredentials(transaction, testCase.protected)
+
+			credential, err := mergeCredentialsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+
+			if testCase.expectErr != nil {
+				return
+			}
+
+			survivorID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+			for _, child := range []interface{}{
+				(*entities.CredentialIdentity)(nil),
+				(*entities.CredentialNote)(nil),
+			} {
+				moved, err := transaction.NewSelect().
+					Model(child).
+					Where("credential_id = ?", survivorID).
+					Count(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, 1, moved)
+			}
+
+			var labels []string
+			err = transaction.NewSelect().
+				Model((*entities.CredentialFactor)(nil)).
+				Column("label").
+				Where("credential_id = ?", survivorID).
+				Order("id ASC").
+				Scan(context.Background(), &labels)
+			require.NoError(t, err)
+			require.Equal(t, []string{"phone", "phone (00000000)", "tablet"}, labels)
+
+			service := new(entities.Credential)
+			err = transaction.NewSelect().
+				Model(service).
+				Where("id = ?", uuid.MustParse("00000000-0000-0000-0000-000000000003")).
+				Scan(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, survivorID, service.OwnerID)
+
+			loser := new(entities.Credential)
+			err = transaction.NewSelect().
+				Model(loser).
+				Where("id = ?", uuid.MustParse("00000000-0000-0000-0000-000000000002")).
+				Scan(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, &entities.Credential{
+				ID:          uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:    "default",
+				Email:       "email-2",
+				Labels:      map[string]string{"team": "growth", "source": "ads"},
+				MFARequired: true,
+				TokenEpoch:  2,
+				DeletedAt:   lo.ToPtr(testCase.now),
+				CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:   lo.ToPtr(testCase.now),
+			}, loser)
+
+			// Both the loser and the credentials previously merged into it now redirect to the survivor.
+			var redirects []uuid.UUID
+			err = transaction.NewSelect().
+				Model((*entities.CredentialMerge)(nil)).
+				Column("survivor_id").
+				Where("loser_id IN (?)", bun.In([]uuid.UUID{
+					uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				})).
+				Scan(context.Background(), &redirects)
+			require.NoError(t, err)
+			require.Equal(t, []uuid.UUID{survivorID, survivorID}, redirects)
+		})
+	}
+}
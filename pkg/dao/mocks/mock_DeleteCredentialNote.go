@@ -0,0 +1,87 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockDeleteCredentialNote is an autogenerated mock type for the DeleteCredentialNote type
+type MockDeleteCredentialNote struct {
+	mock.Mock
+}
+
+type MockDeleteCredentialNote_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeleteCredentialNote) EXPECT() *MockDeleteCredentialNote_Expecter {
+	return &MockDeleteCredentialNote_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, id, request
+func (_m *MockDeleteCredentialNote) Exec(ctx context.Context, id uuid.UUID, request *dao.DeleteCredentialNoteRequest) error {
+	ret := _m.Called(ctx, id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *dao.DeleteCredentialNoteRequest) error); ok {
+		r0 = rf(ctx, id, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeleteCredentialNote_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockDeleteCredentialNote_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - request *dao.DeleteCredentialNoteRequest
+func (_e *MockDeleteCredentialNote_Expecter) Exec(ctx interface{}, id interface{}, request interface{}) *MockDeleteCredentialNote_Exec_Call {
+	return &MockDeleteCredentialNote_Exec_Call{Call: _e.mock.On("Exec", ctx, id, request)}
+}
+
+func (_c *MockDeleteCredentialNote_Exec_Call) Run(run func(ctx context.Context, id uuid.UUID, request *dao.DeleteCredentialNoteRequest)) *MockDeleteCredentialNote_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*dao.DeleteCredentialNoteRequest))
+	})
+	return _c
+}
+
+func (_c *MockDeleteCredentialNote_Exec_Call) Return(_a0 error) *MockDeleteCredentialNote_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeleteCredentialNote_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID, *dao.DeleteCredentialNoteRequest) error) *MockDeleteCredentialNote_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeleteCredentialNote creates a new instance of MockDeleteCredentialNote. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeleteCredentialNote(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeleteCredentialNote {
+	mock := &MockDeleteCredentialNote{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
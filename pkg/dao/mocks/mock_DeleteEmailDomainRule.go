@@ -0,0 +1,83 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDeleteEmailDomainRule is an autogenerated mock type for the DeleteEmailDomainRule type
+type MockDeleteEmailDomainRule struct {
+	mock.Mock
+}
+
+type MockDeleteEmailDomainRule_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeleteEmailDomainRule) EXPECT() *MockDeleteEmailDomainRule_Expecter {
+	return &MockDeleteEmailDomainRule_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, domain
+func (_m *MockDeleteEmailDomainRule) Exec(ctx context.Context, domain string) error {
+	ret := _m.Called(ctx, domain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, domain)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeleteEmailDomainRule_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockDeleteEmailDomainRule_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain string
+func (_e *MockDeleteEmailDomainRule_Expecter) Exec(ctx interface{}, domain interface{}) *MockDeleteEmailDomainRule_Exec_Call {
+	return &MockDeleteEmailDomainRule_Exec_Call{Call: _e.mock.On("Exec", ctx, domain)}
+}
+
+func (_c *MockDeleteEmailDomainRule_Exec_Call) Run(run func(ctx context.Context, domain string)) *MockDeleteEmailDomainRule_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDeleteEmailDomainRule_Exec_Call) Return(_a0 error) *MockDeleteEmailDomainRule_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeleteEmailDomainRule_Exec_Call) RunAndReturn(run func(context.Context, string) error) *MockDeleteEmailDomainRule_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeleteEmailDomainRule creates a new instance of MockDeleteEmailDomainRule. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeleteEmailDomainRule(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeleteEmailDomainRule {
+	mock := &MockDeleteEmailDomainRule{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
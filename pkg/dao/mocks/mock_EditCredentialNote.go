@@ -0,0 +1,104 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockEditCredentialNote is an autogenerated mock type for the EditCredentialNote type
+type MockEditCredentialNote struct {
+	mock.Mock
+}
+
+type MockEditCredentialNote_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEditCredentialNote) EXPECT() *MockEditCredentialNote_Expecter {
+	return &MockEditCredentialNote_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, id, now, request
+func (_m *MockEditCredentialNote) Exec(ctx context.Context, id uuid.UUID, now time.Time, request *dao.EditCredentialNoteRequest) (*entities.CredentialNote, error) {
+	ret := _m.Called(ctx, id, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.CredentialNote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *dao.EditCredentialNoteRequest) (*entities.CredentialNote, error)); ok {
+		return rf(ctx, id, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *dao.EditCredentialNoteRequest) *entities.CredentialNote); ok {
+		r0 = rf(ctx, id, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.CredentialNote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, *dao.EditCredentialNoteRequest) error); ok {
+		r1 = rf(ctx, id, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEditCredentialNote_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockEditCredentialNote_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+//   - request *dao.EditCredentialNoteRequest
+func (_e *MockEditCredentialNote_Expecter) Exec(ctx interface{}, id interface{}, now interface{}, request interface{}) *MockEditCredentialNote_Exec_Call {
+	return &MockEditCredentialNote_Exec_Call{Call: _e.mock.On("Exec", ctx, id, now, request)}
+}
+
+func (_c *MockEditCredentialNote_Exec_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time, request *dao.EditCredentialNoteRequest)) *MockEditCredentialNote_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(*dao.EditCredentialNoteRequest))
+	})
+	return _c
+}
+
+func (_c *MockEditCredentialNote_Exec_Call) Return(_a0 *entities.CredentialNote, _a1 error) *MockEditCredentialNote_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEditCredentialNote_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, *dao.EditCredentialNoteRequest) (*entities.CredentialNote, error)) *MockEditCredentialNote_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEditCredentialNote creates a new instance of MockEditCredentialNote. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEditCredentialNote(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEditCredentialNote {
+	mock := &MockEditCredentialNote{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,104 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockEnrollFactor is an autogenerated mock type for the EnrollFactor type
+type MockEnrollFactor struct {
+	mock.Mock
+}
+
+type MockEnrollFactor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEnrollFactor) EXPECT() *MockEnrollFactor_Expecter {
+	return &MockEnrollFactor_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, id, now, request
+func (_m *MockEnrollFactor) Exec(ctx context.Context, id uuid.UUID, now time.Time, request *dao.EnrollFactorRequest) (*entities.CredentialFactor, error) {
+	ret := _m.Called(ctx, id, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.CredentialFactor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *dao.EnrollFactorRequest) (*entities.CredentialFactor, error)); ok {
+		return rf(ctx, id, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *dao.EnrollFactorRequest) *entities.CredentialFactor); ok {
+		r0 = rf(ctx, id, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.CredentialFactor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, *dao.EnrollFactorRequest) error); ok {
+		r1 = rf(ctx, id, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEnrollFactor_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockEnrollFactor_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+//   - request *dao.EnrollFactorRequest
+func (_e *MockEnrollFactor_Expecter) Exec(ctx interface{}, id interface{}, now interface{}, request interface{}) *MockEnrollFactor_Exec_Call {
+	return &MockEnrollFactor_Exec_Call{Call: _e.mock.On("Exec", ctx, id, now, request)}
+}
+
+func (_c *MockEnrollFactor_Exec_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time, request *dao.EnrollFactorRequest)) *MockEnrollFactor_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(*dao.EnrollFactorRequest))
+	})
+	return _c
+}
+
+func (_c *MockEnrollFactor_Exec_Call) Return(_a0 *entities.CredentialFactor, _a1 error) *MockEnrollFactor_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEnrollFactor_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, *dao.EnrollFactorRequest) (*entities.CredentialFactor, error)) *MockEnrollFactor_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEnrollFactor creates a new instance of MockEnrollFactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEnrollFactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEnrollFactor {
+	mock := &MockEnrollFactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockEraseScheduledCredentials is an autogenerated mock type for the EraseScheduledCredentials type
+type MockEraseScheduledCredentials struct {
+	mock.Mock
+}
+
+type MockEraseScheduledCredentials_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEraseScheduledCredentials) EXPECT() *MockEraseScheduledCredentials_Expecter {
+	return &MockEraseScheduledCredentials_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now
+func (_m *MockEraseScheduledCredentials) Exec(ctx context.Context, now time.Time) ([]*entities.CredentialDeletion, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 []*entities.CredentialDeletion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entities.CredentialDeletion, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*entities.CredentialDeletion); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.CredentialDeletion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEraseScheduledCredentials_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockEraseScheduledCredentials_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockEraseScheduledCredentials_Expecter) Exec(ctx interface{}, now interface{}) *MockEraseScheduledCredentials_Exec_Call {
+	return &MockEraseScheduledCredentials_Exec_Call{Call: _e.mock.On("Exec", ctx, now)}
+}
+
+func (_c *MockEraseScheduledCredentials_Exec_Call) Run(run func(ctx context.Context, now time.Time)) *MockEraseScheduledCredentials_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockEraseScheduledCredentials_Exec_Call) Return(_a0 []*entities.CredentialDeletion, _a1 error) *MockEraseScheduledCredentials_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEraseScheduledCredentials_Exec_Call) RunAndReturn(run func(context.Context, time.Time) ([]*entities.CredentialDeletion, error)) *MockEraseScheduledCredentials_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEraseScheduledCredentials creates a new instance of MockEraseScheduledCredentials. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEraseScheduledCredentials(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEraseScheduledCredentials {
+	mock := &MockEraseScheduledCredentials{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockExportCredentialData is an autogenerated mock type for the ExportCredentialData type
+type MockExportCredentialData struct {
+	mock.Mock
+}
+
+type MockExportCredentialData_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportCredentialData) EXPECT() *MockExportCredentialData_Expecter {
+	return &MockExportCredentialData_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, id
+func (_m *MockExportCredentialData) Exec(ctx context.Context, id uuid.UUID) (*dao.ExportCredentialDataResult, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *dao.ExportCredentialDataResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*dao.ExportCredentialDataResult, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *dao.ExportCredentialDataResult); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.ExportCredentialDataResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExportCredentialData_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockExportCredentialData_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockExportCredentialData_Expecter) Exec(ctx interface{}, id interface{}) *MockExportCredentialData_Exec_Call {
+	return &MockExportCredentialData_Exec_Call{Call: _e.mock.On("Exec", ctx, id)}
+}
+
+func (_c *MockExportCredentialData_Exec_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockExportCredentialData_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExportCredentialData_Exec_Call) Return(_a0 *dao.ExportCredentialDataResult, _a1 error) *MockExportCredentialData_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExportCredentialData_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*dao.ExportCredentialDataResult, error)) *MockExportCredentialData_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportCredentialData creates a new instance of MockExportCredentialData. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportCredentialData(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportCredentialData {
+	mock := &MockExportCredentialData{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
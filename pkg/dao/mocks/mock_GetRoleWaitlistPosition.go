@@ -0,0 +1,98 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetRoleWaitlistPosition is an autogenerated mock type for the GetRoleWaitlistPosition type
+type MockGetRoleWaitlistPosition struct {
+	mock.Mock
+}
+
+type MockGetRoleWaitlistPosition_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetRoleWaitlistPosition) EXPECT() *MockGetRoleWaitlistPosition_Expecter {
+	return &MockGetRoleWaitlistPosition_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockGetRoleWaitlistPosition) Exec(ctx context.Context, request *dao.GetRoleWaitlistPositionRequest) (*entities.RoleWaitlistPosition, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.RoleWaitlistPosition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.GetRoleWaitlistPositionRequest) (*entities.RoleWaitlistPosition, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.GetRoleWaitlistPositionRequest) *entities.RoleWaitlistPosition); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.RoleWaitlistPosition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.GetRoleWaitlistPositionRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetRoleWaitlistPosition_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockGetRoleWaitlistPosition_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.GetRoleWaitlistPositionRequest
+func (_e *MockGetRoleWaitlistPosition_Expecter) Exec(ctx interface{}, request interface{}) *MockGetRoleWaitlistPosition_Exec_Call {
+	return &MockGetRoleWaitlistPosition_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockGetRoleWaitlistPosition_Exec_Call) Run(run func(ctx context.Context, request *dao.GetRoleWaitlistPositionRequest)) *MockGetRoleWaitlistPosition_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.GetRoleWaitlistPositionRequest))
+	})
+	return _c
+}
+
+func (_c *MockGetRoleWaitlistPosition_Exec_Call) Return(_a0 *entities.RoleWaitlistPosition, _a1 error) *MockGetRoleWaitlistPosition_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetRoleWaitlistPosition_Exec_Call) RunAndReturn(run func(context.Context, *dao.GetRoleWaitlistPositionRequest) (*entities.RoleWaitlistPosition, error)) *MockGetRoleWaitlistPosition_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetRoleWaitlistPosition creates a new instance of MockGetRoleWaitlistPosition. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetRoleWaitlistPosition(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetRoleWaitlistPosition {
+	mock := &MockGetRoleWaitlistPosition{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
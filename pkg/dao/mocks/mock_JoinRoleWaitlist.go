@@ -0,0 +1,104 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockJoinRoleWaitlist is an autogenerated mock type for the JoinRoleWaitlist type
+type MockJoinRoleWaitlist struct {
+	mock.Mock
+}
+
+type MockJoinRoleWaitlist_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJoinRoleWaitlist) EXPECT() *MockJoinRoleWaitlist_Expecter {
+	return &MockJoinRoleWaitlist_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, id, now, request
+func (_m *MockJoinRoleWaitlist) Exec(ctx context.Context, id uuid.UUID, now time.Time, request *dao.JoinRoleWaitlistRequest) (*entities.RoleWaitlistPosition, error) {
+	ret := _m.Called(ctx, id, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.RoleWaitlistPosition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *dao.JoinRoleWaitlistRequest) (*entities.RoleWaitlistPosition, error)); ok {
+		return rf(ctx, id, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *dao.JoinRoleWaitlistRequest) *entities.RoleWaitlistPosition); ok {
+		r0 = rf(ctx, id, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.RoleWaitlistPosition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, *dao.JoinRoleWaitlistRequest) error); ok {
+		r1 = rf(ctx, id, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJoinRoleWaitlist_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockJoinRoleWaitlist_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+//   - request *dao.JoinRoleWaitlistRequest
+func (_e *MockJoinRoleWaitlist_Expecter) Exec(ctx interface{}, id interface{}, now interface{}, request interface{}) *MockJoinRoleWaitlist_Exec_Call {
+	return &MockJoinRoleWaitlist_Exec_Call{Call: _e.mock.On("Exec", ctx, id, now, request)}
+}
+
+func (_c *MockJoinRoleWaitlist_Exec_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time, request *dao.JoinRoleWaitlistRequest)) *MockJoinRoleWaitlist_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(*dao.JoinRoleWaitlistRequest))
+	})
+	return _c
+}
+
+func (_c *MockJoinRoleWaitlist_Exec_Call) Return(_a0 *entities.RoleWaitlistPosition, _a1 error) *MockJoinRoleWaitlist_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJoinRoleWaitlist_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, *dao.JoinRoleWaitlistRequest) (*entities.RoleWaitlistPosition, error)) *MockJoinRoleWaitlist_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockJoinRoleWaitlist creates a new instance of MockJoinRoleWaitlist. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJoinRoleWaitlist(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJoinRoleWaitlist {
+	mock := &MockJoinRoleWaitlist{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockListCredentialNotes is an autogenerated mock type for the ListCredentialNotes type
+type MockListCredentialNotes struct {
+	mock.Mock
+}
+
+type MockListCredentialNotes_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListCredentialNotes) EXPECT() *MockListCredentialNotes_Expecter {
+	return &MockListCredentialNotes_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, credentialID
+func (_m *MockListCredentialNotes) Exec(ctx context.Context, credentialID uuid.UUID) ([]*entities.CredentialNote, error) {
+	ret := _m.Called(ctx, credentialID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 []*entities.CredentialNote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*entities.CredentialNote, error)); ok {
+		return rf(ctx, credentialID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*entities.CredentialNote); ok {
+		r0 = rf(ctx, credentialID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.CredentialNote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, credentialID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListCredentialNotes_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockListCredentialNotes_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credentialID uuid.UUID
+func (_e *MockListCredentialNotes_Expecter) Exec(ctx interface{}, credentialID interface{}) *MockListCredentialNotes_Exec_Call {
+	return &MockListCredentialNotes_Exec_Call{Call: _e.mock.On("Exec", ctx, credentialID)}
+}
+
+func (_c *MockListCredentialNotes_Exec_Call) Run(run func(ctx context.Context, credentialID uuid.UUID)) *MockListCredentialNotes_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockListCredentialNotes_Exec_Call) Return(_a0 []*entities.CredentialNote, _a1 error) *MockListCredentialNotes_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListCredentialNotes_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*entities.CredentialNote, error)) *MockListCredentialNotes_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListCredentialNotes creates a new instance of MockListCredentialNotes. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListCredentialNotes(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListCredentialNotes {
+	mock := &MockListCredentialNotes{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
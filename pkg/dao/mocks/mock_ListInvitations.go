@@ -0,0 +1,98 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListInvitations is an autogenerated mock type for the ListInvitations type
+type MockListInvitations struct {
+	mock.Mock
+}
+
+type MockListInvitations_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListInvitations) EXPECT() *MockListInvitations_Expecter {
+	return &MockListInvitations_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockListInvitations) Exec(ctx context.Context, request *dao.ListInvitationsRequest) ([]*entities.Invitation, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 []*entities.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.ListInvitationsRequest) ([]*entities.Invitation, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.ListInvitationsRequest) []*entities.Invitation); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.ListInvitationsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListInvitations_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockListInvitations_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.ListInvitationsRequest
+func (_e *MockListInvitations_Expecter) Exec(ctx interface{}, request interface{}) *MockListInvitations_Exec_Call {
+	return &MockListInvitations_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockListInvitations_Exec_Call) Run(run func(ctx context.Context, request *dao.ListInvitationsRequest)) *MockListInvitations_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.ListInvitationsRequest))
+	})
+	return _c
+}
+
+func (_c *MockListInvitations_Exec_Call) Return(_a0 []*entities.Invitation, _a1 error) *MockListInvitations_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListInvitations_Exec_Call) RunAndReturn(run func(context.Context, *dao.ListInvitationsRequest) ([]*entities.Invitation, error)) *MockListInvitations_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListInvitations creates a new instance of MockListInvitations. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListInvitations(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListInvitations {
+	mock := &MockListInvitations{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
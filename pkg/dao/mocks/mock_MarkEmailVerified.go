@@ -0,0 +1,102 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockMarkEmailVerified is an autogenerated mock type for the MarkEmailVerified type
+type MockMarkEmailVerified struct {
+	mock.Mock
+}
+
+type MockMarkEmailVerified_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMarkEmailVerified) EXPECT() *MockMarkEmailVerified_Expecter {
+	return &MockMarkEmailVerified_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, id, now
+func (_m *MockMarkEmailVerified) Exec(ctx context.Context, id uuid.UUID, now time.Time) (*entities.Credential, error) {
+	ret := _m.Called(ctx, id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*entities.Credential, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *entities.Credential); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Credential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMarkEmailVerified_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockMarkEmailVerified_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *MockMarkEmailVerified_Expecter) Exec(ctx interface{}, id interface{}, now interface{}) *MockMarkEmailVerified_Exec_Call {
+	return &MockMarkEmailVerified_Exec_Call{Call: _e.mock.On("Exec", ctx, id, now)}
+}
+
+func (_c *MockMarkEmailVerified_Exec_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *MockMarkEmailVerified_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockMarkEmailVerified_Exec_Call) Return(_a0 *entities.Credential, _a1 error) *MockMarkEmailVerified_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMarkEmailVerified_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*entities.Credential, error)) *MockMarkEmailVerified_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMarkEmailVerified creates a new instance of MockMarkEmailVerified. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMarkEmailVerified(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMarkEmailVerified {
+	mock := &MockMarkEmailVerified{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockMergeCredentials is an autogenerated mock type for the MergeCredentials type
+type MockMergeCredentials struct {
+	mock.Mock
+}
+
+type MockMergeCredentials_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMergeCredentials) EXPECT() *MockMergeCredentials_Expecter {
+	return &MockMergeCredentials_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now, request
+func (_m *MockMergeCredentials) Exec(ctx context.Context, now time.Time, request *dao.MergeCredentialsRequest) (*entities.Credential, error) {
+	ret := _m.Called(ctx, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.MergeCredentialsRequest) (*entities.Credential, error)); ok {
+		return rf(ctx, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.MergeCredentialsRequest) *entities.Credential); ok {
+		r0 = rf(ctx, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Credential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *dao.MergeCredentialsRequest) error); ok {
+		r1 = rf(ctx, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMergeCredentials_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockMergeCredentials_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - request *dao.MergeCredentialsRequest
+func (_e *MockMergeCredentials_Expecter) Exec(ctx interface{}, now interface{}, request interface{}) *MockMergeCredentials_Exec_Call {
+	return &MockMergeCredentials_Exec_Call{Call: _e.mock.On("Exec", ctx, now, request)}
+}
+
+func (_c *MockMergeCredentials_Exec_Call) Run(run func(ctx context.Context, now time.Time, request *dao.MergeCredentialsRequest)) *MockMergeCredentials_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*dao.MergeCredentialsRequest))
+	})
+	return _c
+}
+
+func (_c *MockMergeCredentials_Exec_Call) Return(_a0 *entities.Credential, _a1 error) *MockMergeCredentials_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMergeCredentials_Exec_Call) RunAndReturn(run func(context.Context, time.Time, *dao.MergeCredentialsRequest) (*entities.Credential, error)) *MockMergeCredentials_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMergeCredentials creates a new instance of MockMergeCredentials. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMergeCredentials(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMergeCredentials {
+	mock := &MockMergeCredentials{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
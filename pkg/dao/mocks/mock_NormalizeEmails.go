@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockNormalizeEmails is an autogenerated mock type for the NormalizeEmails type
+type MockNormalizeEmails struct {
+	mock.Mock
+}
+
+type MockNormalizeEmails_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNormalizeEmails) EXPECT() *MockNormalizeEmails_Expecter {
+	return &MockNormalizeEmails_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockNormalizeEmails) Exec(ctx context.Context, request *dao.NormalizeEmailsRequest) (*dao.NormalizeEmailsResult, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *dao.NormalizeEmailsResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.NormalizeEmailsRequest) (*dao.NormalizeEmailsResult, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.NormalizeEmailsRequest) *dao.NormalizeEmailsResult); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.NormalizeEmailsResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.NormalizeEmailsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNormalizeEmails_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockNormalizeEmails_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.NormalizeEmailsRequest
+func (_e *MockNormalizeEmails_Expecter) Exec(ctx interface{}, request interface{}) *MockNormalizeEmails_Exec_Call {
+	return &MockNormalizeEmails_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockNormalizeEmails_Exec_Call) Run(run func(ctx context.Context, request *dao.NormalizeEmailsRequest)) *MockNormalizeEmails_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.NormalizeEmailsRequest))
+	})
+	return _c
+}
+
+func (_c *MockNormalizeEmails_Exec_Call) Return(_a0 *dao.NormalizeEmailsResult, _a1 error) *MockNormalizeEmails_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNormalizeEmails_Exec_Call) RunAndReturn(run func(context.Context, *dao.NormalizeEmailsRequest) (*dao.NormalizeEmailsResult, error)) *MockNormalizeEmails_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNormalizeEmails creates a new instance of MockNormalizeEmails. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNormalizeEmails(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNormalizeEmails {
+	mock := &MockNormalizeEmails{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPreviewRoleRule is an autogenerated mock type for the PreviewRoleRule type
+type MockPreviewRoleRule struct {
+	mock.Mock
+}
+
+type MockPreviewRoleRule_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPreviewRoleRule) EXPECT() *MockPreviewRoleRule_Expecter {
+	return &MockPreviewRoleRule_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, request
+func (_m *MockPreviewRoleRule) Exec(ctx context.Context, request *dao.PreviewRoleRuleRequest) ([]*entities.Credential, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 []*entities.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PreviewRoleRuleRequest) ([]*entities.Credential, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.PreviewRoleRuleRequest) []*entities.Credential); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.Credential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *dao.PreviewRoleRuleRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPreviewRoleRule_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockPreviewRoleRule_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *dao.PreviewRoleRuleRequest
+func (_e *MockPreviewRoleRule_Expecter) Exec(ctx interface{}, request interface{}) *MockPreviewRoleRule_Exec_Call {
+	return &MockPreviewRoleRule_Exec_Call{Call: _e.mock.On("Exec", ctx, request)}
+}
+
+func (_c *MockPreviewRoleRule_Exec_Call) Run(run func(ctx context.Context, request *dao.PreviewRoleRuleRequest)) *MockPreviewRoleRule_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dao.PreviewRoleRuleRequest))
+	})
+	return _c
+}
+
+func (_c *MockPreviewRoleRule_Exec_Call) Return(_a0 []*entities.Credential, _a1 error) *MockPreviewRoleRule_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPreviewRoleRule_Exec_Call) RunAndReturn(run func(context.Context, *dao.PreviewRoleRuleRequest) ([]*entities.Credential, error)) *MockPreviewRoleRule_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPreviewRoleRule creates a new instance of MockPreviewRoleRule. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPreviewRoleRule(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPreviewRoleRule {
+	mock := &MockPreviewRoleRule{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
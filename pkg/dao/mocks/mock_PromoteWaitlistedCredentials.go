@@ -0,0 +1,99 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockPromoteWaitlistedCredentials is an autogenerated mock type for the PromoteWaitlistedCredentials type
+type MockPromoteWaitlistedCredentials struct {
+	mock.Mock
+}
+
+type MockPromoteWaitlistedCredentials_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPromoteWaitlistedCredentials) EXPECT() *MockPromoteWaitlistedCredentials_Expecter {
+	return &MockPromoteWaitlistedCredentials_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now
+func (_m *MockPromoteWaitlistedCredentials) Exec(ctx context.Context, now time.Time) ([]*entities.CredentialRoleChange, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 []*entities.CredentialRoleChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*entities.CredentialRoleChange, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*entities.CredentialRoleChange); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.CredentialRoleChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPromoteWaitlistedCredentials_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockPromoteWaitlistedCredentials_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockPromoteWaitlistedCredentials_Expecter) Exec(ctx interface{}, now interface{}) *MockPromoteWaitlistedCredentials_Exec_Call {
+	return &MockPromoteWaitlistedCredentials_Exec_Call{Call: _e.mock.On("Exec", ctx, now)}
+}
+
+func (_c *MockPromoteWaitlistedCredentials_Exec_Call) Run(run func(ctx context.Context, now time.Time)) *MockPromoteWaitlistedCredentials_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockPromoteWaitlistedCredentials_Exec_Call) Return(_a0 []*entities.CredentialRoleChange, _a1 error) *MockPromoteWaitlistedCredentials_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPromoteWaitlistedCredentials_Exec_Call) RunAndReturn(run func(context.Context, time.Time) ([]*entities.CredentialRoleChange, error)) *MockPromoteWaitlistedCredentials_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPromoteWaitlistedCredentials creates a new instance of MockPromoteWaitlistedCredentials. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPromoteWaitlistedCredentials(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPromoteWaitlistedCredentials {
+	mock := &MockPromoteWaitlistedCredentials{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockPutEmailDomainRule is an autogenerated mock type for the PutEmailDomainRule type
+type MockPutEmailDomainRule struct {
+	mock.Mock
+}
+
+type MockPutEmailDomainRule_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPutEmailDomainRule) EXPECT() *MockPutEmailDomainRule_Expecter {
+	return &MockPutEmailDomainRule_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now, request
+func (_m *MockPutEmailDomainRule) Exec(ctx context.Context, now time.Time, request *dao.PutEmailDomainRuleRequest) (*entities.EmailDomainRule, error) {
+	ret := _m.Called(ctx, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.EmailDomainRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.PutEmailDomainRuleRequest) (*entities.EmailDomainRule, error)); ok {
+		return rf(ctx, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.PutEmailDomainRuleRequest) *entities.EmailDomainRule); ok {
+		r0 = rf(ctx, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.EmailDomainRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *dao.PutEmailDomainRuleRequest) error); ok {
+		r1 = rf(ctx, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPutEmailDomainRule_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockPutEmailDomainRule_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - request *dao.PutEmailDomainRuleRequest
+func (_e *MockPutEmailDomainRule_Expecter) Exec(ctx interface{}, now interface{}, request interface{}) *MockPutEmailDomainRule_Exec_Call {
+	return &MockPutEmailDomainRule_Exec_Call{Call: _e.mock.On("Exec", ctx, now, request)}
+}
+
+func (_c *MockPutEmailDomainRule_Exec_Call) Run(run func(ctx context.Context, now time.Time, request *dao.PutEmailDomainRuleRequest)) *MockPutEmailDomainRule_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*dao.PutEmailDomainRuleRequest))
+	})
+	return _c
+}
+
+func (_c *MockPutEmailDomainRule_Exec_Call) Return(_a0 *entities.EmailDomainRule, _a1 error) *MockPutEmailDomainRule_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPutEmailDomainRule_Exec_Call) RunAndReturn(run func(context.Context, time.Time, *dao.PutEmailDomainRuleRequest) (*entities.EmailDomainRule, error)) *MockPutEmailDomainRule_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPutEmailDomainRule creates a new instance of MockPutEmailDomainRule. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPutEmailDomainRule(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPutEmailDomainRule {
+	mock := &MockPutEmailDomainRule{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
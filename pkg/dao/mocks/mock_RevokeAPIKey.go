@@ -0,0 +1,90 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockRevokeAPIKey is an autogenerated mock type for the RevokeAPIKey type
+type MockRevokeAPIKey struct {
+	mock.Mock
+}
+
+type MockRevokeAPIKey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevokeAPIKey) EXPECT() *MockRevokeAPIKey_Expecter {
+	return &MockRevokeAPIKey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, id, now, request
+func (_m *MockRevokeAPIKey) Exec(ctx context.Context, id uuid.UUID, now time.Time, request *dao.RevokeAPIKeyRequest) error {
+	ret := _m.Called(ctx, id, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *dao.RevokeAPIKeyRequest) error); ok {
+		r0 = rf(ctx, id, now, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRevokeAPIKey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRevokeAPIKey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+//   - request *dao.RevokeAPIKeyRequest
+func (_e *MockRevokeAPIKey_Expecter) Exec(ctx interface{}, id interface{}, now interface{}, request interface{}) *MockRevokeAPIKey_Exec_Call {
+	return &MockRevokeAPIKey_Exec_Call{Call: _e.mock.On("Exec", ctx, id, now, request)}
+}
+
+func (_c *MockRevokeAPIKey_Exec_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time, request *dao.RevokeAPIKeyRequest)) *MockRevokeAPIKey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(*dao.RevokeAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockRevokeAPIKey_Exec_Call) Return(_a0 error) *MockRevokeAPIKey_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRevokeAPIKey_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, *dao.RevokeAPIKeyRequest) error) *MockRevokeAPIKey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevokeAPIKey creates a new instance of MockRevokeAPIKey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevokeAPIKey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevokeAPIKey {
+	mock := &MockRevokeAPIKey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
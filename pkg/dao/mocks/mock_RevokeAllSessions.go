@@ -0,0 +1,102 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockRevokeAllSessions is an autogenerated mock type for the RevokeAllSessions type
+type MockRevokeAllSessions struct {
+	mock.Mock
+}
+
+type MockRevokeAllSessions_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevokeAllSessions) EXPECT() *MockRevokeAllSessions_Expecter {
+	return &MockRevokeAllSessions_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, id, now
+func (_m *MockRevokeAllSessions) Exec(ctx context.Context, id uuid.UUID, now time.Time) (*entities.Credential, error) {
+	ret := _m.Called(ctx, id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*entities.Credential, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *entities.Credential); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Credential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRevokeAllSessions_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRevokeAllSessions_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - now time.Time
+func (_e *MockRevokeAllSessions_Expecter) Exec(ctx interface{}, id interface{}, now interface{}) *MockRevokeAllSessions_Exec_Call {
+	return &MockRevokeAllSessions_Exec_Call{Call: _e.mock.On("Exec", ctx, id, now)}
+}
+
+func (_c *MockRevokeAllSessions_Exec_Call) Run(run func(ctx context.Context, id uuid.UUID, now time.Time)) *MockRevokeAllSessions_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRevokeAllSessions_Exec_Call) Return(_a0 *entities.Credential, _a1 error) *MockRevokeAllSessions_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRevokeAllSessions_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time) (*entities.Credential, error)) *MockRevokeAllSessions_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevokeAllSessions creates a new instance of MockRevokeAllSessions. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevokeAllSessions(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevokeAllSessions {
+	mock := &MockRevokeAllSessions{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
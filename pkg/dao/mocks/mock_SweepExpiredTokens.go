@@ -0,0 +1,95 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockSweepExpiredTokens is an autogenerated mock type for the SweepExpiredTokens type
+type MockSweepExpiredTokens struct {
+	mock.Mock
+}
+
+type MockSweepExpiredTokens_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSweepExpiredTokens) EXPECT() *MockSweepExpiredTokens_Expecter {
+	return &MockSweepExpiredTokens_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now
+func (_m *MockSweepExpiredTokens) Exec(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSweepExpiredTokens_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockSweepExpiredTokens_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockSweepExpiredTokens_Expecter) Exec(ctx interface{}, now interface{}) *MockSweepExpiredTokens_Exec_Call {
+	return &MockSweepExpiredTokens_Exec_Call{Call: _e.mock.On("Exec", ctx, now)}
+}
+
+func (_c *MockSweepExpiredTokens_Exec_Call) Run(run func(ctx context.Context, now time.Time)) *MockSweepExpiredTokens_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSweepExpiredTokens_Exec_Call) Return(_a0 int64, _a1 error) *MockSweepExpiredTokens_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSweepExpiredTokens_Exec_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockSweepExpiredTokens_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSweepExpiredTokens creates a new instance of MockSweepExpiredTokens. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSweepExpiredTokens(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSweepExpiredTokens {
+	mock := &MockSweepExpiredTokens{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	dao "github.com/a-novel/uservice-credentials/pkg/dao"
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockVerifyAPIKey is an autogenerated mock type for the VerifyAPIKey type
+type MockVerifyAPIKey struct {
+	mock.Mock
+}
+
+type MockVerifyAPIKey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockVerifyAPIKey) EXPECT() *MockVerifyAPIKey_Expecter {
+	return &MockVerifyAPIKey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, now, request
+func (_m *MockVerifyAPIKey) Exec(ctx context.Context, now time.Time, request *dao.VerifyAPIKeyRequest) (*entities.APIKey, error) {
+	ret := _m.Called(ctx, now, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *entities.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.VerifyAPIKeyRequest) (*entities.APIKey, error)); ok {
+		return rf(ctx, now, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, *dao.VerifyAPIKeyRequest) *entities.APIKey); ok {
+		r0 = rf(ctx, now, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, *dao.VerifyAPIKeyRequest) error); ok {
+		r1 = rf(ctx, now, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockVerifyAPIKey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockVerifyAPIKey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - request *dao.VerifyAPIKeyRequest
+func (_e *MockVerifyAPIKey_Expecter) Exec(ctx interface{}, now interface{}, request interface{}) *MockVerifyAPIKey_Exec_Call {
+	return &MockVerifyAPIKey_Exec_Call{Call: _e.mock.On("Exec", ctx, now, request)}
+}
+
+func (_c *MockVerifyAPIKey_Exec_Call) Run(run func(ctx context.Context, now time.Time, request *dao.VerifyAPIKeyRequest)) *MockVerifyAPIKey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(*dao.VerifyAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockVerifyAPIKey_Exec_Call) Return(_a0 *entities.APIKey, _a1 error) *MockVerifyAPIKey_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockVerifyAPIKey_Exec_Call) RunAndReturn(run func(context.Context, time.Time, *dao.VerifyAPIKeyRequest) (*entities.APIKey, error)) *MockVerifyAPIKey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockVerifyAPIKey creates a new instance of MockVerifyAPIKey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVerifyAPIKey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVerifyAPIKey {
+	mock := &MockVerifyAPIKey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package daomocks
+
+import (
+	context "context"
+
+	entities "github.com/a-novel/uservice-credentials/pkg/entities"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockWithdrawConsent is an autogenerated mock type for the WithdrawConsent type
+type MockWithdrawConsent struct {
+	mock.Mock
+}
+
+type MockWithdrawConsent_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWithdrawConsent) EXPECT() *MockWithdrawConsent_Expecter {
+	return &MockWithdrawConsent_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, credentialID, now, document
+func (_m *MockWithdrawConsent) Exec(ctx context.Context, credentialID uuid.UUID, now time.Time, document entities.ConsentDocument) ([]*entities.CredentialConsent, error) {
+	ret := _m.Called(ctx, credentialID, now, document)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 []*entities.CredentialConsent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, entities.ConsentDocument) ([]*entities.CredentialConsent, error)); ok {
+		return rf(ctx, credentialID, now, document)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, entities.ConsentDocument) []*entities.CredentialConsent); ok {
+		r0 = rf(ctx, credentialID, now, document)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.CredentialConsent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, entities.ConsentDocument) error); ok {
+		r1 = rf(ctx, credentialID, now, document)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWithdrawConsent_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockWithdrawConsent_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credentialID uuid.UUID
+//   - now time.Time
+//   - document entities.ConsentDocument
+func (_e *MockWithdrawConsent_Expecter) Exec(ctx interface{}, credentialID interface{}, now interface{}, document interface{}) *MockWithdrawConsent_Exec_Call {
+	return &MockWithdrawConsent_Exec_Call{Call: _e.mock.On("Exec", ctx, credentialID, now, document)}
+}
+
+func (_c *MockWithdrawConsent_Exec_Call) Run(run func(ctx context.Context, credentialID uuid.UUID, now time.Time, document entities.ConsentDocument)) *MockWithdrawConsent_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time), args[3].(entities.ConsentDocument))
+	})
+	return _c
+}
+
+func (_c *MockWithdrawConsent_Exec_Call) Return(_a0 []*entities.CredentialConsent, _a1 error) *MockWithdrawConsent_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWithdrawConsent_Exec_Call) RunAndReturn(run func(context.Context, uuid.UUID, time.Time, entities.ConsentDocument) ([]*entities.CredentialConsent, error)) *MockWithdrawConsent_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWithdrawConsent creates a new instance of MockWithdrawConsent. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWithdrawConsent(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWithdrawConsent {
+	mock := &MockWithdrawConsent{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
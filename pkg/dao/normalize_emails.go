@@ -0,0 +1,162 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// unnormalizedEmailCondition matches the emails entities.NormalizeEmail may alter. It must stay in sync with the
+// predicate of the credentials_email_unnormalized_idx index, so the index can serve it.
+const unnormalizedEmailCondition = "(email ~ '[^[:ascii:]]' OR substring(email FROM '[^@]*$') ~ '[A-Z]')"
+
+type NormalizeEmailsRequest struct {
+	// After is the ID of the last credentials of the previous batch. Leave empty to start from the first credentials.
+	After uuid.UUID
+	Limit int
+}
+
+// NormalizeEmailsResult describes a batch of credentials, whose stored email was rewritten to its normalized form.
+type NormalizeEmailsResult struct {
+	// Last is the ID of the last credentials of the batch, to resume from. It is empty once every credential has been
+	// processed.
+	Last uuid.UUID
+	// Normalized credentials had their email rewritten, and their canonical email recomputed.
+	Normalized []*entities.Credential
+	// Conflicts are credentials whose normalized email belongs to other credentials of the tenant. They keep their
+	// stored email, and must be merged with the other credentials.
+	Conflicts []*entities.Credential
+}
+
+type NormalizeEmails interface {
+	Exec(ctx context.Context, request *NormalizeEmailsRequest) (*NormalizeEmailsResult, error)
+}
+
+type normalizeEmailsImpl struct {
+	database bun.IDB
+}
+
+// Exec rewrites the emails of a batch of users to the form lookups use, with entities.NormalizeEmail. Emails stored
+// before normalization was introduced would otherwise not be found anymore. Users are processed in the order of
+// their IDs, starting after request.After.
+//
+// Normalization only alters emails with non-ASCII characters, or with uppercase letters in their domain, so other
+// users are not selected. Users that cannot be normalized, because their email is invalid or conflicts with other
+// credentials, are selected again on each run.
+func (dao *normalizeEmailsImpl) Exec(
+	ctx context.Context, request *NormalizeEmailsRequest,
+) (*NormalizeEmailsResult, error) {
+	result := &NormalizeEmailsResult{
+		Normalized: make([]*entities.Credential, 0),
+		Conflicts:  make([]*entities.Credential, 0),
+	}
+
+	// Normalization does not depend on the tenant, so every tenant is processed at once.
+	err := runAcrossTenants(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		credentials := make([]*entities.Credential, 0)
+
+		err := tx.NewSelect().
+			Model(&credentials).
+			Column("id", "tenant_id", "email").
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where(unnormalizedEmailCondition).
+			Where("id > ?", request.After).
+			Order("id ASC").
+			Limit(request.Limit).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		if len(credentials) == 0 {
+			return nil
+		}
+
+		result.Last = credentials[len(credentials)-1].ID
+
+		// Credentials of the batch that normalize to the same email are left to the first one.
+		normalized := make(map[uuid.UUID]string)
+		targets := make(map[[2]string]uuid.UUID)
+		conflicts := make(map[uuid.UUID]bool)
+
+		for _, credential := range credentials {
+			email := entities.NormalizeEmail(credential.Email)
+			if email == credential.Email {
+				continue
+			}
+
+			target := [2]string{credential.TenantID, email}
+			if _, ok := targets[target]; ok {
+				conflicts[credential.ID] = true
+				continue
+			}
+
+			targets[target] = credential.ID
+			normalized[credential.ID] = email
+		}
+
+		if len(normalized) > 0 {
+			taken := make([]*entities.Credential, 0)
+
+			err = tx.NewSelect().
+				Model(&taken).
+				Column("tenant_id", "email").
+				Where("(tenant_id, email) IN (?)", bun.In(lo.MapToSlice(
+					targets,
+					func(target [2]string, _ uuid.UUID) []string { return target[:] },
+				))).
+				Scan(ctx)
+			if err != nil {
+				return fmt.Errorf("select taken emails: %w", err)
+			}
+
+			for _, credential := range taken {
+				conflicts[targets[[2]string{credential.TenantID, credential.Email}]] = true
+			}
+		}
+
+		for _, credential := range credentials {
+			email, ok := normalized[credential.ID]
+
+			switch {
+			case conflicts[credential.ID]:
+				result.Conflicts = append(result.Conflicts, credential)
+			case ok:
+				credential.Email = email
+				credential.CanonicalEmail = entities.CanonicalEmail(email)
+				result.Normalized = append(result.Normalized, credential)
+			}
+		}
+
+		if len(result.Normalized) == 0 {
+			return nil
+		}
+
+		_, err = tx.NewUpdate().
+			Model(&result.Normalized).
+			Column("email", "canonical_email").
+			Bulk().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func NewNormalizeEmails(database bun.IDB) NormalizeEmails {
+	return &normalizeEmailsImpl{database: database}
+}
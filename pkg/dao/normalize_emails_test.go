@@ -0,0 +1,217 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestNormalizeEmails(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "Jane.Doe@Bücher.DE",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Already normalized.
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:       "default",
+			Email:          "john@example.com",
+			CanonicalEmail: "john@example.com",
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Normalizes to the email of the credentials above.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "john@Example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Both normalize to the same email.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "mary@Example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:  "default",
+			Email:     "mary@EXAMPLE.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			TenantID:  "default",
+			Email:     "erased-0006@Erased.invalid",
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// The same email in another tenant does not conflict.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "john@EXAMPLE.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.NormalizeEmailsRequest
+
+		expect       *dao.NormalizeEmailsResult
+		expectEmails map[uuid.UUID]string
+		expectErr    error
+	}{
+		{
+			name: "Normalize",
+
+			request: &dao.NormalizeEmailsRequest{Limit: 10},
+
+			expect: &dao.NormalizeEmailsResult{
+				Last: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				Normalized: []*entities.Credential{
+					{
+						ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+						TenantID:       "default",
+						Email:          "Jane.Doe@xn--bcher-kva.de",
+						CanonicalEmail: "jane.doe@xn--bcher-kva.de",
+					},
+					{
+						ID:             uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+						TenantID:       "default",
+						Email:          "mary@example.com",
+						CanonicalEmail: "mary@example.com",
+					},
+					{
+						ID:             uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+						TenantID:       "tenant-2",
+						Email:          "john@example.com",
+						CanonicalEmail: "john@example.com",
+					},
+				},
+				Conflicts: []*entities.Credential{
+					{
+						ID:       uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+						TenantID: "default",
+						Email:    "john@Example.com",
+					},
+					{
+						ID:       uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+						TenantID: "default",
+						Email:    "mary@EXAMPLE.com",
+					},
+				},
+			},
+			expectEmails: map[uuid.UUID]string{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"): "Jane.Doe@xn--bcher-kva.de",
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"): "john@example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"): "john@Example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"): "mary@example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000005"): "mary@EXAMPLE.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000006"): "erased-0006@Erased.invalid",
+				uuid.MustParse("00000000-0000-0000-0000-000000000099"): "john@example.com",
+			},
+		},
+		{
+			name: "Batch",
+
+			request: &dao.NormalizeEmailsRequest{
+				After: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Limit: 2,
+			},
+
+			// Credentials that are already normalized are not part of the batch.
+			expect: &dao.NormalizeEmailsResult{
+				Last: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Normalized: []*entities.Credential{
+					{
+						ID:             uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+						TenantID:       "default",
+						Email:          "mary@example.com",
+						CanonicalEmail: "mary@example.com",
+					},
+				},
+				Conflicts: []*entities.Credential{
+					{
+						ID:       uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+						TenantID: "default",
+						Email:    "john@Example.com",
+					},
+				},
+			},
+			expectEmails: map[uuid.UUID]string{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"): "Jane.Doe@Bücher.DE",
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"): "john@example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"): "john@Example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"): "mary@example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000005"): "mary@EXAMPLE.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000006"): "erased-0006@Erased.invalid",
+				uuid.MustParse("00000000-0000-0000-0000-000000000099"): "john@EXAMPLE.com",
+			},
+		},
+		{
+			name: "Done",
+
+			request: &dao.NormalizeEmailsRequest{
+				After: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				Limit: 10,
+			},
+
+			expect: &dao.NormalizeEmailsResult{
+				Normalized: []*entities.Credential{},
+				Conflicts:  []*entities.Credential{},
+			},
+			expectEmails: map[uuid.UUID]string{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"): "Jane.Doe@Bücher.DE",
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"): "john@example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"): "john@Example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"): "mary@Example.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000005"): "mary@EXAMPLE.com",
+				uuid.MustParse("00000000-0000-0000-0000-000000000006"): "erased-0006@Erased.invalid",
+				uuid.MustParse("00000000-0000-0000-0000-000000000099"): "john@EXAMPLE.com",
+			},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			normalizeEmailsDAO := dao.NewNormalizeEmails(transaction)
+
+			result, err := normalizeEmailsDAO.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, result)
+
+			stored := make([]*entities.Credential, 0)
+			require.NoError(t, transaction.NewSelect().Model(&stored).Column("id", "email").Scan(
+				context.Background(),
+			))
+			require.Equal(t, testCase.expectEmails, lo.SliceToMap(
+				stored,
+				func(item *entities.Credential) (uuid.UUID, string) { return item.ID, item.Email },
+			))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type PreviewRoleRuleRequest struct {
+	Rule   entities.RoleRule
+	Limit  int
+	Offset int
+}
+
+type PreviewRoleRule interface {
+	Exec(ctx context.Context, request *PreviewRoleRuleRequest) ([]*entities.Credential, error)
+}
+
+type previewRoleRuleImpl struct {
+	database bun.IDB
+}
+
+// Exec returns the users that the rule would promote, sorted by email. Users already holding the role of the rule, or
+// a higher one, are left out.
+//
+// Domain rules are matched by the database, and paginated there. Patterns are matched by the rule, as they are on
+// signup, so the preview cannot drift from the actual assignment: candidates are streamed in order, and the scan
+// stops once the page is full.
+func (dao *previewRoleRuleImpl) Exec(
+	ctx context.Context, request *PreviewRoleRuleRequest,
+) ([]*entities.Credential, error) {
+	credentials := make([]*entities.Credential, 0, request.Limit)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		query := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			// Roles are declared in order of rank, so rules only promote users holding a lower role.
+			Where("role < ?", request.Rule.Role).
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			Order("email ASC", "id ASC")
+
+		if request.Rule.Domain != "" {
+			err := query.
+				Where("lower(substring(email FROM '[^@]*$')) = ?", entities.NormalizeEmailDomain(request.Rule.Domain)).
+				Limit(request.Limit).
+				Offset(request.Offset).
+				Scan(ctx, &credentials)
+			if err != nil {
+				return fmt.Errorf("exec query: %w", err)
+			}
+
+			return nil
+		}
+
+		rows, err := query.Rows(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+		defer rows.Close()
+
+		for skipped := 0; len(credentials) < request.Limit && rows.Next(); {
+			credential := new(entities.Credential)
+			if err = query.DB().ScanRow(ctx, rows, credential); err != nil {
+				return fmt.Errorf("scan credentials: %w", err)
+			}
+
+			if !request.Rule.Matches(credential.Email) {
+				continue
+			}
+
+			if skipped < request.Offset {
+				skipped++
+
+				continue
+			}
+
+			credentials = append(credentials, credential)
+		}
+
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("read credentials: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func NewPreviewRoleRule(database bun.IDB) PreviewRoleRule {
+	return &previewRoleRuleImpl{database: database}
+}
@@ -0,0 +1,168 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestPreviewRoleRule(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "alice@staff.example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "bob@STAFF.example.com",
+			Role:      entities.RoleEarlyAccessProgram,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Already holds the role of the rule.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "carol@staff.example.com",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Subdomains do not match.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "dave@eu.staff.example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:  "default",
+			Email:     "erin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000006"),
+			TenantID:  "default",
+			Email:     "erased-0006@erased.invalid",
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "frank@staff.example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		request *dao.PreviewRoleRuleRequest
+
+		expect    []uuid.UUID
+		expectErr error
+	}{
+		{
+			name: "Domain",
+
+			request: &dao.PreviewRoleRuleRequest{
+				Rule:  entities.RoleRule{Domain: "staff.example.com", Role: entities.RoleCore},
+				Limit: 10,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
+		{
+			// Users holding a higher role are not demoted.
+			name: "Domain/HigherRole",
+
+			request: &dao.PreviewRoleRuleRequest{
+				Rule:  entities.RoleRule{Domain: "staff.example.com", Role: entities.RoleAdmin},
+				Limit: 10,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
+		{
+			name: "Pattern",
+
+			request: &dao.PreviewRoleRuleRequest{
+				Rule:  lo.Must(entities.NewRoleRule("", `\.staff\.example\.com$`, entities.RoleEarlyAccessProgram)),
+				Limit: 10,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			},
+		},
+		{
+			name: "Pattern/Paginate",
+
+			request: &dao.PreviewRoleRuleRequest{
+				Rule:   lo.Must(entities.NewRoleRule("", `(?i)staff\.example\.com$`, entities.RoleCore)),
+				Limit:  1,
+				Offset: 1,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
+		{
+			name: "Paginate",
+
+			request: &dao.PreviewRoleRuleRequest{
+				Rule:   entities.RoleRule{Domain: "staff.example.com", Role: entities.RoleCore},
+				Limit:  1,
+				Offset: 1,
+			},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			previewRoleRuleDAO := dao.NewPreviewRoleRule(transaction)
+
+			credentials, err := previewRoleRuleDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(
+				t,
+				testCase.expect,
+				lo.Map(credentials, func(item *entities.Credential, _ int) uuid.UUID { return item.ID }),
+			)
+		})
+	}
+}
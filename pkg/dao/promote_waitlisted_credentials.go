@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type PromoteWaitlistedCredentials interface {
+	Exec(ctx context.Context, now time.Time) ([]*entities.CredentialRoleChange, error)
+}
+
+type promoteWaitlistedCredentialsImpl struct {
+	database   bun.IDB
+	capacities entities.RoleCapacities
+}
+
+// Exec hands every free seat to the waitlists. Seats are released immediately by the operations that change roles,
+// but not by erasures and merges, nor by a raise of the configured capacities: those are picked up here.
+func (dao *promoteWaitlistedCredentialsImpl) Exec(
+	ctx context.Context, now time.Time,
+) ([]*entities.CredentialRoleChange, error) {
+	changes := make([]*entities.CredentialRoleChange, 0)
+
+	err := runAcrossTenants(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		var queues []*entities.RoleWaitlistEntry
+
+		err := tx.NewSelect().
+			Model(&queues).
+			Column("tenant_id", "role").
+			Distinct().
+			Order("tenant_id ASC", "role ASC").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select waitlists: %w", err)
+		}
+
+		for _, queue := range queues {
+			promotions, err := promoteWaitlisted(ctx, tx, queue.TenantID, dao.capacities, queue.Role, now)
+			if err != nil {
+				return err
+			}
+
+			changes = append(changes, promotions...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func NewPromoteWaitlistedCredentials(
+	database bun.IDB, capacities entities.RoleCapacities,
+) PromoteWaitlistedCredentials {
+	return &promoteWaitlistedCredentialsImpl{database: database, capacities: capacities}
+}
@@ -0,0 +1,172 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestPromoteWaitlistedCredentials(t *testing.T) {
+	fixtures := []interface{}{
+		// Erased, so its seat is free.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "erased-0001@erased.invalid",
+			Role:      entities.RoleEarlyAccessProgram,
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			Role:      entities.RoleEarlyAccessProgram,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// First in line.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Second in line, no seat left.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "email-4",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Temporary admin, promoted to a higher permanent role.
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:      "default",
+			Email:         "email-5",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleNone),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleEarlyAccessProgram,
+			Seq:          1,
+			EnqueuedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			Role:         entities.RoleEarlyAccessProgram,
+			Seq:          2,
+			EnqueuedAt:   time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			Role:         entities.RoleCore,
+			Seq:          3,
+			EnqueuedAt:   time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0006-000000000099"),
+			TenantID:     "tenant-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			Role:         entities.RoleEarlyAccessProgram,
+			Seq:          4,
+			EnqueuedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	promoteWaitlistedCredentialsDAO := dao.NewPromoteWaitlistedCredentials(
+		transaction, entities.RoleCapacities{entities.RoleEarlyAccessProgram: 2},
+	)
+
+	now := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	changes, err := promoteWaitlistedCredentialsDAO.Exec(context.Background(), now)
+	require.NoError(t, err)
+
+	// Records are identified by random IDs.
+	require.ElementsMatch(
+		t,
+		[]*entities.CredentialRoleChange{
+			{
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				FromRole:     entities.RoleNone,
+				ToRole:       entities.RoleEarlyAccessProgram,
+				Reason:       entities.RoleChangeReasonWaitlistPromoted,
+				ChangedAt:    now,
+			},
+			{
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				FromRole:     entities.RoleAdmin,
+				ToRole:       entities.RoleCore,
+				Reason:       entities.RoleChangeReasonWaitlistPromoted,
+				ChangedAt:    now,
+			},
+			{
+				TenantID:     "tenant-2",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				FromRole:     entities.RoleNone,
+				ToRole:       entities.RoleEarlyAccessProgram,
+				Reason:       entities.RoleChangeReasonWaitlistPromoted,
+				ChangedAt:    now,
+			},
+		},
+		lo.Map(changes, func(item *entities.CredentialRoleChange, _ int) *entities.CredentialRoleChange {
+			require.NotEqual(t, uuid.Nil, item.ID)
+			item.ID = uuid.Nil
+
+			return item
+		}),
+	)
+
+	credentials := make([]*entities.Credential, 0)
+	require.NoError(t, transaction.NewSelect().Model(&credentials).Order("id ASC").Scan(context.Background()))
+	require.Equal(
+		t,
+		[]entities.Role{
+			entities.RoleEarlyAccessProgram,
+			entities.RoleEarlyAccessProgram,
+			entities.RoleEarlyAccessProgram,
+			entities.RoleNone,
+			entities.RoleCore,
+			entities.RoleEarlyAccessProgram,
+		},
+		lo.Map(credentials, func(item *entities.Credential, _ int) entities.Role { return item.Role }),
+	)
+
+	waiting, err := transaction.NewSelect().Model((*entities.RoleWaitlistEntry)(nil)).Count(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, waiting)
+}
@@ -0,0 +1,107 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type ProposeRoleChangeRequest struct {
+	CredentialID uuid.UUID
+	Role         entities.Role
+	ProposedBy   uuid.UUID
+	ExpiresAt    time.Time
+}
+
+type ProposeRoleChange interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *ProposeRoleChangeRequest,
+	) (*entities.RoleChangeRequest, error)
+}
+
+type proposeRoleChangeImpl struct {
+	database bun.IDB
+}
+
+// Exec opens a role change request, that must be approved by another actor before it applies. Credentials can only
+// have one pending request at a time.
+func (dao *proposeRoleChangeImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *ProposeRoleChangeRequest,
+) (*entities.RoleChangeRequest, error) {
+	model := &entities.RoleChangeRequest{
+		ID:           id,
+		CredentialID: request.CredentialID,
+		Role:         request.Role,
+		Status:       entities.RoleChangeRequestStatusPending,
+		ProposedBy:   request.ProposedBy,
+		ExpiresAt:    request.ExpiresAt,
+		CreatedAt:    now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		if _, err := checkRoleChangeActor(ctx, tx, tenantID, request.ProposedBy, now); err != nil {
+			return err
+		}
+
+		exists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			For("SHARE").
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check credentials existence: %w", err)
+		}
+
+		if !exists {
+			return ErrCredentialsNotFound
+		}
+
+		// Close the pending request that expired, so a new one can be opened.
+		_, err = tx.NewUpdate().
+			Model((*entities.RoleChangeRequest)(nil)).
+			Set("status = ?", entities.RoleChangeRequestStatusExpired).
+			Set("decided_at = expires_at").
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("status = ?", entities.RoleChangeRequestStatusPending).
+			Where("expires_at <= ?", now).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("expire role change requests: %w", err)
+		}
+
+		_, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx)
+		if err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrRoleChangeRequestAlreadyPending
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewProposeRoleChange(database bun.IDB) ProposeRoleChange {
+	return &proposeRoleChangeImpl{database: database}
+}
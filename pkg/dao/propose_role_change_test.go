@@ -0,0 +1,252 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestProposeRoleChange(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Has a pending request.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Has a pending request that expired.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "email-4",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-1",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt:    time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.ProposeRoleChangeRequest
+
+		expect    *entities.RoleChangeRequest
+		expectErr error
+	}{
+		{
+			name: "Propose",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleAdmin,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusPending,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Propose/ReplaceExpired",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Role:         entities.RoleCore,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Role:         entities.RoleCore,
+				Status:       entities.RoleChangeRequestStatusPending,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "AlreadyPending",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Role:         entities.RoleCore,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrRoleChangeRequestAlreadyPending,
+		},
+		{
+			// A legal hold only freezes the deletion and the email of the credentials.
+			name: "LegalHold",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Role:         entities.RoleAdmin,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusPending,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "ServiceAccount",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+				Role:         entities.RoleAdmin,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "ProposerInOtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleAdmin,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				Role:         entities.RoleCore,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			proposeRoleChangeDAO := dao.NewProposeRoleChange(transaction)
+
+			request, err := proposeRoleChangeDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, request)
+		})
+	}
+}
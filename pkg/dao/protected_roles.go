@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// permanentRole returns the role the credentials keep once any temporary grant expires.
+func permanentRole(credential *entities.Credential) entities.Role {
+	return lo.FromPtr(lo.CoalesceOrEmpty(credential.FallbackRole, &credential.Role))
+}
+
+// checkLastRoleHolder ensures the credentials are not the last user holding their permanent role, when this role is
+// protected. Credentials pending deletion no longer count as holders, so the deletion job never has to check it.
+//
+// The role is locked until the end of the transaction, so concurrent transactions removing different holders count
+// them one after the other. Holder rows are not locked, as each transaction already locks its own credentials.
+func checkLastRoleHolder(
+	ctx context.Context, tx bun.Tx, tenantID string, protected []entities.Role, credential *entities.Credential,
+) error {
+	role := permanentRole(credential)
+
+	if credential.Kind != entities.CredentialKindUser || !lo.Contains(protected, role) {
+		return nil
+	}
+
+	// Capacity checks lock the roles under another key, as a transaction may lock the role it leaves and the role it
+	// joins, in any order.
+	lockKey := tenantID + "/protected_role/" + string(role)
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", lockKey); err != nil {
+		return fmt.Errorf("lock protected role: %w", err)
+	}
+
+	holders, err := tx.NewSelect().
+		Model((*entities.Credential)(nil)).
+		Where("tenant_id = ?", tenantID).
+		Where("kind = ?", entities.CredentialKindUser).
+		Where("COALESCE(fallback_role, role) = ?", role).
+		Where("id <> ?", credential.ID).
+		Where("erased_at IS NULL").
+		Where("deleted_at IS NULL").
+		Where("deletion_scheduled_at IS NULL").
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("count role holders: %w", err)
+	}
+
+	if holders == 0 {
+		return fmt.Errorf("%w: '%s'", ErrLastProtectedRoleHolder, role)
+	}
+
+	return nil
+}
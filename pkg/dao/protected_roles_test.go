@@ -0,0 +1,84 @@
+package dao_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// TestLastRoleHolderConcurrency removes the only two holders of a protected role concurrently. The removals run in
+// their own transactions, so the fixtures are committed rather than rolled back.
+func TestLastRoleHolderConcurrency(t *testing.T) {
+	fixtures := []*entities.Credential{
+		{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	ctx := entities.ContextWithTenant(context.Background(), "default")
+
+	_, err = database.NewInsert().Model(&fixtures).Exec(ctx)
+	require.NoError(t, err)
+
+	requestAccountDeletionDAO := dao.NewRequestAccountDeletion(database, []entities.Role{entities.RoleAdmin})
+
+	var wg sync.WaitGroup
+
+	errs := make([]error, len(fixtures))
+
+	for i, fixture := range fixtures {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, errs[i] = requestAccountDeletionDAO.Exec(
+				ctx, fixture.ID, time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC), &dao.RequestAccountDeletionRequest{
+					ScheduledAt: time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+				},
+			)
+		}()
+	}
+
+	wg.Wait()
+
+	// Exactly one removal goes through, and neither transaction is aborted as a deadlock.
+	succeeded := 0
+
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+
+			continue
+		}
+
+		require.ErrorIs(t, err, dao.ErrLastProtectedRoleHolder)
+	}
+
+	require.Equal(t, 1, succeeded)
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type PutEmailDomainRuleRequest struct {
+	// ID identifies the rule, if the domain has none yet.
+	ID uuid.UUID
+	// Domain must be lowercase.
+	Domain string
+	Action entities.EmailDomainAction
+	Reason string
+	SetBy  string
+}
+
+type PutEmailDomainRule interface {
+	Exec(ctx context.Context, now time.Time, request *PutEmailDomainRuleRequest) (*entities.EmailDomainRule, error)
+}
+
+type putEmailDomainRuleImpl struct {
+	database bun.IDB
+}
+
+// Exec sets the custom rule of a domain, replacing the existing one if any.
+func (dao *putEmailDomainRuleImpl) Exec(
+	ctx context.Context, now time.Time, request *PutEmailDomainRuleRequest,
+) (*entities.EmailDomainRule, error) {
+	model := &entities.EmailDomainRule{
+		ID:        request.ID,
+		Domain:    request.Domain,
+		Action:    request.Action,
+		Reason:    request.Reason,
+		SetBy:     request.SetBy,
+		CreatedAt: now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		_, err := tx.NewInsert().
+			Model(model).
+			On("CONFLICT (tenant_id, domain) DO UPDATE").
+			Set("action = EXCLUDED.action").
+			Set("reason = EXCLUDED.reason").
+			Set("set_by = EXCLUDED.set_by").
+			Set("updated_at = EXCLUDED.created_at").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewPutEmailDomainRule(database bun.IDB) PutEmailDomainRule {
+	return &putEmailDomainRuleImpl{database: database}
+}
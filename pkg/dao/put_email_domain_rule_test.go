@@ -0,0 +1,113 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestPutEmailDomainRule(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+			TenantID:  "default",
+			Domain:    "spam.example.com",
+			Action:    entities.EmailDomainActionDeny,
+			Reason:    "spam wave",
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.EmailDomainRule{
+			ID:        uuid.MustParse("00000000-0000-0000-0008-000000000099"),
+			TenantID:  "tenant-2",
+			Domain:    "partner.example.com",
+			Action:    entities.EmailDomainActionDeny,
+			SetBy:     "admin@example.com",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now     time.Time
+		request *dao.PutEmailDomainRuleRequest
+
+		expect    *entities.EmailDomainRule
+		expectErr error
+	}{
+		{
+			name: "Create",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.PutEmailDomainRuleRequest{
+				ID:     uuid.MustParse("00000000-0000-0000-0008-000000000002"),
+				Domain: "partner.example.com",
+				Action: entities.EmailDomainActionAllow,
+				SetBy:  "admin-2@example.com",
+			},
+
+			expect: &entities.EmailDomainRule{
+				ID:        uuid.MustParse("00000000-0000-0000-0008-000000000002"),
+				TenantID:  "default",
+				Domain:    "partner.example.com",
+				Action:    entities.EmailDomainActionAllow,
+				SetBy:     "admin-2@example.com",
+				CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Replace",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.PutEmailDomainRuleRequest{
+				ID:     uuid.MustParse("00000000-0000-0000-0008-000000000002"),
+				Domain: "spam.example.com",
+				Action: entities.EmailDomainActionAllow,
+				Reason: "false positive",
+				SetBy:  "admin-2@example.com",
+			},
+
+			expect: &entities.EmailDomainRule{
+				ID:        uuid.MustParse("00000000-0000-0000-0008-000000000001"),
+				TenantID:  "default",
+				Domain:    "spam.example.com",
+				Action:    entities.EmailDomainActionAllow,
+				Reason:    "false positive",
+				SetBy:     "admin-2@example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			putEmailDomainRuleDAO := dao.NewPutEmailDomainRule(transaction)
+
+			rule, err := putEmailDomainRuleDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, rule)
+		})
+	}
+}
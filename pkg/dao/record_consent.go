@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RecordConsentRequest struct {
+	ID       uuid.UUID
+	Document entities.ConsentDocument
+	Version  string
+}
+
+type RecordConsent interface {
+	Exec(
+		ctx context.Context, credentialID uuid.UUID, now time.Time, request *RecordConsentRequest,
+	) (*entities.CredentialConsent, error)
+}
+
+type recordConsentImpl struct {
+	database bun.IDB
+}
+
+// Exec records the acceptance of a version of a document. Accepting a version that is already accepted returns the
+// existing record, so the original acceptance date is preserved.
+func (dao *recordConsentImpl) Exec(
+	ctx context.Context, credentialID uuid.UUID, now time.Time, request *RecordConsentRequest,
+) (*entities.CredentialConsent, error) {
+	model := &entities.CredentialConsent{
+		ID:           request.ID,
+		CredentialID: credentialID,
+		Document:     request.Document,
+		Version:      request.Version,
+		AcceptedAt:   now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		// Foreign keys ignore tenant isolation, so the credentials must be checked explicitly. Only users accept
+		// documents.
+		exists, err := tx.NewSelect().
+			Model((*entities.Credential)(nil)).
+			Where("id = ?", credentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			For("SHARE").
+			Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("check credentials existence: %w", err)
+		}
+
+		if !exists {
+			return ErrCredentialsNotFound
+		}
+
+		res, err := tx.NewInsert().
+			Model(model).
+			On("CONFLICT (tenant_id, document, version, credential_id) WHERE withdrawn_at IS NULL DO NOTHING").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows > 0 {
+			return nil
+		}
+
+		err = tx.NewSelect().
+			Model(model).
+			Where("credential_id = ?", credentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("document = ?", request.Document).
+			Where("version = ?", request.Version).
+			Where("withdrawn_at IS NULL").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select existing consent: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewRecordConsent(database bun.IDB) RecordConsent {
+	return &recordConsentImpl{database: database}
+}
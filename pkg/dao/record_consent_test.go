@@ -0,0 +1,204 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestRecordConsent(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-2",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "erased-0003@erased.invalid",
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Document:     entities.ConsentDocumentTOS,
+			Version:      "v1",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		// Withdrawn consents do not prevent accepting the version again.
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Document:     entities.ConsentDocumentMarketing,
+			Version:      "v1",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			WithdrawnAt:  lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		credentialID uuid.UUID
+		now          time.Time
+		request      *dao.RecordConsentRequest
+
+		expect    *entities.CredentialConsent
+		expectErr error
+	}{
+		{
+			name: "Record",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RecordConsentRequest{
+				ID:       uuid.MustParse("00000000-0000-0000-0001-000000000010"),
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+			},
+
+			expect: &entities.CredentialConsent{
+				ID:           uuid.MustParse("00000000-0000-0000-0001-000000000010"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Document:     entities.ConsentDocumentTOS,
+				Version:      "v2",
+				AcceptedAt:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "AlreadyAccepted",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RecordConsentRequest{
+				ID:       uuid.MustParse("00000000-0000-0000-0001-000000000010"),
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v1",
+			},
+
+			expect: &entities.CredentialConsent{
+				ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Document:     entities.ConsentDocumentTOS,
+				Version:      "v1",
+				AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "AcceptAgainAfterWithdrawal",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RecordConsentRequest{
+				ID:       uuid.MustParse("00000000-0000-0000-0001-000000000010"),
+				Document: entities.ConsentDocumentMarketing,
+				Version:  "v1",
+			},
+
+			expect: &entities.CredentialConsent{
+				ID:           uuid.MustParse("00000000-0000-0000-0001-000000000010"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Document:     entities.ConsentDocumentMarketing,
+				Version:      "v1",
+				AcceptedAt:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "ServiceAccount",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RecordConsentRequest{
+				ID:       uuid.MustParse("00000000-0000-0000-0001-000000000010"),
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "Erased",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RecordConsentRequest{
+				ID:       uuid.MustParse("00000000-0000-0000-0001-000000000010"),
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RecordConsentRequest{
+				ID:       uuid.MustParse("00000000-0000-0000-0001-000000000010"),
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			recordConsentDAO := dao.NewRecordConsent(transaction)
+
+			consent, err := recordConsentDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				testCase.credentialID, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if testCase.expectErr != nil {
+				require.Nil(t, consent)
+			} else {
+				require.Equal(t, testCase.expect, consent)
+			}
+		})
+	}
+}
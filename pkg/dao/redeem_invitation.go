@@ -0,0 +1,177 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RedeemInvitationRequest struct {
+	Code         string
+	CredentialID uuid.UUID
+}
+
+type RedeemInvitation interface {
+	Exec(ctx context.Context, now time.Time, request *RedeemInvitationRequest) (*entities.Credential, error)
+}
+
+type redeemInvitationImpl struct {
+	database   bun.IDB
+	capacities entities.RoleCapacities
+}
+
+// Exec consumes a use of the invitation, and upgrades the credentials to its role. Invitations never downgrade
+// credentials: a lower role only raises the fallback of a temporary grant, so the credentials keep it once the grant
+// expires.
+func (dao *redeemInvitationImpl) Exec(
+	ctx context.Context, now time.Time, request *RedeemInvitationRequest,
+) (*entities.Credential, error) {
+	credential := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		// The lock serializes concurrent redemptions, so the remaining uses are checked reliably.
+		invitation := new(entities.Invitation)
+
+		err := tx.NewSelect().
+			Model(invitation).
+			Where("code = ?", request.Code).
+			Where("tenant_id = ?", tenantID).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvitationNotFound
+			}
+
+			return fmt.Errorf("select invitation: %w", err)
+		}
+
+		if !invitation.Available(now) {
+			return ErrInvitationUnavailable
+		}
+
+		err = tx.NewSelect().
+			Model(credential).
+			Where("id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		redemption := &entities.InvitationRedemption{
+			InvitationID: invitation.ID,
+			TenantID:     tenantID,
+			CredentialID: credential.ID,
+			RedeemedAt:   now,
+		}
+
+		if _, err = tx.NewInsert().Model(redemption).Exec(ctx); err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrInvitationAlreadyRedeemed
+			}
+
+			return fmt.Errorf("record redemption: %w", err)
+		}
+
+		_, err = tx.NewUpdate().
+			Model(invitation).
+			Set("uses = uses + 1").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("consume invitation: %w", err)
+		}
+
+		fromRole := credential.Role
+		heldBefore := heldRoles(credential)
+
+		if !applyInvitationRole(credential, invitation.Role) {
+			return nil
+		}
+
+		err = reassignSeats(ctx, tx, tenantID, dao.capacities, now, heldBefore, heldRoles(credential))
+		if err != nil {
+			return err
+		}
+
+		credential.UpdatedAt = &now
+
+		_, err = tx.NewUpdate().
+			Model(credential).
+			Column("role", "role_expires_at", "fallback_role", "updated_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		if fromRole == credential.Role {
+			return nil
+		}
+
+		change := &entities.CredentialRoleChange{
+			ID:           uuid.New(),
+			TenantID:     tenantID,
+			CredentialID: credential.ID,
+			FromRole:     fromRole,
+			ToRole:       credential.Role,
+			Reason:       entities.RoleChangeReasonInvitationRedeemed,
+			ChangedAt:    now,
+		}
+
+		if _, err = tx.NewInsert().Model(change).Exec(ctx); err != nil {
+			return fmt.Errorf("record role change: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// applyInvitationRole upgrades the credentials to the given role, and reports whether they changed. A role at least
+// as high as the current one replaces it permanently, ending any temporary grant.
+func applyInvitationRole(credential *entities.Credential, role entities.Role) bool {
+	if roleRank(role) >= roleRank(credential.Role) {
+		changed := credential.Role != role || credential.RoleExpiresAt != nil
+
+		credential.Role = role
+		credential.RoleExpiresAt = nil
+		credential.FallbackRole = nil
+
+		return changed
+	}
+
+	if credential.FallbackRole != nil && roleRank(role) > roleRank(*credential.FallbackRole) {
+		credential.FallbackRole = &role
+
+		return true
+	}
+
+	return false
+}
+
+func NewRedeemInvitation(database bun.IDB, capacities entities.RoleCapacities) RedeemInvitation {
+	return &redeemInvitationImpl{database: database, capacities: capacities}
+}
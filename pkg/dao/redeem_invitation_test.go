@@ -0,0 +1,317 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestRedeemInvitation(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Temporary admin, that reverts to no role.
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:      "default",
+			Email:         "email-3",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleNone),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+			TenantID:  "default",
+			Code:      "EAP",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   2,
+			Uses:      1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000002"),
+			TenantID:  "default",
+			Code:      "EXPIRED",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000003"),
+			TenantID:  "default",
+			Code:      "USED",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   1,
+			Uses:      1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000004"),
+			TenantID:  "default",
+			Code:      "CORE",
+			Role:      entities.RoleCore,
+			MaxUses:   1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.InvitationRedemption{
+			InvitationID: uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			RedeemedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Invitation{
+			ID:        uuid.MustParse("00000000-0000-0000-0005-000000000099"),
+			TenantID:  "tenant-2",
+			Code:      "OTHER",
+			Role:      entities.RoleEarlyAccessProgram,
+			MaxUses:   1,
+			CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now     time.Time
+		request *dao.RedeemInvitationRequest
+
+		expect            *entities.Credential
+		expectRoleChanges int
+		expectErr         error
+	}{
+		{
+			name: "Upgrade",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "EAP",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expect: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:  "default",
+				Email:     "email-2",
+				Role:      entities.RoleEarlyAccessProgram,
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			expectRoleChanges: 1,
+		},
+		{
+			name: "RaiseFallback",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "EAP",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+
+			expect: &entities.Credential{
+				ID:            uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				TenantID:      "default",
+				Email:         "email-3",
+				Role:          entities.RoleAdmin,
+				RoleExpiresAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "AlreadyRedeemed",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "EAP",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expectErr: dao.ErrInvitationAlreadyRedeemed,
+		},
+		{
+			name: "Expired",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "EXPIRED",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrInvitationUnavailable,
+		},
+		{
+			name: "NoUseLeft",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "USED",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrInvitationUnavailable,
+		},
+		{
+			name: "RoleCapacityReached",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "CORE",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrRoleCapacityReached,
+		},
+		{
+			// A legal hold only freezes the deletion and the email of the credentials.
+			name: "LegalHold",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "EAP",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			},
+
+			expect: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				TenantID:        "default",
+				Email:           "email-held",
+				Role:            entities.RoleEarlyAccessProgram,
+				LegalHold:       true,
+				LegalHoldReason: "dispute",
+				LegalHoldSetBy:  "legal@example.com",
+				LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			expectRoleChanges: 1,
+		},
+		{
+			name: "CredentialsNotFound",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "EAP",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "NotFound",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "UNKNOWN",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrInvitationNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			now: time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+			request: &dao.RedeemInvitationRequest{
+				Code:         "OTHER",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrInvitationNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			redeemInvitationDAO := dao.NewRedeemInvitation(transaction, entities.RoleCapacities{entities.RoleCore: 1})
+
+			credential, err := redeemInvitationDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+
+			if testCase.expectErr != nil {
+				return
+			}
+
+			invitation := new(entities.Invitation)
+			err = transaction.NewSelect().
+				Model(invitation).
+				Where("code = ?", testCase.request.Code).
+				Where("tenant_id = ?", "default").
+				Scan(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, 2, invitation.Uses)
+
+			redeemed, err := transaction.NewSelect().
+				Model((*entities.InvitationRedemption)(nil)).
+				Where("invitation_id = ?", invitation.ID).
+				Where("credential_id = ?", testCase.request.CredentialID).
+				Exists(context.Background())
+			require.NoError(t, err)
+			require.True(t, redeemed)
+
+			roleChanges, err := transaction.NewSelect().
+				Model((*entities.CredentialRoleChange)(nil)).
+				Where("credential_id = ?", testCase.request.CredentialID).
+				Where("reason = ?", entities.RoleChangeReasonInvitationRedeemed).
+				Count(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectRoleChanges, roleChanges)
+		})
+	}
+}
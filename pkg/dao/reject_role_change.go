@@ -0,0 +1,77 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RejectRoleChangeRequest struct {
+	RejectedBy uuid.UUID
+}
+
+type RejectRoleChange interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *RejectRoleChangeRequest,
+	) (*entities.RoleChangeRequest, error)
+}
+
+type rejectRoleChangeImpl struct {
+	database bun.IDB
+	// privileged roles are the only ones allowed to decide role change requests.
+	privileged []entities.Role
+}
+
+// Exec rejects a pending role change request. The rejecter must hold a privileged role. Unlike approvals, the
+// proposer may reject their own request, to withdraw it.
+func (dao *rejectRoleChangeImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *RejectRoleChangeRequest,
+) (*entities.RoleChangeRequest, error) {
+	var model *entities.RoleChangeRequest
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		var err error
+
+		if model, err = lockPendingRoleChangeRequest(ctx, tx, tenantID, id, now); err != nil {
+			return err
+		}
+
+		if request.RejectedBy == model.ProposedBy {
+			_, err = checkRoleChangeActor(ctx, tx, tenantID, request.RejectedBy, now)
+		} else {
+			err = checkRoleChangeDecider(ctx, tx, tenantID, dao.privileged, request.RejectedBy, now)
+		}
+		if err != nil {
+			return err
+		}
+
+		model.Status = entities.RoleChangeRequestStatusRejected
+		model.DecidedBy = &request.RejectedBy
+		model.DecidedAt = &now
+
+		_, err = tx.NewUpdate().
+			Model(model).
+			Column("status", "decided_by", "decided_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewRejectRoleChange(database bun.IDB, privileged []entities.Role) RejectRoleChange {
+	return &rejectRoleChangeImpl{database: database, privileged: privileged}
+}
@@ -0,0 +1,203 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestRejectRoleChange(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleAdmin,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleAdmin,
+			Status:       entities.RoleChangeRequestStatusPending,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleChangeRequest{
+			ID:           uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			Role:         entities.RoleCore,
+			Status:       entities.RoleChangeRequestStatusApproved,
+			ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000002")),
+			ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			DecidedAt:    lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.RejectRoleChangeRequest
+
+		expect    *entities.RoleChangeRequest
+		expectErr error
+	}{
+		{
+			name: "Reject",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expect: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusRejected,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000002")),
+				ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+				DecidedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Withdraw",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expect: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusRejected,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000001")),
+				ExpiresAt:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+				DecidedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "NotPrivileged",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+
+			expectErr: dao.ErrRoleChangeNotPrivileged,
+		},
+		{
+			name: "Decided",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrRoleChangeRequestClosed,
+		},
+		{
+			name: "Expired",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			request: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrRoleChangeRequestClosed,
+		},
+		{
+			name: "RejecterInOtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0007-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrRoleChangeRequestNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			rejectRoleChangeDAO := dao.NewRejectRoleChange(
+				transaction, []entities.Role{entities.RoleAdmin, entities.RoleCore},
+			)
+
+			request, err := rejectRoleChangeDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, request)
+		})
+	}
+}
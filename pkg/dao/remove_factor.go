@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RemoveFactorRequest struct {
+	CredentialID uuid.UUID
+}
+
+type RemoveFactor interface {
+	Exec(ctx context.Context, id uuid.UUID, request *RemoveFactorRequest) error
+}
+
+type removeFactorImpl struct {
+	database bun.IDB
+}
+
+func (dao *removeFactorImpl) Exec(ctx context.Context, id uuid.UUID, request *RemoveFactorRequest) error {
+	return runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		// Lock the credentials, so concurrent requests cannot remove every factor at once.
+		credential := new(entities.Credential)
+
+		err := tx.NewSelect().
+			Model(credential).
+			Column("mfa_required").
+			Where("id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrFactorNotFound
+			}
+
+			return fmt.Errorf("lock credentials: %w", err)
+		}
+
+		res, err := tx.NewDelete().
+			Model((*entities.CredentialFactor)(nil)).
+			Where("id = ?", id).
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return ErrFactorNotFound
+		}
+
+		if !credential.MFARequired {
+			return nil
+		}
+
+		// The transaction is rolled back if no factor remains.
+		remaining, err := tx.NewSelect().
+			Model((*entities.CredentialFactor)(nil)).
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Count(ctx)
+		if err != nil {
+			return fmt.Errorf("count remaining factors: %w", err)
+		}
+
+		if remaining == 0 {
+			return ErrLastFactor
+		}
+
+		return nil
+	})
+}
+
+func NewRemoveFactor(database bun.IDB) RemoveFactor {
+	return &removeFactorImpl{database: database}
+}
@@ -0,0 +1,198 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestRemoveFactor(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:    "default",
+			Email:       "email-1",
+			MFARequired: true,
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:         entities.FactorTypeTOTP,
+			Label:        "phone",
+			SecretRef:    "totp-secret-ref",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:                   uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			TenantID:             "default",
+			CredentialID:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:                 entities.FactorTypeWebAuthn,
+			Label:                "security key",
+			WebAuthnCredentialID: "webauthn-credential-id",
+			WebAuthnPublicKey:    []byte("public-key"),
+			CreatedAt:            time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			LastUsedAt:           lo.ToPtr(time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Type:         entities.FactorTypeRecoveryCodes,
+			Label:        "recovery codes",
+			SecretRef:    "recovery-codes-ref",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		request *dao.RemoveFactorRequest
+
+		expectRemaining int
+		expectErr       error
+	}{
+		{
+			name: "Remove",
+
+			id: uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			request: &dao.RemoveFactorRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expectRemaining: 1,
+		},
+		{
+			name: "Remove/Last/MFANotRequired",
+
+			id: uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			request: &dao.RemoveFactorRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectRemaining: 0,
+		},
+		{
+			name: "OtherCredentials",
+
+			id: uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			request: &dao.RemoveFactorRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expectRemaining: 2,
+			expectErr:       dao.ErrFactorNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			removeFactorDAO := dao.NewRemoveFactor(transaction)
+
+			ctx := entities.ContextWithTenant(context.Background(), "default")
+			err := removeFactorDAO.Exec(ctx, testCase.id, testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			remaining, err := dao.NewListFactors(transaction).Exec(ctx, testCase.request.CredentialID)
+			require.NoError(t, err)
+			require.Len(t, remaining, testCase.expectRemaining)
+		})
+	}
+}
+
+func TestRemoveFactorLast(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:          uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:    "default",
+			Email:       "email-1",
+			MFARequired: true,
+			CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:         entities.FactorTypeTOTP,
+			Label:        "phone",
+			SecretRef:    "totp-secret-ref",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialFactor{
+			ID:                   uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			TenantID:             "default",
+			CredentialID:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Type:                 entities.FactorTypeWebAuthn,
+			Label:                "security key",
+			WebAuthnCredentialID: "webauthn-credential-id",
+			WebAuthnPublicKey:    []byte("public-key"),
+			CreatedAt:            time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			LastUsedAt:           lo.ToPtr(time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.CredentialFactor{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Type:         entities.FactorTypeRecoveryCodes,
+			Label:        "recovery codes",
+			SecretRef:    "recovery-codes-ref",
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	removeFactorDAO := dao.NewRemoveFactor(transaction)
+
+	ctx := entities.ContextWithTenant(context.Background(), "default")
+	request := &dao.RemoveFactorRequest{CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001")}
+
+	err = removeFactorDAO.Exec(ctx, uuid.MustParse("10000000-0000-0000-0000-000000000001"), request)
+	require.NoError(t, err)
+
+	// Credentials require MFA, so the last factor cannot be removed.
+	err = removeFactorDAO.Exec(ctx, uuid.MustParse("10000000-0000-0000-0000-000000000002"), request)
+	require.ErrorIs(t, err, dao.ErrLastFactor)
+
+	remaining, err := dao.NewListFactors(transaction).Exec(ctx, request.CredentialID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+}
@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RenameFactorRequest struct {
+	CredentialID uuid.UUID
+	Label        string
+}
+
+type RenameFactor interface {
+	Exec(ctx context.Context, id uuid.UUID, request *RenameFactorRequest) (*entities.CredentialFactor, error)
+}
+
+type renameFactorImpl struct {
+	database bun.IDB
+}
+
+func (dao *renameFactorImpl) Exec(
+	ctx context.Context, id uuid.UUID, request *RenameFactorRequest,
+) (*entities.CredentialFactor, error) {
+	model := new(entities.CredentialFactor)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		res, err := tx.NewUpdate().
+			Model(model).
+			Set("label = ?", request.Label).
+			Where("id = ?", id).
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			var pgErr pgdriver.Error
+			if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+				return ErrFactorAlreadyExists
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return ErrFactorNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewRenameFactor(database bun.IDB) RenameFactor {
+	return &renameFactorImpl{database: database}
+}
@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RequestAccountDeletionRequest struct {
+	// ScheduledAt is the end of the grace period, after which the credentials are erased.
+	ScheduledAt time.Time
+}
+
+type RequestAccountDeletion interface {
+	Exec(
+		ctx context.Context, id uuid.UUID, now time.Time, request *RequestAccountDeletionRequest,
+	) (*entities.Credential, error)
+}
+
+type requestAccountDeletionImpl struct {
+	database bun.IDB
+	// protected roles must keep at least one holder.
+	protected []entities.Role
+}
+
+// Exec schedules the deletion of the credentials. Requesting the deletion of credentials already pending deletion
+// keeps the original schedule, so the grace period cannot be extended by repeating the request.
+func (dao *requestAccountDeletionImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *RequestAccountDeletionRequest,
+) (*entities.Credential, error) {
+	model := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		current := new(entities.Credential)
+
+		err := tx.NewSelect().
+			Model(current).
+			Column("id", "kind", "role", "fallback_role", "deletion_scheduled_at").
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Where("NOT legal_hold").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return checkLegalHold(ctx, tx, tenantID, id)
+			}
+
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		if current.DeletionScheduledAt == nil {
+			if err = checkLastRoleHolder(ctx, tx, tenantID, dao.protected, current); err != nil {
+				return err
+			}
+		}
+
+		err = tx.
+			NewUpdate().
+			Model(model).
+			Set("deletion_scheduled_at = COALESCE(deletion_scheduled_at, ?)", request.ScheduledAt).
+			Set("updated_at = ?", now).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Where("NOT legal_hold").
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewRequestAccountDeletion(database bun.IDB, protected []entities.Role) RequestAccountDeletion {
+	return &requestAccountDeletionImpl{database: database, protected: protected}
+}
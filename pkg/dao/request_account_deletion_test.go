@@ -0,0 +1,173 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestRequestAccountDeletion(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:            "default",
+			Email:               "email-2",
+			Role:                entities.RoleCore,
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id        uuid.UUID
+		now       time.Time
+		request   *dao.RequestAccountDeletionRequest
+		protected []entities.Role
+
+		expect    *entities.Credential
+		expectErr error
+	}{
+		{
+			name: "Request",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.RequestAccountDeletionRequest{
+				ScheduledAt: time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &entities.Credential{
+				ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:            "default",
+				Email:               "email-1",
+				Role:                entities.RoleCore,
+				DeletionScheduledAt: lo.ToPtr(time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:           lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "AlreadyScheduled",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.RequestAccountDeletionRequest{
+				ScheduledAt: time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC),
+			},
+			// The credentials stopped holding their role when their deletion was first requested.
+			protected: []entities.Role{entities.RoleCore},
+
+			expect: &entities.Credential{
+				ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:            "default",
+				Email:               "email-2",
+				Role:                entities.RoleCore,
+				DeletionScheduledAt: lo.ToPtr(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:           lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			// Credentials pending deletion no longer count as holders of their role.
+			name: "LastProtectedRoleHolder",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.RequestAccountDeletionRequest{
+				ScheduledAt: time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC),
+			},
+			protected: []entities.Role{entities.RoleCore},
+
+			expectErr: dao.ErrLastProtectedRoleHolder,
+		},
+		{
+			name: "NotFound",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.RequestAccountDeletionRequest{
+				ScheduledAt: time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "LegalHold",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.RequestAccountDeletionRequest{
+				ScheduledAt: time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsOnLegalHold,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.RequestAccountDeletionRequest{
+				ScheduledAt: time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			requestAccountDeletionDAO := dao.NewRequestAccountDeletion(transaction, testCase.protected)
+
+			credential, err := requestAccountDeletionDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, credential)
+		})
+	}
+}
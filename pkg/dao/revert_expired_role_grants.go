@@ -0,0 +1,112 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RevertExpiredRoleGrants interface {
+	Exec(ctx context.Context, now time.Time) ([]*entities.CredentialRoleChange, error)
+}
+
+type revertExpiredRoleGrantsImpl struct {
+	database   bun.IDB
+	capacities entities.RoleCapacities
+}
+
+// Exec reverts the credentials whose role grant is due to their fallback role, and records each change. Legal holds
+// do not prevent the revert: a hold preserves data, and must not extend access. The seats released by the expired
+// roles go to the waitlist, and the resulting promotions are returned along with the reverts.
+func (dao *revertExpiredRoleGrantsImpl) Exec(
+	ctx context.Context, now time.Time,
+) ([]*entities.CredentialRoleChange, error) {
+	var changes []*entities.CredentialRoleChange
+
+	// Grants do not depend on the tenant, so every tenant is processed at once.
+	err := runAcrossTenants(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		expired := make([]*entities.Credential, 0)
+
+		// Rows locked by a concurrent update are picked up on the next run.
+		err := tx.NewSelect().
+			Model(&expired).
+			Column("id", "tenant_id", "role", "fallback_role").
+			Where("role_expires_at <= ?", now).
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("select expired grants: %w", err)
+		}
+
+		if len(expired) == 0 {
+			return nil
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*entities.Credential)(nil)).
+			Set("role = fallback_role").
+			Set("fallback_role = NULL").
+			Set("role_expires_at = NULL").
+			Set("updated_at = ?", now).
+			Where("id IN (?)", bun.In(lo.Map(expired, func(item *entities.Credential, _ int) uuid.UUID {
+				return item.ID
+			}))).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("revert grants: %w", err)
+		}
+
+		changes = lo.Map(expired, func(item *entities.Credential, _ int) *entities.CredentialRoleChange {
+			return &entities.CredentialRoleChange{
+				ID:           uuid.New(),
+				TenantID:     item.TenantID,
+				CredentialID: item.ID,
+				FromRole:     item.Role,
+				ToRole:       lo.FromPtr(item.FallbackRole),
+				Reason:       entities.RoleChangeReasonGrantExpired,
+				ChangedAt:    now,
+			}
+		})
+
+		if _, err = tx.NewInsert().Model(&changes).Exec(ctx); err != nil {
+			return fmt.Errorf("record role changes: %w", err)
+		}
+
+		released := lo.Uniq(lo.Map(
+			changes,
+			func(item *entities.CredentialRoleChange, _ int) lo.Tuple2[string, entities.Role] {
+				return lo.T2(item.TenantID, item.FromRole)
+			},
+		))
+
+		for _, seat := range released {
+			if _, ok := dao.capacities.Limit(seat.B); !ok {
+				continue
+			}
+
+			promotions, err := promoteWaitlisted(ctx, tx, seat.A, dao.capacities, seat.B, now)
+			if err != nil {
+				return err
+			}
+
+			changes = append(changes, promotions...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func NewRevertExpiredRoleGrants(database bun.IDB, capacities entities.RoleCapacities) RevertExpiredRoleGrants {
+	return &revertExpiredRoleGrantsImpl{database: database, capacities: capacities}
+}
@@ -0,0 +1,172 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestRevertExpiredRoleGrants(t *testing.T) {
+	fixtures := []interface{}{
+		// Expired.
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:      "default",
+			Email:         "email-1",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleNone),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Still active.
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:      "default",
+			Email:         "email-2",
+			Role:          entities.RoleEarlyAccessProgram,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleNone),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Permanent role.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:  "default",
+			Email:     "email-3",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Held credentials are reverted too.
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:        "default",
+			Email:           "email-held",
+			Role:            entities.RoleCore,
+			RoleExpiresAt:   lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:    lo.ToPtr(entities.RoleAdmin),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Takes the admin seat released by the first credentials.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:  "default",
+			Email:     "email-waitlisted",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			Role:         entities.RoleAdmin,
+			EnqueuedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:      "tenant-2",
+			Email:         "email-other",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	revertExpiredRoleGrantsDAO := dao.NewRevertExpiredRoleGrants(
+		transaction, entities.RoleCapacities{entities.RoleAdmin: 2},
+	)
+
+	now := time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	changes, err := revertExpiredRoleGrantsDAO.Exec(context.Background(), now)
+	require.NoError(t, err)
+
+	// Records are identified by random IDs.
+	require.ElementsMatch(
+		t,
+		[]*entities.CredentialRoleChange{
+			{
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				FromRole:     entities.RoleAdmin,
+				ToRole:       entities.RoleNone,
+				Reason:       entities.RoleChangeReasonGrantExpired,
+				ChangedAt:    now,
+			},
+			{
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				FromRole:     entities.RoleCore,
+				ToRole:       entities.RoleAdmin,
+				Reason:       entities.RoleChangeReasonGrantExpired,
+				ChangedAt:    now,
+			},
+			{
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+				FromRole:     entities.RoleNone,
+				ToRole:       entities.RoleAdmin,
+				Reason:       entities.RoleChangeReasonWaitlistPromoted,
+				ChangedAt:    now,
+			},
+			{
+				TenantID:     "tenant-2",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+				FromRole:     entities.RoleAdmin,
+				ToRole:       entities.RoleEarlyAccessProgram,
+				Reason:       entities.RoleChangeReasonGrantExpired,
+				ChangedAt:    now,
+			},
+		},
+		lo.Map(changes, func(item *entities.CredentialRoleChange, _ int) *entities.CredentialRoleChange {
+			require.NotEqual(t, uuid.Nil, item.ID)
+			item.ID = uuid.Nil
+
+			return item
+		}),
+	)
+
+	credentials := make([]*entities.Credential, 0)
+	require.NoError(t, transaction.NewSelect().Model(&credentials).Order("id ASC").Scan(context.Background()))
+	require.Equal(
+		t,
+		[]entities.Role{
+			entities.RoleNone,
+			entities.RoleEarlyAccessProgram,
+			entities.RoleCore,
+			entities.RoleAdmin,
+			entities.RoleAdmin,
+			entities.RoleEarlyAccessProgram,
+		},
+		lo.Map(credentials, func(item *entities.Credential, _ int) entities.Role { return item.Role }),
+	)
+	require.Equal(
+		t,
+		[]bool{false, true, false, false, false, false},
+		lo.Map(credentials, func(item *entities.Credential, _ int) bool { return item.RoleExpiresAt != nil }),
+	)
+
+	recorded, err := transaction.NewSelect().Model((*entities.CredentialRoleChange)(nil)).Count(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 4, recorded)
+}
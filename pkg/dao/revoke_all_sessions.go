@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RevokeAllSessions interface {
+	Exec(ctx context.Context, id uuid.UUID, now time.Time) (*entities.Credential, error)
+}
+
+type revokeAllSessionsImpl struct {
+	database bun.IDB
+}
+
+// Exec bumps the token epoch of the credentials, so every session and token issued before is rejected.
+func (dao *revokeAllSessionsImpl) Exec(ctx context.Context, id uuid.UUID, now time.Time) (*entities.Credential, error) {
+	model := new(entities.Credential)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.
+			NewUpdate().
+			Model(model).
+			Set("token_epoch = token_epoch + 1").
+			Set("updated_at = ?", now).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewRevokeAllSessions(database bun.IDB) RevokeAllSessions {
+	return &revokeAllSessionsImpl{database: database}
+}
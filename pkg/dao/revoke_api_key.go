@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RevokeAPIKeyRequest struct {
+	CredentialID uuid.UUID
+}
+
+type RevokeAPIKey interface {
+	Exec(ctx context.Context, id uuid.UUID, now time.Time, request *RevokeAPIKeyRequest) error
+}
+
+type revokeAPIKeyImpl struct {
+	database bun.IDB
+}
+
+func (dao *revokeAPIKeyImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *RevokeAPIKeyRequest,
+) error {
+	return runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		res, err := tx.NewUpdate().
+			Model((*entities.APIKey)(nil)).
+			Set("revoked_at = ?", now).
+			Where("id = ?", id).
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("revoked_at IS NULL").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return ErrAPIKeyNotFound
+		}
+
+		return nil
+	})
+}
+
+func NewRevokeAPIKey(database bun.IDB) RevokeAPIKey {
+	return &revokeAPIKeyImpl{database: database}
+}
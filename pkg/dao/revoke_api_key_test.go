@@ -0,0 +1,123 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestRevokeAPIKey(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-1",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.APIKey{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Prefix:       "prefix-1",
+			SecretHash:   []byte("hash-1"),
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.APIKey{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Prefix:       "prefix-2",
+			SecretHash:   []byte("hash-2"),
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			RevokedAt:    lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		request *dao.RevokeAPIKeyRequest
+
+		expectErr error
+	}{
+		{
+			name: "Revoke",
+
+			id: uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			request: &dao.RevokeAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
+		{
+			name: "AlreadyRevoked",
+
+			id: uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			request: &dao.RevokeAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrAPIKeyNotFound,
+		},
+		{
+			name: "WrongCredentials",
+
+			id: uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			request: &dao.RevokeAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expectErr: dao.ErrAPIKeyNotFound,
+		},
+		{
+			name: "NotFound",
+
+			id: uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			request: &dao.RevokeAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expectErr: dao.ErrAPIKeyNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			revokeAPIKeyDAO := dao.NewRevokeAPIKey(transaction)
+
+			err := revokeAPIKeyDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				testCase.id,
+				time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func roleRank(role entities.Role) int {
+	return lo.IndexOf(entities.RolesSorted, role)
+}
+
+// heldRoles returns the roles that occupy a seat for the credentials. A temporary grant keeps the seat of the
+// fallback role, so the credentials can revert to it.
+func heldRoles(credential *entities.Credential) []entities.Role {
+	if credential.FallbackRole == nil {
+		return []entities.Role{credential.Role}
+	}
+
+	return lo.Uniq([]entities.Role{credential.Role, *credential.FallbackRole})
+}
+
+// lockRole serializes the changes of the holders of a role within a tenant, until the end of the transaction.
+func lockRole(ctx context.Context, tx bun.Tx, tenantID string, role entities.Role) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", tenantID+"/"+string(role)); err != nil {
+		return fmt.Errorf("lock role: %w", err)
+	}
+
+	return nil
+}
+
+func countRoleHolders(ctx context.Context, tx bun.Tx, tenantID string, role entities.Role) (int, error) {
+	count, err := tx.NewSelect().
+		Model((*entities.Credential)(nil)).
+		Where("tenant_id = ?", tenantID).
+		Where("role = ? OR fallback_role = ?", role, role).
+		Where("erased_at IS NULL").
+		Where("deleted_at IS NULL").
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("count role holders: %w", err)
+	}
+
+	return count, nil
+}
+
+// checkRoleCapacity ensures a seat is left for one more holder of the role. The role stays locked until the end of
+// the transaction, so the seat cannot be taken concurrently.
+func checkRoleCapacity(
+	ctx context.Context, tx bun.Tx, tenantID string, capacities entities.RoleCapacities, role entities.Role,
+) error {
+	limit, ok := capacities.Limit(role)
+	if !ok {
+		return nil
+	}
+
+	if err := lockRole(ctx, tx, tenantID, role); err != nil {
+		return err
+	}
+
+	count, err := countRoleHolders(ctx, tx, tenantID, role)
+	if err != nil {
+		return err
+	}
+
+	if count >= limit {
+		return ErrRoleCapacityReached
+	}
+
+	return nil
+}
+
+// reassignSeats moves the seats of credentials whose roles changed: the roles they gained must have a seat left,
+// and the seats they released go to the next credentials in the waitlist.
+func reassignSeats(
+	ctx context.Context,
+	tx bun.Tx,
+	tenantID string,
+	capacities entities.RoleCapacities,
+	now time.Time,
+	before, after []entities.Role,
+) error {
+	gained, released := lo.Difference(after, before)
+
+	for _, role := range gained {
+		if err := checkRoleCapacity(ctx, tx, tenantID, capacities, role); err != nil {
+			return err
+		}
+	}
+
+	for _, role := range released {
+		if _, ok := capacities.Limit(role); !ok {
+			continue
+		}
+
+		if _, err := promoteWaitlisted(ctx, tx, tenantID, capacities, role, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// promoteWaitlisted hands the free seats of a role to the credentials first in its waitlist, and records the
+// changes. Credentials that can no longer be promoted leave the waitlist.
+func promoteWaitlisted(
+	ctx context.Context,
+	tx bun.Tx,
+	tenantID string,
+	capacities entities.RoleCapacities,
+	role entities.Role,
+	now time.Time,
+) ([]*entities.CredentialRoleChange, error) {
+	_, err := tx.NewDelete().
+		Model((*entities.RoleWaitlistEntry)(nil)).
+		Where("tenant_id = ?", tenantID).
+		Where("role = ?", role).
+		Where(
+			"credential_id IN (?)",
+			tx.NewSelect().
+				Model((*entities.Credential)(nil)).
+				Column("id").
+				Where("tenant_id = ?", tenantID).
+				Where("erased_at IS NOT NULL OR deleted_at IS NOT NULL OR COALESCE(fallback_role, role) >= ?", role),
+		).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("clean waitlist: %w", err)
+	}
+
+	limit, limited := capacities.Limit(role)
+
+	free := 0
+	if limited {
+		if err = lockRole(ctx, tx, tenantID, role); err != nil {
+			return nil, err
+		}
+
+		count, err := countRoleHolders(ctx, tx, tenantID, role)
+		if err != nil {
+			return nil, err
+		}
+
+		if free = limit - count; free <= 0 {
+			return nil, nil
+		}
+	}
+
+	candidates := make([]*entities.Credential, 0)
+
+	query := tx.NewSelect().
+		Model(&candidates).
+		Column("credentials.id", "credentials.role", "credentials.role_expires_at", "credentials.fallback_role").
+		Join("JOIN role_waitlist ON role_waitlist.credential_id = credentials.id").
+		Where("role_waitlist.tenant_id = ?", tenantID).
+		Where("role_waitlist.role = ?", role).
+		Where("credentials.kind = ?", entities.CredentialKindUser).
+		Order("role_waitlist.seq ASC").
+		For("UPDATE OF credentials")
+	if limited {
+		query = query.Limit(free)
+	}
+
+	if err = query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("select waitlisted credentials: %w", err)
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	changes := make([]*entities.CredentialRoleChange, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		fromRole := candidate.Role
+
+		if candidate.RoleExpiresAt != nil && roleRank(candidate.Role) > roleRank(role) {
+			// A higher temporary role is kept, and reverts to the promoted role once it expires.
+			candidate.FallbackRole = &role
+		} else {
+			candidate.Role = role
+			candidate.RoleExpiresAt = nil
+			candidate.FallbackRole = nil
+		}
+
+		candidate.UpdatedAt = &now
+
+		_, err = tx.NewUpdate().
+			Model(candidate).
+			Column("role", "role_expires_at", "fallback_role", "updated_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("promote credentials: %w", err)
+		}
+
+		if fromRole != candidate.Role {
+			changes = append(changes, &entities.CredentialRoleChange{
+				ID:           uuid.New(),
+				TenantID:     tenantID,
+				CredentialID: candidate.ID,
+				FromRole:     fromRole,
+				ToRole:       candidate.Role,
+				Reason:       entities.RoleChangeReasonWaitlistPromoted,
+				ChangedAt:    now,
+			})
+		}
+	}
+
+	_, err = tx.NewDelete().
+		Model((*entities.RoleWaitlistEntry)(nil)).
+		Where("role = ?", role).
+		Where("credential_id IN (?)", bun.In(lo.Map(candidates, func(item *entities.Credential, _ int) uuid.UUID {
+			return item.ID
+		}))).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("dequeue credentials: %w", err)
+	}
+
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	if _, err = tx.NewInsert().Model(&changes).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("record role changes: %w", err)
+	}
+
+	return changes, nil
+}
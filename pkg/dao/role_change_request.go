@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// checkRoleChangeActor ensures the actor proposing or deciding a role change request is a user of the tenant, and
+// returns the role they hold at the given time. Foreign keys ignore tenant isolation, so this must be checked
+// explicitly.
+func checkRoleChangeActor(
+	ctx context.Context, tx bun.Tx, tenantID string, actorID uuid.UUID, now time.Time,
+) (entities.Role, error) {
+	actor := new(entities.Credential)
+
+	err := tx.NewSelect().
+		Model(actor).
+		Column("id", "role", "role_expires_at", "fallback_role").
+		Where("id = ?", actorID).
+		Where("tenant_id = ?", tenantID).
+		Where("kind = ?", entities.CredentialKindUser).
+		Where("erased_at IS NULL").
+		Where("deleted_at IS NULL").
+		For("SHARE").
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.RoleNone, ErrCredentialsNotFound
+		}
+
+		return entities.RoleNone, fmt.Errorf("check actor existence: %w", err)
+	}
+
+	actor.ClearExpiredRoleGrant(now)
+
+	return actor.Role, nil
+}
+
+// checkRoleChangeDecider ensures the actor deciding a role change request is a user of the tenant, that holds a
+// privileged role.
+func checkRoleChangeDecider(
+	ctx context.Context, tx bun.Tx, tenantID string, privileged []entities.Role, actorID uuid.UUID, now time.Time,
+) error {
+	role, err := checkRoleChangeActor(ctx, tx, tenantID, actorID, now)
+	if err != nil {
+		return err
+	}
+
+	if !lo.Contains(privileged, role) {
+		return ErrRoleChangeNotPrivileged
+	}
+
+	return nil
+}
+
+// lockPendingRoleChangeRequest locks a role change request that is still open for a decision.
+func lockPendingRoleChangeRequest(
+	ctx context.Context, tx bun.Tx, tenantID string, id uuid.UUID, now time.Time,
+) (*entities.RoleChangeRequest, error) {
+	request := new(entities.RoleChangeRequest)
+
+	err := tx.NewSelect().
+		Model(request).
+		Where("id = ?", id).
+		Where("tenant_id = ?", tenantID).
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRoleChangeRequestNotFound
+		}
+
+		return nil, fmt.Errorf("select role change request: %w", err)
+	}
+
+	if request.StatusAt(now) != entities.RoleChangeRequestStatusPending {
+		return nil, ErrRoleChangeRequestClosed
+	}
+
+	return request, nil
+}
@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type RotateAPIKeyRequest struct {
+	CredentialID uuid.UUID
+	// NewID, Prefix and SecretHash describe the key that replaces the rotated one.
+	NewID      uuid.UUID
+	Prefix     string
+	SecretHash []byte
+	ExpiresAt  *time.Time
+}
+
+type RotateAPIKey interface {
+	Exec(ctx context.Context, id uuid.UUID, now time.Time, request *RotateAPIKeyRequest) (*entities.APIKey, error)
+}
+
+type rotateAPIKeyImpl struct {
+	database bun.IDB
+}
+
+// Exec revokes an active API key, and replaces it with a new key that has the same scopes.
+func (dao *rotateAPIKeyImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *RotateAPIKeyRequest,
+) (*entities.APIKey, error) {
+	rotated := new(entities.APIKey)
+
+	model := &entities.APIKey{
+		ID:           request.NewID,
+		CredentialID: request.CredentialID,
+		Prefix:       request.Prefix,
+		SecretHash:   request.SecretHash,
+		ExpiresAt:    request.ExpiresAt,
+		CreatedAt:    now,
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		model.TenantID = tenantID
+
+		err := tx.NewUpdate().
+			Model(rotated).
+			Set("revoked_at = ?", now).
+			Where("id = ?", id).
+			Where("credential_id = ?", request.CredentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("revoked_at IS NULL").
+			Where("(expires_at IS NULL OR expires_at > ?)", now).
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAPIKeyNotFound
+			}
+
+			return fmt.Errorf("revoke api key: %w", err)
+		}
+
+		model.Scopes = rotated.Scopes
+
+		if _, err = tx.NewInsert().Model(model).Returning("*").Exec(ctx); err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewRotateAPIKey(database bun.IDB) RotateAPIKey {
+	return &rotateAPIKeyImpl{database: database}
+}
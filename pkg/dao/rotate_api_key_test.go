@@ -0,0 +1,161 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestRotateAPIKey(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-1",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.APIKey{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Prefix:       "prefix-1",
+			SecretHash:   []byte("hash-1"),
+			Scopes:       []string{"books:read", "books:write"},
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.APIKey{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Prefix:       "prefix-2",
+			SecretHash:   []byte("hash-2"),
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			RevokedAt:    lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.APIKey{
+			ID:           uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			Prefix:       "prefix-3",
+			SecretHash:   []byte("hash-3"),
+			ExpiresAt:    lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		id      uuid.UUID
+		now     time.Time
+		request *dao.RotateAPIKeyRequest
+
+		expect    *entities.APIKey
+		expectErr error
+	}{
+		{
+			name: "Rotate",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RotateAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				NewID:        uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+				Prefix:       "prefix-10",
+				SecretHash:   []byte("hash-10"),
+				ExpiresAt:    lo.ToPtr(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &entities.APIKey{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Prefix:       "prefix-10",
+				SecretHash:   []byte("hash-10"),
+				Scopes:       []string{"books:read", "books:write"},
+				ExpiresAt:    lo.ToPtr(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Revoked",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RotateAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				NewID:        uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+				Prefix:       "prefix-10",
+				SecretHash:   []byte("hash-10"),
+			},
+
+			expectErr: dao.ErrAPIKeyNotFound,
+		},
+		{
+			name: "Expired",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RotateAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				NewID:        uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+				Prefix:       "prefix-10",
+				SecretHash:   []byte("hash-10"),
+			},
+
+			expectErr: dao.ErrAPIKeyNotFound,
+		},
+		{
+			name: "WrongCredentials",
+
+			id:  uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			request: &dao.RotateAPIKeyRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				NewID:        uuid.MustParse("10000000-0000-0000-0000-000000000010"),
+				Prefix:       "prefix-10",
+				SecretHash:   []byte("hash-10"),
+			},
+
+			expectErr: dao.ErrAPIKeyNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			rotateAPIKeyDAO := dao.NewRotateAPIKey(transaction)
+
+			apiKey, err := rotateAPIKeyDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.id, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, apiKey)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -29,7 +30,16 @@ type SearchCredentialsRequest struct {
 	Sort          entities.SortCredentials
 	SortDirection database.SortDirection
 	Emails        []string
-	Roles         []entities.Role
+	// CanonicalEmails only keeps credentials whose canonical email is one of the given values.
+	CanonicalEmails []string
+	Roles           []entities.Role
+	Labels          []*entities.LabelSelector
+	// Verified only keeps credentials with a verified email when true, or an unverified one when false. A nil value
+	// disables the filter.
+	Verified *bool
+	// LegalHold only keeps credentials on legal hold when true, or not on legal hold when false. A nil value disables
+	// the filter.
+	LegalHold *bool
 }
 
 type SearchCredentials interface {
@@ -78,15 +88,52 @@ func (dao *searchCredentialsImpl) Exec(ctx context.Context, request *SearchCrede
 		query = query.Where("email = ?", request.Emails[0])
 	}
 
+	if len(request.CanonicalEmails) > 0 {
+		query = query.Where("credentials.canonical_email IN (?)", bun.In(request.CanonicalEmails))
+	}
+
 	if len(request.Roles) > 1 {
 		query = query.Where("role IN (?)", bun.In(request.Roles))
 	} else if len(request.Roles) == 1 {
 		query = query.Where("role = ?", request.Roles[0])
 	}
 
-	err := query.Scan(ctx, &credentials)
+	if request.Verified != nil {
+		if *request.Verified {
+			query = query.Where("credentials.email_verified_at IS NOT NULL")
+		} else {
+			query = query.Where("credentials.email_verified_at IS NULL")
+		}
+	}
+
+	if request.LegalHold != nil {
+		if *request.LegalHold {
+			query = query.Where("credentials.legal_hold")
+		} else {
+			query = query.Where("NOT credentials.legal_hold")
+		}
+	}
+
+	for _, selector := range request.Labels {
+		var err error
+		if query, err = whereLabelSelector(query, selector); err != nil {
+			return nil, err
+		}
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := query.Conn(tx).
+			Where("credentials.tenant_id = ?", tenantID).
+			Where("credentials.deleted_at IS NULL").
+			Scan(ctx, &credentials)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("exec query: %w", err)
+		return nil, err
 	}
 
 	ids := lo.Map(credentials, func(item *entities.Credential, _ int) uuid.UUID {
@@ -96,6 +143,27 @@ func (dao *searchCredentialsImpl) Exec(ctx context.Context, request *SearchCrede
 	return ids, nil
 }
 
+func whereLabelSelector(
+	query *bun.SelectQuery, selector *entities.LabelSelector,
+) (*bun.SelectQuery, error) {
+	switch selector.Operator {
+	case entities.LabelSelectorOperatorEquals, entities.LabelSelectorOperatorIn:
+		// Use the containment operator, so the lookup is backed by the GIN index on labels.
+		return query.WhereGroup(" AND ", func(query *bun.SelectQuery) *bun.SelectQuery {
+			for _, value := range selector.Values {
+				containment, _ := json.Marshal(map[string]string{selector.Key: value})
+				query = query.WhereOr("credentials.labels @> ?::jsonb", string(containment))
+			}
+
+			return query
+		}), nil
+	case entities.LabelSelectorOperatorNotExists:
+		return query.Where("credentials.labels -> ? IS NULL", selector.Key), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown operator '%s'", entities.ErrInvalidLabelSelector, selector.Operator)
+	}
+}
+
 func NewSearchCredentials(database bun.IDB) SearchCredentials {
 	return &searchCredentialsImpl{database: database}
 }
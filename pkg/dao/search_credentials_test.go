@@ -25,25 +25,51 @@ func TestCredentialsSearch(t *testing.T) {
 		// Insertion order: Credentials 2, Credentials 1, Credentials 3
 
 		&entities.Credential{
-			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
-			Email:     "email_2",
-			Role:      entities.RoleEarlyAccessProgram,
-			CreatedAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
-			UpdatedAt: lo.ToPtr(time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC)),
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:        "default",
+			Email:           "email_2",
+			CanonicalEmail:  "email_2",
+			EmailVerifiedAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+			Labels:          map[string]string{"source": "ads", "cohort": "beta-1"},
+			Role:            entities.RoleEarlyAccessProgram,
+			CreatedAt:       time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:       lo.ToPtr(time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC)),
 		},
 		&entities.Credential{
-			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
-			Email:     "email_1",
-			Role:      entities.RoleCore,
-			CreatedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
-			UpdatedAt: lo.ToPtr(time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)),
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:       "default",
+			Email:          "email_1",
+			CanonicalEmail: "email_1",
+			Labels:         map[string]string{"source": "organic"},
+			Role:           entities.RoleCore,
+			CreatedAt:      time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:      lo.ToPtr(time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)),
 		},
 		&entities.Credential{
-			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000003"),
-			Email:     "email_3",
-			Role:      entities.RoleAdmin,
-			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
-			UpdatedAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:       "default",
+			Email:          "email_3",
+			CanonicalEmail: "email_3",
+			Labels:         map[string]string{"cohort": "beta-2"},
+			Role:           entities.RoleAdmin,
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:      lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		// Belongs to another tenant, and must never show up in results.
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:       "tenant-2",
+			Email:          "email_0",
+			CanonicalEmail: "email_0",
+			Labels:         map[string]string{"source": "ads"},
+			Role:           entities.RoleCore,
+			CreatedAt:      time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:      lo.ToPtr(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
 		},
 	}
 
@@ -318,6 +344,20 @@ func TestCredentialsSearch(t *testing.T) {
 			},
 		},
 
+		{
+			name: "Filter/CanonicalEmails",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:           3,
+				Offset:          0,
+				CanonicalEmails: []string{"email_2", "email_0"},
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
+
 		// Filter: roles
 		{
 			name: "Filter/Roles",
@@ -346,6 +386,122 @@ func TestCredentialsSearch(t *testing.T) {
 				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
 			},
 		},
+		{
+			name: "Filter/Labels/Equals",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:  3,
+				Offset: 0,
+				Labels: []*entities.LabelSelector{
+					{Key: "source", Operator: entities.LabelSelectorOperatorEquals, Values: []string{"ads"}},
+				},
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
+		{
+			name: "Filter/Labels/In",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:  3,
+				Offset: 0,
+				Labels: []*entities.LabelSelector{
+					{Key: "cohort", Operator: entities.LabelSelectorOperatorIn, Values: []string{"beta-1", "beta-2"}},
+				},
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+		},
+		{
+			name: "Filter/Labels/NotExists",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:  3,
+				Offset: 0,
+				Labels: []*entities.LabelSelector{
+					{Key: "cohort", Operator: entities.LabelSelectorOperatorNotExists},
+				},
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+		},
+		{
+			name: "Filter/Labels/Combined",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:  3,
+				Offset: 0,
+				Labels: []*entities.LabelSelector{
+					{Key: "cohort", Operator: entities.LabelSelectorOperatorIn, Values: []string{"beta-1", "beta-2"}},
+					{Key: "source", Operator: entities.LabelSelectorOperatorNotExists},
+				},
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+		},
+		{
+			name: "Filter/Verified",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:    3,
+				Offset:   0,
+				Verified: lo.ToPtr(true),
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
+		{
+			name: "Filter/Unverified",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:    3,
+				Offset:   0,
+				Verified: lo.ToPtr(false),
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+		},
+		{
+			name: "Filter/LegalHold",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:     3,
+				Offset:    0,
+				LegalHold: lo.ToPtr(true),
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+		},
+		{
+			name: "Filter/NoLegalHold",
+
+			request: &dao.SearchCredentialsRequest{
+				Limit:     3,
+				Offset:    0,
+				LegalHold: lo.ToPtr(false),
+			},
+
+			expect: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+		},
 	}
 
 	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
@@ -359,7 +515,9 @@ func TestCredentialsSearch(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			searchCredentialsDAO := dao.NewSearchCredentials(transaction)
 
-			credential, err := searchCredentialsDAO.Exec(context.Background(), testCase.request)
+			credential, err := searchCredentialsDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"), testCase.request,
+			)
 
 			require.ErrorIs(t, err, testCase.expectErr)
 			require.Equal(t, testCase.expect, credential)
@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type SetLegalHoldRequest struct {
+	// Hold places the credentials on legal hold when true, and releases them when false.
+	Hold bool
+	// Reason and SetBy are only kept while the hold is active.
+	Reason string
+	SetBy  string
+}
+
+type SetLegalHold interface {
+	Exec(ctx context.Context, id uuid.UUID, now time.Time, request *SetLegalHoldRequest) (*entities.Credential, error)
+}
+
+type setLegalHoldImpl struct {
+	database bun.IDB
+}
+
+// Exec places the credentials on legal hold, or releases them. Setting a hold on credentials already held replaces
+// its reason and author.
+func (dao *setLegalHoldImpl) Exec(
+	ctx context.Context, id uuid.UUID, now time.Time, request *SetLegalHoldRequest,
+) (*entities.Credential, error) {
+	model := new(entities.Credential)
+
+	var setAt *time.Time
+	if request.Hold {
+		setAt = &now
+	}
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.
+			NewUpdate().
+			Model(model).
+			Set("legal_hold = ?", request.Hold).
+			Set("legal_hold_reason = ?", bun.NullZero(request.Reason)).
+			Set("legal_hold_set_by = ?", bun.NullZero(request.SetBy)).
+			Set("legal_hold_set_at = ?", setAt).
+			Set("updated_at = ?", now).
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			// There is nothing left to protect on erased credentials.
+			Where("erased_at IS NULL").
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewSetLegalHold(database bun.IDB) SetLegalHold {
+	return &setLegalHoldImpl{database: database}
+}
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type SweepExpiredTokens interface {
+	Exec(ctx context.Context, now time.Time) (int64, error)
+}
+
+type sweepExpiredTokensImpl struct {
+	database bun.IDB
+}
+
+func (dao *sweepExpiredTokensImpl) Exec(ctx context.Context, now time.Time) (int64, error) {
+	var cleared int64
+
+	// Expiration does not depend on the tenant, so every tenant is swept at once.
+	err := runAcrossTenants(ctx, dao.database, func(ctx context.Context, tx bun.Tx) error {
+		for _, column := range tokenColumns {
+			res, err := tx.
+				NewUpdate().
+				Model((*entities.Credential)(nil)).
+				Set("? = NULL", column.id).
+				Set("? = NULL", column.expiresAt).
+				Where("? <= ?", column.expiresAt, now).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("exec query (%s): %w", column.field, err)
+			}
+
+			rows, err := res.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("get rows affected (%s): %w", column.field, err)
+			}
+
+			cleared += rows
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return cleared, nil
+}
+
+func NewSweepExpiredTokens(database bun.IDB) SweepExpiredTokens {
+	return &sweepExpiredTokensImpl{database: database}
+}
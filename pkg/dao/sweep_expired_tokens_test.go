@@ -0,0 +1,141 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestSweepExpiredTokens(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:                                   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:                             "default",
+			Email:                                "email-1",
+			Role:                                 entities.RoleCore,
+			EmailValidationTokenID:               "email-validation-token-id",
+			PendingEmailValidationTokenID:        "pending-email-validation-token-id",
+			PasswordTokenID:                      "password-token-id",
+			ResetPasswordTokenID:                 "reset-password-token-id",
+			EmailValidationTokenExpiresAt:        lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			PendingEmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+			ResetPasswordTokenExpiresAt:          lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:                            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:                          uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:                    "tenant-2",
+			Email:                       "email-2",
+			Role:                        entities.RoleNone,
+			ResetPasswordTokenID:        "reset-password-token-id-2",
+			ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:                   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		now time.Time
+
+		expect      int64
+		expectState []*entities.Credential
+		expectErr   error
+	}{
+		{
+			name: "Sweep",
+
+			now: time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+
+			expect: 2,
+			expectState: []*entities.Credential{
+				{
+					ID:                                   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TenantID:                             "default",
+					Email:                                "email-1",
+					Role:                                 entities.RoleCore,
+					PendingEmailValidationTokenID:        "pending-email-validation-token-id",
+					PasswordTokenID:                      "password-token-id",
+					PendingEmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:                            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:                          uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					TenantID:                    "tenant-2",
+					Email:                       "email-2",
+					Role:                        entities.RoleNone,
+					ResetPasswordTokenID:        "reset-password-token-id-2",
+					ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:                   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "Sweep/All",
+
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+
+			expect: 4,
+			expectState: []*entities.Credential{
+				{
+					ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TenantID:        "default",
+					Email:           "email-1",
+					Role:            entities.RoleCore,
+					PasswordTokenID: "password-token-id",
+					CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					TenantID:  "tenant-2",
+					Email:     "email-2",
+					Role:      entities.RoleNone,
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "Sweep/Nothing",
+
+			now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+
+			expect: 0,
+			expectState: []*entities.Credential{
+				fixtures[0].(*entities.Credential),
+				fixtures[1].(*entities.Credential),
+			},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			sweepExpiredTokensDAO := dao.NewSweepExpiredTokens(transaction)
+
+			cleared, err := sweepExpiredTokensDAO.Exec(context.Background(), testCase.now)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, cleared)
+
+			state := make([]*entities.Credential, 0)
+			require.NoError(t, transaction.NewSelect().Model(&state).Order("id ASC").Scan(context.Background()))
+			require.Equal(t, testCase.expectState, state)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// runInTenant executes the callback in a transaction, scoped to the tenant found in the context. The tenant is
+// exposed to the row level security policies of the database, so rows from other tenants remain invisible even
+// if a query forgets to filter on them.
+func runInTenant(
+	ctx context.Context, database bun.IDB, callback func(ctx context.Context, tx bun.Tx, tenantID string) error,
+) error {
+	tenantID, err := entities.TenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return database.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', ?, true)", tenantID); err != nil {
+			return fmt.Errorf("set tenant: %w", err)
+		}
+
+		return callback(ctx, tx, tenantID)
+	})
+}
+
+// runAcrossTenants executes the callback in a transaction that bypasses tenant isolation. It must only be used by
+// maintenance tasks, that are not triggered on behalf of a tenant.
+func runAcrossTenants(
+	ctx context.Context, database bun.IDB, callback func(ctx context.Context, tx bun.Tx) error,
+) error {
+	return database.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT set_config('app.all_tenants', 'on', true)"); err != nil {
+			return fmt.Errorf("bypass tenant isolation: %w", err)
+		}
+
+		return callback(ctx, tx)
+	})
+}
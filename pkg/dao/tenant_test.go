@@ -0,0 +1,142 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// TestTenantIsolation checks the row level security policy of the credentials table, without the explicit tenant
+// filters of the DAOs. The test database connects as a superuser, that ignores the policy, so queries run as a
+// regular role.
+func TestTenantIsolation(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		// settings are declared to the database before running the queries, as runInTenant and runAcrossTenants do.
+		settings map[string]string
+		// insertTenant is the tenant of a new row, inserted after the select. Leave empty to skip the insert.
+		insertTenant string
+
+		expect          []uuid.UUID
+		expectInsertErr bool
+	}{
+		{
+			name: "Tenant",
+
+			settings: map[string]string{"app.tenant_id": "default"},
+
+			expect: []uuid.UUID{uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+		},
+		{
+			name: "Tenant/Other",
+
+			settings: map[string]string{"app.tenant_id": "tenant-2"},
+
+			expect: []uuid.UUID{uuid.MustParse("00000000-0000-0000-0000-000000000099")},
+		},
+		{
+			name: "Tenant/Insert",
+
+			settings:     map[string]string{"app.tenant_id": "default"},
+			insertTenant: "default",
+
+			expect: []uuid.UUID{uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+		},
+		{
+			name: "Tenant/InsertOther",
+
+			settings:     map[string]string{"app.tenant_id": "default"},
+			insertTenant: "tenant-2",
+
+			expect:          []uuid.UUID{uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+			expectInsertErr: true,
+		},
+		{
+			name: "AllTenants",
+
+			settings: map[string]string{"app.all_tenants": "on"},
+
+			expect: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			},
+		},
+		{
+			name: "NoTenant",
+
+			expect: []uuid.UUID{},
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			ctx := context.Background()
+
+			// Roles are transactional, so the rollback drops it along with the fixtures.
+			_, err := transaction.ExecContext(ctx, "CREATE ROLE credentials_tenant_test NOLOGIN NOBYPASSRLS")
+			require.NoError(t, err)
+			_, err = transaction.ExecContext(ctx, "GRANT SELECT, INSERT ON credentials TO credentials_tenant_test")
+			require.NoError(t, err)
+			_, err = transaction.ExecContext(ctx, "SET LOCAL ROLE credentials_tenant_test")
+			require.NoError(t, err)
+
+			for key, value := range testCase.settings {
+				_, err = transaction.ExecContext(ctx, "SELECT set_config(?, ?, true)", key, value)
+				require.NoError(t, err)
+			}
+
+			ids := make([]uuid.UUID, 0)
+			require.NoError(t, transaction.NewSelect().
+				Model((*entities.Credential)(nil)).
+				Column("id").
+				Order("id ASC").
+				Scan(ctx, &ids))
+			require.Equal(t, testCase.expect, ids)
+
+			if testCase.insertTenant == "" {
+				return
+			}
+
+			_, err = transaction.NewInsert().
+				Model(&entities.Credential{
+					ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					TenantID:  testCase.insertTenant,
+					Email:     "email-2",
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				}).
+				Exec(ctx)
+			require.Equal(t, testCase.expectInsertErr, err != nil, err)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type tokenColumn struct {
+	field     entities.TokenField
+	id        bun.Ident
+	expiresAt bun.Ident
+}
+
+var tokenColumns = []tokenColumn{
+	{
+		field:     entities.TokenFieldEmailValidation,
+		id:        "email_validation_token_id",
+		expiresAt: "email_validation_token_expires_at",
+	},
+	{
+		field:     entities.TokenFieldPendingEmailValidation,
+		id:        "pending_email_validation_token_id",
+		expiresAt: "pending_email_validation_token_expires_at",
+	},
+	{
+		field:     entities.TokenFieldPassword,
+		id:        "password_token_id",
+		expiresAt: "password_token_expires_at",
+	},
+	{
+		field:     entities.TokenFieldResetPassword,
+		id:        "reset_password_token_id",
+		expiresAt: "reset_password_token_expires_at",
+	},
+}
+
+func getTokenColumn(field entities.TokenField) (tokenColumn, bool) {
+	for _, column := range tokenColumns {
+		if column.field == field {
+			return column, true
+		}
+	}
+
+	return tokenColumn{}, false
+}
+
+// tokenValues returns the ID and the expiration date of a token of the credentials.
+func tokenValues(credential *entities.Credential, field entities.TokenField) (string, *time.Time) {
+	switch field {
+	case entities.TokenFieldEmailValidation:
+		return credential.EmailValidationTokenID, credential.EmailValidationTokenExpiresAt
+	case entities.TokenFieldPendingEmailValidation:
+		return credential.PendingEmailValidationTokenID, credential.PendingEmailValidationTokenExpiresAt
+	case entities.TokenFieldPassword:
+		return credential.PasswordTokenID, credential.PasswordTokenExpiresAt
+	case entities.TokenFieldResetPassword:
+		return credential.ResetPasswordTokenID, credential.ResetPasswordTokenExpiresAt
+	default:
+		return "", nil
+	}
+}
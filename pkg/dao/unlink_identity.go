@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type UnlinkIdentityRequest struct {
+	Provider string
+	Subject  string
+}
+
+type UnlinkIdentity interface {
+	Exec(ctx context.Context, credentialID uuid.UUID, now time.Time, request *UnlinkIdentityRequest) error
+}
+
+type unlinkIdentityImpl struct {
+	database bun.IDB
+}
+
+func (dao *unlinkIdentityImpl) Exec(
+	ctx context.Context, credentialID uuid.UUID, now time.Time, request *UnlinkIdentityRequest,
+) error {
+	return runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		// Lock the credentials, so concurrent requests cannot unlink every login method at once.
+		credential := new(entities.Credential)
+
+		err := tx.NewSelect().
+			Model(credential).
+			Where("id = ?", credentialID).
+			Where("tenant_id = ?", tenantID).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("lock credentials: %w", err)
+		}
+
+		identities := make([]*entities.CredentialIdentity, 0)
+
+		err = tx.NewSelect().
+			Model(&identities).
+			Where("credential_id = ?", credentialID).
+			Where("tenant_id = ?", tenantID).
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("list identities: %w", err)
+		}
+
+		linked := false
+		for _, identity := range identities {
+			if identity.Provider == request.Provider && identity.Subject == request.Subject {
+				linked = true
+
+				break
+			}
+		}
+
+		if !linked {
+			return ErrIdentityNotFound
+		}
+
+		credential.ClearExpiredTokens(now)
+		if credential.PasswordTokenID == "" && len(identities) == 1 {
+			return ErrLastLoginMethod
+		}
+
+		_, err = tx.NewDelete().
+			Model((*entities.CredentialIdentity)(nil)).
+			Where("tenant_id = ?", tenantID).
+			Where("provider = ?", request.Provider).
+			Where("subject = ?", request.Subject).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func NewUnlinkIdentity(database bun.IDB) UnlinkIdentity {
+	return &unlinkIdentityImpl{database: database}
+}
@@ -0,0 +1,227 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestUnlinkIdentity(t *testing.T) {
+	fixtures := []interface{}{
+		// Password and one identity.
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:        "default",
+			Email:           "email-1",
+			PasswordTokenID: "password-token-id",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Two identities, no password.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:  "default",
+			Email:     "email-2",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// One identity, expired password.
+		&entities.Credential{
+			ID:                     uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:               "default",
+			Email:                  "email-3",
+			PasswordTokenID:        "password-token-id",
+			PasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "google",
+			Subject:      "subject-1",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "google",
+			Subject:      "subject-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "github",
+			Subject:      "subject-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "google",
+			Subject:      "subject-3",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		credentialID uuid.UUID
+		now          time.Time
+		request      *dao.UnlinkIdentityRequest
+
+		expectErr error
+	}{
+		{
+			name: "Unlink/WithPassword",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.UnlinkIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-1",
+			},
+		},
+		{
+			name: "Unlink/WithOtherIdentity",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now:          time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.UnlinkIdentityRequest{
+				Provider: "github",
+				Subject:  "subject-2",
+			},
+		},
+		{
+			name: "Unlink/WithValidPassword",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now:          time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.UnlinkIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-3",
+			},
+		},
+		{
+			name: "LastLoginMethod/ExpiredPassword",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now:          time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+			request: &dao.UnlinkIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-3",
+			},
+
+			expectErr: dao.ErrLastLoginMethod,
+		},
+		{
+			name: "IdentityOfOtherCredentials",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.UnlinkIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-2",
+			},
+
+			expectErr: dao.ErrIdentityNotFound,
+		},
+		{
+			name: "IdentityNotFound",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.UnlinkIdentityRequest{
+				Provider: "github",
+				Subject:  "subject-1",
+			},
+
+			expectErr: dao.ErrIdentityNotFound,
+		},
+		{
+			name: "CredentialsNotFound",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			now:          time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			request: &dao.UnlinkIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-1",
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			unlinkIdentityDAO := dao.NewUnlinkIdentity(transaction)
+
+			err := unlinkIdentityDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				testCase.credentialID, testCase.now, testCase.request,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+		})
+	}
+}
+
+func TestUnlinkIdentityLastLoginMethod(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "google",
+			Subject:      "subject-1",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialIdentity{
+			TenantID:     "default",
+			Provider:     "github",
+			Subject:      "subject-1",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	transaction := anoveldb.BeginTestTX(database, fixtures)
+	defer anoveldb.RollbackTestTX(transaction)
+
+	unlinkIdentityDAO := dao.NewUnlinkIdentity(transaction)
+
+	ctx := entities.ContextWithTenant(context.Background(), "default")
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	now := time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	err = unlinkIdentityDAO.Exec(ctx, id, now, &dao.UnlinkIdentityRequest{Provider: "google", Subject: "subject-1"})
+	require.NoError(t, err)
+
+	err = unlinkIdentityDAO.Exec(ctx, id, now, &dao.UnlinkIdentityRequest{Provider: "github", Subject: "subject-1"})
+	require.ErrorIs(t, err, dao.ErrLastLoginMethod)
+}
@@ -2,10 +2,13 @@ package dao
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/uptrace/bun"
 
 	"github.com/a-novel/uservice-credentials/pkg/entities"
@@ -14,11 +17,21 @@ import (
 type UpdateCredentialsRequest struct {
 	Email string
 	Role  entities.Role
+	// Labels replace the current labels of the credentials. A nil value leaves them unchanged, while an empty map
+	// removes them.
+	Labels map[string]string
+	// MFARequired replaces the MFA requirement of the credentials. A nil value leaves it unchanged.
+	MFARequired *bool
 
 	EmailValidationTokenID        string
 	PendingEmailValidationTokenID string
 	PasswordTokenID               string
 	ResetPasswordTokenID          string
+
+	EmailValidationTokenExpiresAt        *time.Time
+	PendingEmailValidationTokenExpiresAt *time.Time
+	PasswordTokenExpiresAt               *time.Time
+	ResetPasswordTokenExpiresAt          *time.Time
 }
 
 type UpdateCredentials interface {
@@ -28,7 +41,17 @@ type UpdateCredentials interface {
 }
 
 type updateCredentialsImpl struct {
-	database bun.IDB
+	database   bun.IDB
+	capacities entities.RoleCapacities
+	// privileged roles can only be set through an approved role change request.
+	privileged []entities.Role
+	// protected roles must keep at least one holder.
+	protected []entities.Role
+	// rules are re-evaluated when the email of the credentials changes.
+	rules   entities.RoleRules
+	domains entities.EmailDomainPolicy
+	// uniqueCanonical rejects emails that are a variant of the email of other credentials.
+	uniqueCanonical bool
 }
 
 func (dao *updateCredentialsImpl) Exec(
@@ -37,37 +60,181 @@ func (dao *updateCredentialsImpl) Exec(
 	model := &entities.Credential{
 		ID:                            id,
 		Email:                         data.Email,
+		CanonicalEmail:                entities.CanonicalEmail(data.Email),
 		Role:                          data.Role,
+		Labels:                        data.Labels,
+		MFARequired:                   lo.FromPtr(data.MFARequired),
 		EmailValidationTokenID:        data.EmailValidationTokenID,
 		PendingEmailValidationTokenID: data.PendingEmailValidationTokenID,
 		PasswordTokenID:               data.PasswordTokenID,
 		ResetPasswordTokenID:          data.ResetPasswordTokenID,
-		UpdatedAt:                     &now,
+
+		EmailValidationTokenExpiresAt:        data.EmailValidationTokenExpiresAt,
+		PendingEmailValidationTokenExpiresAt: data.PendingEmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:               data.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:          data.ResetPasswordTokenExpiresAt,
+
+		UpdatedAt: &now,
 	}
 
-	res, err := dao.database.
-		NewUpdate().
-		Model(model).
-		WherePK().
-		ExcludeColumn("id", "created_at").
-		Returning("*").
-		Exec(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("exec query: %w", err)
+	// Service accounts are managed by their owners, and cannot become users. Deletion, erasure and legal holds have
+	// their own lifecycle, that updates must not reset.
+	excludedColumns := []string{
+		"id", "created_at", "kind", "name", "owner_id", "deletion_scheduled_at", "erased_at",
+		"legal_hold", "legal_hold_reason", "legal_hold_set_by", "legal_hold_set_at", "deleted_at",
+	}
+	if data.Labels == nil {
+		excludedColumns = append(excludedColumns, "labels")
+	} else if len(data.Labels) == 0 {
+		// Store removed labels as NULL rather than an empty object.
+		model.Labels = nil
 	}
 
-	rows, err := res.RowsAffected()
-	if err != nil {
-		return nil, fmt.Errorf("get rows affected: %w", err)
+	if data.MFARequired == nil {
+		excludedColumns = append(excludedColumns, "mfa_required")
 	}
 
-	if rows == 0 {
-		return nil, ErrCredentialsNotFound
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		current := new(entities.Credential)
+
+		err := tx.NewSelect().
+			Model(current).
+			Column("id", "kind", "email", "canonical_email", "role", "fallback_role", "legal_hold").
+			Where("id = ?", id).
+			Where("tenant_id = ?", tenantID).
+			Where("kind = ?", entities.CredentialKindUser).
+			// Erased and merged credentials are tombstones, and cannot be updated anymore.
+			Where("erased_at IS NULL").
+			Where("deleted_at IS NULL").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrCredentialsNotFound
+			}
+
+			return fmt.Errorf("select credentials: %w", err)
+		}
+
+		// Credentials on legal hold keep their email. Other updates, such as password resets, remain allowed.
+		if current.LegalHold && current.Email != model.Email {
+			return ErrCredentialsOnLegalHold
+		}
+
+		if current.Email != model.Email {
+			if err = checkEmailDomain(ctx, tx, tenantID, dao.domains, model.Email); err != nil {
+				return err
+			}
+		}
+
+		if current.CanonicalEmail != model.CanonicalEmail {
+			err = checkCanonicalEmail(ctx, tx, tenantID, dao.uniqueCanonical, id, model.CanonicalEmail)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Rules only apply when the caller keeps the current role. The new email is not verified yet, so it cannot
+		// grant a privileged role on its own.
+		if current.Email != model.Email && current.Role == model.Role {
+			role := dao.rules.Reassign(current.Role, current.Email, model.Email)
+			if !lo.Contains(dao.privileged, role) {
+				model.Role = role
+			}
+		}
+
+		// Setting another role ends any temporary grant, which frees the seat of its fallback role.
+		if current.Role != model.Role {
+			if lo.Contains(dao.privileged, model.Role) {
+				return ErrPrivilegedRoleChange
+			}
+
+			if permanentRole(current) != model.Role {
+				if err = checkLastRoleHolder(ctx, tx, tenantID, dao.protected, current); err != nil {
+					return err
+				}
+			}
+
+			err = reassignSeats(
+				ctx, tx, tenantID, dao.capacities, now, heldRoles(current), []entities.Role{model.Role},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		query := tx.
+			NewUpdate().
+			Model(model).
+			WherePK().
+			Where("tenant_id = ?", tenantID).
+			ExcludeColumn(append(excludedColumns, "tenant_id")...).
+			// Sessions issued before a change of email or password are no longer valid.
+			Value(
+				"token_epoch",
+				// Consumed and swept tokens are NULL, while the request holds an empty string for them.
+				"CASE WHEN email IS DISTINCT FROM ? OR COALESCE(password_token_id, '') IS DISTINCT FROM ? "+
+					"THEN token_epoch + 1 ELSE token_epoch END",
+				model.Email, model.PasswordTokenID,
+			).
+			// A new email must be verified again.
+			Value(
+				"email_verified_at",
+				"CASE WHEN email IS DISTINCT FROM ? THEN NULL ELSE email_verified_at END",
+				model.Email,
+			).
+			// Setting another role ends any temporary grant, so the new role is not reverted later.
+			Value(
+				"role_expires_at",
+				"CASE WHEN role IS DISTINCT FROM ? THEN NULL ELSE role_expires_at END",
+				model.Role,
+			).
+			Value(
+				"fallback_role",
+				"CASE WHEN role IS DISTINCT FROM ? THEN NULL ELSE fallback_role END",
+				model.Role,
+			)
+
+		// Token IDs sent back unchanged keep their expiration, unless a new one is given. Callers that cannot carry
+		// expiration dates, such as the gRPC handlers, would otherwise clear them on every update.
+		for _, column := range tokenColumns {
+			id, expiresAt := tokenValues(model, column.field)
+			query = query.Value(
+				string(column.expiresAt),
+				"COALESCE(?, CASE WHEN ? = ? THEN ? END)",
+				expiresAt, column.id, id, column.expiresAt,
+			)
+		}
+
+		_, err = query.Returning("*").Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return model, nil
 }
 
-func NewUpdateCredentials(database bun.IDB) UpdateCredentials {
-	return &updateCredentialsImpl{database: database}
+func NewUpdateCredentials(
+	database bun.IDB,
+	capacities entities.RoleCapacities,
+	privileged, protected []entities.Role,
+	rules entities.RoleRules,
+	domains entities.EmailDomainPolicy,
+	uniqueCanonical bool,
+) UpdateCredentials {
+	return &updateCredentialsImpl{
+		database:        database,
+		capacities:      capacities,
+		privileged:      privileged,
+		protected:       protected,
+		rules:           rules,
+		domains:         domains,
+		uniqueCanonical: uniqueCanonical,
+	}
 }
@@ -20,26 +20,113 @@ func TestUpdateCredentials(t *testing.T) {
 	fixtures := []interface{}{
 		&entities.Credential{
 			ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:                      "default",
+			MFARequired:                   true,
 			Email:                         "email-1",
+			EmailVerifiedAt:               lo.ToPtr(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)),
 			Role:                          entities.RoleCore,
+			Labels:                        map[string]string{"source": "ads"},
 			EmailValidationTokenID:        "email-validation-token-id",
 			PendingEmailValidationTokenID: "pending-email-validation-token-id",
 			PasswordTokenID:               "password-token-id",
 			ResetPasswordTokenID:          "reset-password-token-id",
+			PasswordTokenExpiresAt:        lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
 			CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 			UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
 		},
+		&entities.Credential{
+			ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			TenantID:            "default",
+			Email:               "email-scheduled",
+			DeletionScheduledAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:            uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			TenantID:      "default",
+			Email:         "email-granted",
+			Role:          entities.RoleAdmin,
+			RoleExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+			CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			TenantID:  "default",
+			Email:     "email-waitlisted",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:             uuid.MustParse("00000000-0000-0000-0000-000000000005"),
+			TenantID:       "default",
+			Email:          "John.Doe@gmail.com",
+			CanonicalEmail: "johndoe@gmail.com",
+			CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.RoleWaitlistEntry{
+			ID:           uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+			Role:         entities.RoleEarlyAccessProgram,
+			EnqueuedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			TenantID:  "default",
+			Kind:      entities.CredentialKindService,
+			Name:      "service-1",
+			OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000011"),
+			TenantID:  "default",
+			Email:     "erased-0011@erased.invalid",
+			ErasedAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:              uuid.MustParse("00000000-0000-0000-0000-000000000012"),
+			TenantID:        "default",
+			Email:           "email-held",
+			CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			LegalHold:       true,
+			LegalHoldReason: "dispute",
+			LegalHoldSetBy:  "legal@example.com",
+			LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+		},
+		// The password token of these credentials was consumed. See the test loop.
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000013"),
+			TenantID:  "default",
+			Email:     "email-consumed",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			Role:      entities.RoleCore,
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	testCases := []struct {
 		name string
 
-		id   uuid.UUID
-		now  time.Time
-		data *dao.UpdateCredentialsRequest
+		id         uuid.UUID
+		now        time.Time
+		data       *dao.UpdateCredentialsRequest
+		privileged []entities.Role
+		protected  []entities.Role
+		rules      entities.RoleRules
+		domains    entities.EmailDomainPolicy
+		// uniqueCanonical enforces the uniqueness of canonical emails.
+		uniqueCanonical bool
 
-		expect    *entities.Credential
-		expectErr error
+		expect         *entities.Credential
+		expectErr      error
+		expectPromoted bool
 	}{
 		{
 			name: "Update",
@@ -49,6 +136,7 @@ func TestUpdateCredentials(t *testing.T) {
 			data: &dao.UpdateCredentialsRequest{
 				Email:                         "email-2",
 				Role:                          entities.RoleAdmin,
+				Labels:                        map[string]string{"source": "ads"},
 				EmailValidationTokenID:        "new-email-validation-token-id",
 				PendingEmailValidationTokenID: "new-pending-email-validation-token-id",
 				PasswordTokenID:               "new-password-token-id",
@@ -57,7 +145,11 @@ func TestUpdateCredentials(t *testing.T) {
 
 			expect: &entities.Credential{
 				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                      "default",
+				TokenEpoch:                    1,
+				MFARequired:                   true,
 				Email:                         "email-2",
+				CanonicalEmail:                "email-2",
 				Role:                          entities.RoleAdmin,
 				EmailValidationTokenID:        "new-email-validation-token-id",
 				PendingEmailValidationTokenID: "new-pending-email-validation-token-id",
@@ -67,6 +159,37 @@ func TestUpdateCredentials(t *testing.T) {
 				UpdatedAt:                     lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			name: "Update/TokensExpiration",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email:                                "email-2",
+				Role:                                 entities.RoleAdmin,
+				Labels:                               map[string]string{"source": "ads"},
+				PendingEmailValidationTokenID:        "new-pending-email-validation-token-id",
+				ResetPasswordTokenID:                 "new-reset-password-token-id",
+				PendingEmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				ResetPasswordTokenExpiresAt:          lo.ToPtr(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &entities.Credential{
+				ID:                                   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:                             "default",
+				TokenEpoch:                           1,
+				MFARequired:                          true,
+				Email:                                "email-2",
+				CanonicalEmail:                       "email-2",
+				Role:                                 entities.RoleAdmin,
+				PendingEmailValidationTokenID:        "new-pending-email-validation-token-id",
+				ResetPasswordTokenID:                 "new-reset-password-token-id",
+				PendingEmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 2, 2, 0, 0, 0, 0, time.UTC)),
+				ResetPasswordTokenExpiresAt:          lo.ToPtr(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                            lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
 		{
 			name: "Update/Clear",
 
@@ -78,8 +201,154 @@ func TestUpdateCredentials(t *testing.T) {
 			},
 
 			expect: &entities.Credential{
-				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
-				Email:     "email-2",
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:       "default",
+				TokenEpoch:     1,
+				MFARequired:    true,
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleAdmin,
+				Labels:         map[string]string{"source": "ads"},
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Update/Labels",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email:  "email-2",
+				Role:   entities.RoleAdmin,
+				Labels: map[string]string{"campaign": "spring"},
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:       "default",
+				TokenEpoch:     1,
+				MFARequired:    true,
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleAdmin,
+				Labels:         map[string]string{"campaign": "spring"},
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Update/Labels/Clear",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email:  "email-2",
+				Role:   entities.RoleAdmin,
+				Labels: map[string]string{},
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:       "default",
+				TokenEpoch:     1,
+				MFARequired:    true,
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleAdmin,
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Update/MFARequired",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email:       "email-2",
+				Role:        entities.RoleAdmin,
+				MFARequired: lo.ToPtr(false),
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:       "default",
+				TokenEpoch:     1,
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleAdmin,
+				Labels:         map[string]string{"source": "ads"},
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Update/KeepEmailAndPassword",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email:           "email-1",
+				Role:            entities.RoleAdmin,
+				PasswordTokenID: "password-token-id",
+			},
+
+			expect: &entities.Credential{
+				ID:                     uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:               "default",
+				MFARequired:            true,
+				Email:                  "email-1",
+				CanonicalEmail:         "email-1",
+				EmailVerifiedAt:        lo.ToPtr(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)),
+				Role:                   entities.RoleAdmin,
+				Labels:                 map[string]string{"source": "ads"},
+				PasswordTokenID:        "password-token-id",
+				PasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:              lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Update/ChangePassword",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email:           "email-1",
+				Role:            entities.RoleAdmin,
+				PasswordTokenID: "new-password-token-id",
+			},
+
+			expect: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:        "default",
+				TokenEpoch:      1,
+				MFARequired:     true,
+				Email:           "email-1",
+				CanonicalEmail:  "email-1",
+				EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)),
+				Role:            entities.RoleAdmin,
+				Labels:          map[string]string{"source": "ads"},
+				PasswordTokenID: "new-password-token-id",
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Update/ConsumedPassword",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000013"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-consumed",
+				Role:  entities.RoleAdmin,
+			},
+
+			expect: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000013"),
+				TenantID:  "default",
+				Email:     "email-consumed",
 				Role:      entities.RoleAdmin,
 				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
@@ -99,6 +368,378 @@ func TestUpdateCredentials(t *testing.T) {
 				ResetPasswordTokenID:          "new-reset-password-token-id",
 			},
 
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "KeepScheduledDeletion",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-scheduled",
+				Role:  entities.RoleAdmin,
+			},
+
+			expect: &entities.Credential{
+				ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:            "default",
+				Email:               "email-scheduled",
+				CanonicalEmail:      "email-scheduled",
+				Role:                entities.RoleAdmin,
+				DeletionScheduledAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:           lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "KeepRoleGrant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-granted",
+				Role:  entities.RoleAdmin,
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				TenantID:       "default",
+				Email:          "email-granted",
+				CanonicalEmail: "email-granted",
+				Role:           entities.RoleAdmin,
+				RoleExpiresAt:  lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:   lo.ToPtr(entities.RoleEarlyAccessProgram),
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "ReplaceRoleGrant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-granted",
+				Role:  entities.RoleCore,
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				TenantID:       "default",
+				Email:          "email-granted",
+				CanonicalEmail: "email-granted",
+				Role:           entities.RoleCore,
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			expectPromoted: true,
+		},
+		{
+			name: "RoleCapacityReached",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-1",
+				Role:  entities.RoleEarlyAccessProgram,
+			},
+
+			expectErr: dao.ErrRoleCapacityReached,
+		},
+		{
+			name: "PrivilegedRole",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-scheduled",
+				Role:  entities.RoleAdmin,
+			},
+			privileged: []entities.Role{entities.RoleAdmin, entities.RoleCore},
+
+			expectErr: dao.ErrPrivilegedRoleChange,
+		},
+		{
+			name: "PrivilegedRole/Unchanged",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-2",
+				Role:  entities.RoleCore,
+			},
+			privileged: []entities.Role{entities.RoleAdmin, entities.RoleCore},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:       "default",
+				TokenEpoch:     1,
+				MFARequired:    true,
+				Email:          "email-2",
+				CanonicalEmail: "email-2",
+				Role:           entities.RoleCore,
+				Labels:         map[string]string{"source": "ads"},
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "LastProtectedRoleHolder",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-1",
+				Role:  entities.RoleAdmin,
+			},
+			protected: []entities.Role{entities.RoleCore},
+
+			expectErr: dao.ErrLastProtectedRoleHolder,
+		},
+		{
+			// A temporary grant does not make the credentials a holder of the role.
+			name: "LastProtectedRoleHolder/RoleGrant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-granted",
+				Role:  entities.RoleCore,
+			},
+			protected: []entities.Role{entities.RoleAdmin},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				TenantID:       "default",
+				Email:          "email-granted",
+				CanonicalEmail: "email-granted",
+				Role:           entities.RoleCore,
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			expectPromoted: true,
+		},
+		{
+			name: "RoleRules/Promote",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "jane@staff.example.com",
+			},
+			rules: entities.RoleRules{
+				Rules: []entities.RoleRule{{Domain: "staff.example.com", Role: entities.RoleAdmin}},
+			},
+
+			expect: &entities.Credential{
+				ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:            "default",
+				TokenEpoch:          1,
+				Email:               "jane@staff.example.com",
+				CanonicalEmail:      "jane@staff.example.com",
+				Role:                entities.RoleAdmin,
+				DeletionScheduledAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:           lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "RoleRules/Privileged",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "jane@staff.example.com",
+			},
+			privileged: []entities.Role{entities.RoleAdmin, entities.RoleCore},
+			rules: entities.RoleRules{
+				Rules: []entities.RoleRule{{Domain: "staff.example.com", Role: entities.RoleAdmin}},
+			},
+
+			expect: &entities.Credential{
+				ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				TenantID:            "default",
+				TokenEpoch:          1,
+				Email:               "jane@staff.example.com",
+				CanonicalEmail:      "jane@staff.example.com",
+				DeletionScheduledAt: lo.ToPtr(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:           lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "RoleRules/DemoteOnLeave",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "jane@example.com",
+				Role:  entities.RoleCore,
+			},
+			rules: entities.RoleRules{
+				Rules:         []entities.RoleRule{lo.Must(entities.NewRoleRule("", `^email-\d+$`, entities.RoleCore))},
+				DemoteOnLeave: true,
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:       "default",
+				TokenEpoch:     1,
+				MFARequired:    true,
+				Email:          "jane@example.com",
+				CanonicalEmail: "jane@example.com",
+				Labels:         map[string]string{"source": "ads"},
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "EmailDomainNotAllowed",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "jane@yopmail.com",
+				Role:  entities.RoleCore,
+			},
+			domains: entities.EmailDomainPolicy{BlockDisposable: true},
+
+			expectErr: dao.ErrEmailDomainNotAllowed,
+		},
+		{
+			// Existing emails are not checked again.
+			name: "EmailDomainNotAllowed/Unchanged",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-1",
+				Role:  entities.RoleCore,
+			},
+			domains: entities.EmailDomainPolicy{AllowlistOnly: true},
+
+			// The password token is cleared, which still invalidates the sessions.
+			expect: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:        "default",
+				TokenEpoch:      1,
+				MFARequired:     true,
+				Email:           "email-1",
+				CanonicalEmail:  "email-1",
+				EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)),
+				Role:            entities.RoleCore,
+				Labels:          map[string]string{"source": "ads"},
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "CanonicalEmailTaken",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "johndoe+work@googlemail.com",
+				Role:  entities.RoleCore,
+			},
+			uniqueCanonical: true,
+
+			expectErr: dao.ErrCanonicalEmailTaken,
+		},
+		{
+			name: "CanonicalEmailTaken/NotEnforced",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "johndoe+work@googlemail.com",
+				Role:  entities.RoleCore,
+			},
+
+			expect: &entities.Credential{
+				ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TenantID:       "default",
+				TokenEpoch:     1,
+				MFARequired:    true,
+				Email:          "johndoe+work@googlemail.com",
+				CanonicalEmail: "johndoe@gmail.com",
+				Role:           entities.RoleCore,
+				Labels:         map[string]string{"source": "ads"},
+				CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:      lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "ServiceAccount",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-service",
+				Role:  entities.RoleAdmin,
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "Erased",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000011"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-erased",
+				Role:  entities.RoleAdmin,
+			},
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			// Credentials on legal hold can still be updated, as long as they keep their email.
+			name: "LegalHold",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000012"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email:           "email-held",
+				PasswordTokenID: "new-password-token-id",
+			},
+
+			expect: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000012"),
+				TenantID:        "default",
+				TokenEpoch:      1,
+				Email:           "email-held",
+				CanonicalEmail:  "email-held",
+				PasswordTokenID: "new-password-token-id",
+				LegalHold:       true,
+				LegalHoldReason: "dispute",
+				LegalHoldSetBy:  "legal@example.com",
+				LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "LegalHold/ChangeEmail",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000012"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-held-2",
+			},
+
+			expectErr: dao.ErrCredentialsOnLegalHold,
+		},
+		{
+			name: "OtherTenant",
+
+			id:  uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			data: &dao.UpdateCredentialsRequest{
+				Email: "email-other",
+				Role:  entities.RoleAdmin,
+			},
+
 			expectErr: dao.ErrCredentialsNotFound,
 		},
 	}
@@ -112,12 +753,36 @@ func TestUpdateCredentials(t *testing.T) {
 			transaction := anoveldb.BeginTestTX(database, fixtures)
 			defer anoveldb.RollbackTestTX(transaction)
 
-			updateCredentialsDAO := dao.NewUpdateCredentials(transaction)
+			// Fixtures store empty token IDs as empty strings, while consumed tokens are NULL.
+			_, err := transaction.NewUpdate().
+				Model((*entities.Credential)(nil)).
+				Set("password_token_id = NULL").
+				Where("id = ?", uuid.MustParse("00000000-0000-0000-0000-000000000013")).
+				Exec(context.Background())
+			require.NoError(t, err)
 
-			beat, err := updateCredentialsDAO.Exec(context.Background(), testCase.id, testCase.now, testCase.data)
+			updateCredentialsDAO := dao.NewUpdateCredentials(
+				transaction,
+				entities.RoleCapacities{entities.RoleEarlyAccessProgram: 1},
+				testCase.privileged,
+				testCase.protected,
+				testCase.rules,
+				testCase.domains,
+				testCase.uniqueCanonical,
+			)
+
+			ctx := entities.ContextWithTenant(context.Background(), "default")
+			beat, err := updateCredentialsDAO.Exec(ctx, testCase.id, testCase.now, testCase.data)
 
 			require.ErrorIs(t, err, testCase.expectErr)
 			require.Equal(t, testCase.expect, beat)
+
+			waitlisted := new(entities.Credential)
+			require.NoError(t, transaction.NewSelect().
+				Model(waitlisted).
+				Where("id = ?", uuid.MustParse("00000000-0000-0000-0000-000000000004")).
+				Scan(ctx))
+			require.Equal(t, testCase.expectPromoted, waitlisted.Role == entities.RoleEarlyAccessProgram)
 		})
 	}
 }
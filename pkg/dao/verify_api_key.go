@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type VerifyAPIKeyRequest struct {
+	Prefix     string
+	SecretHash []byte
+}
+
+type VerifyAPIKey interface {
+	Exec(ctx context.Context, now time.Time, request *VerifyAPIKeyRequest) (*entities.APIKey, error)
+}
+
+type verifyAPIKeyImpl struct {
+	database bun.IDB
+}
+
+// Exec checks the API key is active, and records its usage. Every failure is reported as entities.ErrInvalidAPIKey,
+// so callers cannot tell a wrong key from a revoked or expired one.
+func (dao *verifyAPIKeyImpl) Exec(
+	ctx context.Context, now time.Time, request *VerifyAPIKeyRequest,
+) (*entities.APIKey, error) {
+	model := new(entities.APIKey)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.NewSelect().
+			Model(model).
+			Where("prefix = ?", request.Prefix).
+			Where("tenant_id = ?", tenantID).
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entities.ErrInvalidAPIKey
+			}
+
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		if subtle.ConstantTimeCompare(model.SecretHash, request.SecretHash) != 1 {
+			return entities.ErrInvalidAPIKey
+		}
+
+		if model.RevokedAt != nil || (model.ExpiresAt != nil && !model.ExpiresAt.After(now)) {
+			return entities.ErrInvalidAPIKey
+		}
+
+		_, err = tx.NewUpdate().
+			Model(model).
+			Set("last_used_at = ?", now).
+			WherePK().
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("record usage: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func NewVerifyAPIKey(database bun.IDB) VerifyAPIKey {
+	return &verifyAPIKeyImpl{database: database}
+}
@@ -0,0 +1,60 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+type WithdrawConsent interface {
+	Exec(
+		ctx context.Context, credentialID uuid.UUID, now time.Time, document entities.ConsentDocument,
+	) ([]*entities.CredentialConsent, error)
+}
+
+type withdrawConsentImpl struct {
+	database bun.IDB
+}
+
+// Exec withdraws the consent of the credentials to every accepted version of a document, and returns the withdrawn
+// records.
+func (dao *withdrawConsentImpl) Exec(
+	ctx context.Context, credentialID uuid.UUID, now time.Time, document entities.ConsentDocument,
+) ([]*entities.CredentialConsent, error) {
+	consents := make([]*entities.CredentialConsent, 0)
+
+	err := runInTenant(ctx, dao.database, func(ctx context.Context, tx bun.Tx, tenantID string) error {
+		err := tx.NewUpdate().
+			Model(&consents).
+			Set("withdrawn_at = ?", now).
+			Where("credential_id = ?", credentialID).
+			Where("tenant_id = ?", tenantID).
+			Where("document = ?", document).
+			Where("withdrawn_at IS NULL").
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("exec query: %w", err)
+		}
+
+		if len(consents) == 0 {
+			return ErrConsentNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consents, nil
+}
+
+func NewWithdrawConsent(database bun.IDB) WithdrawConsent {
+	return &withdrawConsentImpl{database: database}
+}
@@ -0,0 +1,138 @@
+package dao_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	anoveldb "github.com/a-novel/golib/database"
+
+	"github.com/a-novel/uservice-credentials/migrations"
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestWithdrawConsent(t *testing.T) {
+	fixtures := []interface{}{
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			TenantID:  "default",
+			Email:     "email-1",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.Credential{
+			ID:        uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			TenantID:  "tenant-2",
+			Email:     "email-other",
+			CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Document:     entities.ConsentDocumentMarketing,
+			Version:      "v1",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000002"),
+			TenantID:     "default",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Document:     entities.ConsentDocumentTOS,
+			Version:      "v1",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			WithdrawnAt:  lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+		},
+		&entities.CredentialConsent{
+			ID:           uuid.MustParse("00000000-0000-0000-0001-000000000099"),
+			TenantID:     "tenant-2",
+			CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			Document:     entities.ConsentDocumentMarketing,
+			Version:      "v1",
+			AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		credentialID uuid.UUID
+		now          time.Time
+		document     entities.ConsentDocument
+
+		expect    []*entities.CredentialConsent
+		expectErr error
+	}{
+		{
+			name: "Withdraw",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			document:     entities.ConsentDocumentMarketing,
+
+			expect: []*entities.CredentialConsent{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Document:     entities.ConsentDocumentMarketing,
+					Version:      "v1",
+					AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					WithdrawnAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "AlreadyWithdrawn",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			document:     entities.ConsentDocumentTOS,
+
+			expectErr: dao.ErrConsentNotFound,
+		},
+		{
+			name: "NotAccepted",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			document:     entities.ConsentDocumentPrivacy,
+
+			expectErr: dao.ErrConsentNotFound,
+		},
+		{
+			name: "OtherTenant",
+
+			credentialID: uuid.MustParse("00000000-0000-0000-0000-000000000099"),
+			now:          time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			document:     entities.ConsentDocumentMarketing,
+
+			expectErr: dao.ErrConsentNotFound,
+		},
+	}
+
+	database, closer, err := anoveldb.OpenTestDB(&migrations.SQLMigrations)
+	require.NoError(t, err)
+	defer closer()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction := anoveldb.BeginTestTX(database, fixtures)
+			defer anoveldb.RollbackTestTX(transaction)
+
+			withdrawConsentDAO := dao.NewWithdrawConsent(transaction)
+
+			consents, err := withdrawConsentDAO.Exec(
+				entities.ContextWithTenant(context.Background(), "default"),
+				testCase.credentialID, testCase.now, testCase.document,
+			)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, consents)
+		})
+	}
+}
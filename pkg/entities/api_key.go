@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/golib/database"
+)
+
+const (
+	// APIKeyTag starts every API key, so leaked keys are easy to spot by secret scanners.
+	APIKeyTag = "ak"
+
+	apiKeyPrefixSize = 10
+	apiKeySecretSize = 32
+)
+
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// Lowercase base32, without padding, so prefixes are URL safe and easy to read out.
+var apiKeyPrefixEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+var apiKeyScopeRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9:._-]*[a-z0-9])?$`)
+
+// APIKey authenticates a service account. The secret part of the key is never stored: only its hash is kept, and
+// the prefix is used to look the key up.
+type APIKey struct {
+	bun.BaseModel `bun:"table:credential_api_keys,alias:credential_api_keys"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+
+	Prefix     string   `bun:"prefix"`
+	SecretHash []byte   `bun:"secret_hash"`
+	Scopes     []string `bun:"scopes,array,nullzero"`
+
+	ExpiresAt  *time.Time `bun:"expires_at"`
+	CreatedAt  time.Time  `bun:"created_at"`
+	LastUsedAt *time.Time `bun:"last_used_at"`
+	RevokedAt  *time.Time `bun:"revoked_at"`
+}
+
+// GenerateAPIKey returns a new random API key, in the form "ak_<prefix>_<secret>", along with its prefix and hash.
+// The key itself must be handed to the caller once, and never stored.
+func GenerateAPIKey() (key string, prefix string, hash []byte, err error) {
+	rawPrefix := make([]byte, apiKeyPrefixSize)
+	if _, err = rand.Read(rawPrefix); err != nil {
+		return "", "", nil, fmt.Errorf("generate prefix: %w", err)
+	}
+
+	rawSecret := make([]byte, apiKeySecretSize)
+	if _, err = rand.Read(rawSecret); err != nil {
+		return "", "", nil, fmt.Errorf("generate secret: %w", err)
+	}
+
+	prefix = apiKeyPrefixEncoding.EncodeToString(rawPrefix)
+	key = APIKeyTag + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(rawSecret)
+
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// ParseAPIKey extracts the prefix of an API key.
+func ParseAPIKey(key string) (string, error) {
+	// The secret is base64 (URL) encoded, and may contain underscores.
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != APIKeyTag || parts[1] == "" || parts[2] == "" {
+		return "", ErrInvalidAPIKey
+	}
+
+	return parts[1], nil
+}
+
+// HashAPIKey hashes the whole API key. Keys carry enough entropy for a fast hash to be safe.
+func HashAPIKey(key string) []byte {
+	hash := sha256.Sum256([]byte(key))
+
+	return hash[:]
+}
+
+func RegisterAPIKeyScope(customValidator *validator.Validate) {
+	database.MustRegisterValidation(customValidator, "api_key_scope", func(fl validator.FieldLevel) bool {
+		return apiKeyScopeRegexp.MatchString(fl.Field().String())
+	})
+}
@@ -0,0 +1,55 @@
+package entities
+
+import "strings"
+
+// emailProvider describes how a mailbox provider routes variants of an address to the same inbox.
+type emailProvider struct {
+	// Domain is the primary domain of the provider, that its alias domains are rewritten to.
+	Domain string
+	// IgnoreDots is set for providers that ignore dots in the local part of the address.
+	IgnoreDots bool
+}
+
+var emailProviders = map[string]emailProvider{
+	"gmail.com":      {Domain: "gmail.com", IgnoreDots: true},
+	"googlemail.com": {Domain: "gmail.com", IgnoreDots: true},
+}
+
+// CanonicalEmail returns the mailbox an email is delivered to, so credentials registered with variants of the same
+// address can be detected. The email is normalized with NormalizeEmail, then lowercased and stripped of its
+// plus-tag. Providers that ignore dots in the local part also have them removed. An empty email has no canonical
+// form.
+func CanonicalEmail(email string) string {
+	if email == "" {
+		return ""
+	}
+
+	local, domain, err := ParseEmail(email)
+	if err != nil {
+		// Invalid emails are only lowercased, so the canonical form is still stable.
+		return strings.ToLower(email)
+	}
+
+	local = strings.ToLower(local)
+
+	if tagged, _, ok := strings.Cut(local, "+"); ok && tagged != "" {
+		local = tagged
+	}
+
+	if provider, ok := emailProviders[domain]; ok {
+		domain = provider.Domain
+
+		if provider.IgnoreDots {
+			local = strings.ReplaceAll(local, ".", "")
+		}
+	}
+
+	return local + "@" + domain
+}
+
+// CanonicalEmailCluster groups the credentials of a tenant that share the same canonical email.
+type CanonicalEmailCluster struct {
+	CanonicalEmail string
+	// Credentials are sorted from the oldest to the newest.
+	Credentials []*Credential
+}
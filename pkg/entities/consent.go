@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/golib/database"
+)
+
+type ConsentDocument string
+
+const (
+	ConsentDocumentTOS       ConsentDocument = "tos"
+	ConsentDocumentPrivacy   ConsentDocument = "privacy"
+	ConsentDocumentMarketing ConsentDocument = "marketing"
+)
+
+func RegisterConsentDocument(customValidator *validator.Validate) {
+	database.MustRegisterValidation(
+		customValidator, "consent_document",
+		database.ValidateEnum(ConsentDocumentTOS, ConsentDocumentPrivacy, ConsentDocumentMarketing),
+	)
+}
+
+// CredentialConsent records the acceptance of a version of a document by the owner of a credential. Records are
+// never deleted: withdrawing a consent sets WithdrawnAt, so the history of acceptances can always be proven.
+type CredentialConsent struct {
+	bun.BaseModel `bun:"table:credential_consents,alias:credential_consents"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+
+	Document ConsentDocument `bun:"document"`
+	// Version is an opaque identifier of the accepted version of the document.
+	Version string `bun:"version"`
+
+	AcceptedAt  time.Time  `bun:"accepted_at"`
+	WithdrawnAt *time.Time `bun:"withdrawn_at"`
+}
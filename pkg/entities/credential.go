@@ -21,25 +21,196 @@ import (
 var (
 	ErrUnknownRole         = errors.New("unknown value for credentials_role")
 	ErrUnsupportedRoleType = errors.New("unsupported type for credentials_role")
+	ErrUnknownTokenField   = errors.New("unknown token field")
+	ErrUnknownKind         = errors.New("unknown value for credentials_kind")
+	ErrUnsupportedKindType = errors.New("unsupported type for credentials_kind")
 )
 
 type Credential struct {
 	bun.BaseModel `bun:"table:credentials,alias:credentials"`
 
-	ID uuid.UUID `bun:"id,pk,type:uuid"`
+	ID       uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID string    `bun:"tenant_id"`
 
-	Email string `bun:"email"`
-	Role  Role   `bun:"role,type:credentials_role"`
+	Kind CredentialKind `bun:"kind,type:credentials_kind"`
+
+	// Email is only set for user credentials.
+	Email string `bun:"email,nullzero"`
+	// CanonicalEmail identifies the mailbox the email is delivered to, to detect duplicate users. See CanonicalEmail.
+	CanonicalEmail string `bun:"canonical_email,nullzero"`
+	Role           Role   `bun:"role,type:credentials_role"`
+	// RoleExpiresAt is set when Role is only granted for a limited time. Once it expires, the credentials revert to
+	// FallbackRole. Both are set together, or not at all.
+	RoleExpiresAt *time.Time `bun:"role_expires_at"`
+	FallbackRole  *Role      `bun:"fallback_role,type:credentials_role"`
+
+	// EmailVerifiedAt is set once the owner of the credentials has proven they own the email. It is reset when the
+	// email changes.
+	EmailVerifiedAt *time.Time `bun:"email_verified_at"`
+
+	// Name and OwnerID are only set for service accounts. The owner is the user credential responsible for the
+	// service account.
+	Name    string    `bun:"name,nullzero"`
+	OwnerID uuid.UUID `bun:"owner_id,type:uuid,nullzero"`
+
+	Labels map[string]string `bun:"labels,type:jsonb,nullzero"`
+
+	// MFARequired tells the authentication service to require a second factor when signing in.
+	MFARequired bool `bun:"mfa_required"`
+	// TokenEpoch is embedded in every session and token issued for the credentials. Bumping it invalidates all of
+	// them at once.
+	TokenEpoch int64 `bun:"token_epoch"`
 
 	EmailValidationTokenID        string `bun:"email_validation_token_id"`
 	PendingEmailValidationTokenID string `bun:"pending_email_validation_token_id"`
 	PasswordTokenID               string `bun:"password_token_id"`
 	ResetPasswordTokenID          string `bun:"reset_password_token_id"`
 
+	EmailValidationTokenExpiresAt        *time.Time `bun:"email_validation_token_expires_at"`
+	PendingEmailValidationTokenExpiresAt *time.Time `bun:"pending_email_validation_token_expires_at"`
+	PasswordTokenExpiresAt               *time.Time `bun:"password_token_expires_at"`
+	ResetPasswordTokenExpiresAt          *time.Time `bun:"reset_password_token_expires_at"`
+
+	// DeletionScheduledAt is set while the credentials are pending deletion. They are permanently erased once this
+	// date is due, unless the deletion is canceled.
+	DeletionScheduledAt *time.Time `bun:"deletion_scheduled_at"`
+	// ErasedAt is set once the personal data of the credentials has been erased. Erased credentials are kept as
+	// tombstones, so other services can still resolve their ID.
+	ErasedAt *time.Time `bun:"erased_at"`
+
+	// LegalHold freezes the credentials during a dispute: they cannot be deleted, nor have their email changed, until
+	// the hold is released. The reason and the identity of whoever set the hold are kept along with it.
+	LegalHold       bool       `bun:"legal_hold"`
+	LegalHoldReason string     `bun:"legal_hold_reason,nullzero"`
+	LegalHoldSetBy  string     `bun:"legal_hold_set_by,nullzero"`
+	LegalHoldSetAt  *time.Time `bun:"legal_hold_set_at"`
+
+	// DeletedAt is set on credentials merged into others. Deleted credentials are kept so their ID and email
+	// redirect to the surviving credentials.
+	DeletedAt *time.Time `bun:"deleted_at"`
+
+	// HasNotes tells whether agents wrote internal notes about the credentials. It is computed on read, and only
+	// populated by queries that select it.
+	HasNotes bool `bun:"has_notes,scanonly"`
+
 	CreatedAt time.Time  `bun:"created_at"`
 	UpdatedAt *time.Time `bun:"updated_at"`
 }
 
+// ClearExpiredTokens removes every token ID whose expiration date is due at the given time. Expired tokens may
+// remain in the database until they are swept, so they must be cleared on read to be treated as absent.
+func (credential *Credential) ClearExpiredTokens(now time.Time) {
+	expired := func(expiresAt *time.Time) bool {
+		return expiresAt != nil && !expiresAt.After(now)
+	}
+
+	if expired(credential.EmailValidationTokenExpiresAt) {
+		credential.EmailValidationTokenID = ""
+		credential.EmailValidationTokenExpiresAt = nil
+	}
+	if expired(credential.PendingEmailValidationTokenExpiresAt) {
+		credential.PendingEmailValidationTokenID = ""
+		credential.PendingEmailValidationTokenExpiresAt = nil
+	}
+	if expired(credential.PasswordTokenExpiresAt) {
+		credential.PasswordTokenID = ""
+		credential.PasswordTokenExpiresAt = nil
+	}
+	if expired(credential.ResetPasswordTokenExpiresAt) {
+		credential.ResetPasswordTokenID = ""
+		credential.ResetPasswordTokenExpiresAt = nil
+	}
+}
+
+// ClearExpiredRoleGrant reverts the role to its fallback if its grant is due at the given time. Expired grants may
+// remain in the database until they are reverted, so they must be resolved on read to return the effective role.
+func (credential *Credential) ClearExpiredRoleGrant(now time.Time) {
+	if credential.RoleExpiresAt == nil || credential.RoleExpiresAt.After(now) {
+		return
+	}
+
+	if credential.FallbackRole != nil {
+		credential.Role = *credential.FallbackRole
+	}
+
+	credential.RoleExpiresAt = nil
+	credential.FallbackRole = nil
+}
+
+type TokenField string
+
+const (
+	TokenFieldEmailValidation        TokenField = "email_validation"
+	TokenFieldPendingEmailValidation TokenField = "pending_email_validation"
+	TokenFieldPassword               TokenField = "password"
+	TokenFieldResetPassword          TokenField = "reset_password"
+)
+
+func RegisterTokenField(customValidator *validator.Validate) {
+	database.MustRegisterValidation(
+		customValidator, "token_field",
+		database.ValidateEnum(
+			TokenFieldEmailValidation,
+			TokenFieldPendingEmailValidation,
+			TokenFieldPassword,
+			TokenFieldResetPassword,
+		),
+	)
+}
+
+type CredentialKind string
+
+const (
+	// CredentialKindUser is a human user, that signs in with an email.
+	CredentialKindUser CredentialKind = ""
+	// CredentialKindService is a service account, used by automation. It authenticates with API keys.
+	CredentialKindService CredentialKind = "service"
+)
+
+var (
+	_ sql.Scanner   = (*CredentialKind)(nil)
+	_ driver.Valuer = (*CredentialKind)(nil)
+)
+
+func (kind *CredentialKind) String() string {
+	if *kind == CredentialKindUser {
+		return "user"
+	}
+
+	return string(*kind)
+}
+
+func (kind *CredentialKind) FromString(value string) error {
+	switch value {
+	case "user":
+		*kind = CredentialKindUser
+	case string(CredentialKindService):
+		*kind = CredentialKindService
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownKind, value)
+	}
+
+	return nil
+}
+
+func (kind *CredentialKind) Scan(src interface{}) (err error) {
+	switch src := src.(type) {
+	case string:
+		return kind.FromString(src)
+	case []byte:
+		return kind.FromString(string(src))
+	case nil:
+		*kind = CredentialKindUser
+		return nil
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedKindType, src)
+	}
+}
+
+func (kind CredentialKind) Value() (driver.Value, error) {
+	return kind.String(), nil
+}
+
 type Role string
 
 const (
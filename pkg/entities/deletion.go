@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// CredentialDeletion records the permanent erasure of credentials. It keeps no personal data, only the proof that
+// the deletion happened.
+type CredentialDeletion struct {
+	bun.BaseModel `bun:"table:credential_deletions,alias:credential_deletions"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+
+	ScheduledAt time.Time `bun:"scheduled_at"`
+	ErasedAt    time.Time `bun:"erased_at"`
+}
@@ -0,0 +1,211 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/a-novel/golib/database"
+)
+
+var ErrInvalidEmail = errors.New("invalid email")
+
+const (
+	// Limits from RFC 5321, counted in bytes once the email is normalized. Punycode can make an IDN domain longer
+	// than the characters it was typed with.
+	maxEmailLength       = 254
+	maxEmailLocalLength  = 64
+	maxEmailDomainLength = 253
+	maxEmailLabelLength  = 63
+)
+
+// emailSpecials are the ASCII characters allowed in the local part of an email besides letters and digits, as
+// defined by the "atext" rule of RFC 5322.
+const emailSpecials = "!#$%&'*+-/=?^_`{|}~"
+
+// ParseEmail splits an email into its local part and its domain, and normalizes both. The local part is normalized
+// to Unicode NFC, and keeps its case. The domain is lowercased, and encoded in punycode. Internationalized
+// (SMTPUTF8) local parts and IDN domains are accepted. IP literal domains are not.
+func ParseEmail(email string) (string, string, error) {
+	email = norm.NFC.String(email)
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return "", "", fmt.Errorf("%w: missing '@'", ErrInvalidEmail)
+	}
+
+	local := email[:at]
+	if err := validateEmailLocal(local); err != nil {
+		return "", "", err
+	}
+
+	domain, err := ParseEmailDomain(email[at+1:])
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(local)+1+len(domain) > maxEmailLength {
+		return "", "", fmt.Errorf("%w: longer than %d bytes", ErrInvalidEmail, maxEmailLength)
+	}
+
+	return local, domain, nil
+}
+
+// ParseEmailDomain returns the domain of an email lowercased, and encoded in punycode.
+func ParseEmailDomain(domain string) (string, error) {
+	domain, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("%w: domain: %w", ErrInvalidEmail, err)
+	}
+
+	if err = validateEmailDomain(domain); err != nil {
+		return "", err
+	}
+
+	return domain, nil
+}
+
+func validateEmailLocal(local string) error {
+	if local == "" {
+		return fmt.Errorf("%w: empty local part", ErrInvalidEmail)
+	}
+
+	if len(local) > maxEmailLocalLength {
+		return fmt.Errorf("%w: local part longer than %d bytes", ErrInvalidEmail, maxEmailLocalLength)
+	}
+
+	if strings.HasPrefix(local, `"`) {
+		return validateEmailQuotedLocal(local)
+	}
+
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return fmt.Errorf("%w: misplaced '.' in local part", ErrInvalidEmail)
+		}
+
+		for _, char := range atom {
+			if !isEmailAtomChar(char) {
+				return fmt.Errorf("%w: invalid character %q in local part", ErrInvalidEmail, char)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isEmailAtomChar(char rune) bool {
+	if char >= utf8.RuneSelf {
+		// SMTPUTF8 allows any printable non-ASCII character.
+		return unicode.IsGraphic(char) && !unicode.IsSpace(char)
+	}
+
+	return ('a' <= char && char <= 'z') || ('A' <= char && char <= 'Z') || ('0' <= char && char <= '9') ||
+		strings.ContainsRune(emailSpecials, char)
+}
+
+// validateEmailQuotedLocal validates local parts written as a quoted string, such as "john doe"@example.com.
+func validateEmailQuotedLocal(local string) error {
+	if len(local) < 2 || !strings.HasSuffix(local, `"`) {
+		return fmt.Errorf("%w: unterminated quoted local part", ErrInvalidEmail)
+	}
+
+	escaped := false
+	for _, char := range local[1 : len(local)-1] {
+		if char != ' ' && !unicode.IsGraphic(char) {
+			return fmt.Errorf("%w: invalid character %q in local part", ErrInvalidEmail, char)
+		}
+
+		switch {
+		case escaped:
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '"':
+			return fmt.Errorf("%w: unescaped '\"' in local part", ErrInvalidEmail)
+		}
+	}
+
+	if escaped {
+		return fmt.Errorf("%w: unterminated quoted local part", ErrInvalidEmail)
+	}
+
+	return nil
+}
+
+// validateEmailDomain checks the lengths of a domain encoded in punycode. The characters are already validated by
+// the IDNA conversion.
+func validateEmailDomain(domain string) error {
+	if len(domain) > maxEmailDomainLength {
+		return fmt.Errorf("%w: domain longer than %d bytes", ErrInvalidEmail, maxEmailDomainLength)
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("%w: domain has no top-level domain", ErrInvalidEmail)
+	}
+
+	for _, label := range labels {
+		if label == "" || len(label) > maxEmailLabelLength {
+			return fmt.Errorf("%w: invalid domain label '%s'", ErrInvalidEmail, label)
+		}
+	}
+
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return fmt.Errorf("%w: numeric top-level domain", ErrInvalidEmail)
+	}
+
+	return nil
+}
+
+// NormalizeEmail returns the form emails are stored and compared in, so that variants of the same address, such as
+// user@bücher.de and user@xn--bcher-kva.de, resolve to the same credentials. Invalid emails are returned unchanged.
+func NormalizeEmail(email string) string {
+	local, domain, err := ParseEmail(email)
+	if err != nil {
+		return email
+	}
+
+	return local + "@" + domain
+}
+
+// NormalizeEmailDomain returns the form email domains are compared in, lowercased and encoded in punycode. Invalid
+// domains are only lowercased.
+func NormalizeEmailDomain(domain string) string {
+	normalized, err := ParseEmailDomain(domain)
+	if err != nil {
+		return strings.ToLower(domain)
+	}
+
+	return normalized
+}
+
+func IsValidEmail(email string) bool {
+	_, _, err := ParseEmail(email)
+	return err == nil
+}
+
+// RegisterEmail registers the "email_address" tag. Unlike the builtin "email" tag, it accepts internationalized
+// addresses. Values must still go through NormalizeEmail before being stored or compared.
+func RegisterEmail(customValidator *validator.Validate) {
+	database.MustRegisterValidation(customValidator, "email_address", func(fl validator.FieldLevel) bool {
+		return IsValidEmail(fl.Field().String())
+	})
+}
+
+func IsValidEmailDomain(domain string) bool {
+	_, err := ParseEmailDomain(domain)
+	return err == nil
+}
+
+// RegisterEmailDomain registers the "email_domain" tag, that accepts ASCII as well as internationalized domains.
+func RegisterEmailDomain(customValidator *validator.Validate) {
+	database.MustRegisterValidation(customValidator, "email_domain", func(fl validator.FieldLevel) bool {
+		return IsValidEmailDomain(fl.Field().String())
+	})
+}
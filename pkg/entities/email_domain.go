@@ -0,0 +1,124 @@
+package entities
+
+import (
+	_ "embed"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/golib/database"
+)
+
+type EmailDomainAction string
+
+const (
+	EmailDomainActionAllow EmailDomainAction = "allow"
+	EmailDomainActionDeny  EmailDomainAction = "deny"
+)
+
+func RegisterEmailDomainAction(customValidator *validator.Validate) {
+	database.MustRegisterValidation(
+		customValidator, "email_domain_action",
+		database.ValidateEnum(EmailDomainActionAllow, EmailDomainActionDeny),
+	)
+}
+
+// EmailDomainRule is a custom entry of the email domain policy of a tenant, managed at runtime. Like the entries of
+// the configured policy, it also applies to the subdomains of its domain.
+type EmailDomainRule struct {
+	bun.BaseModel `bun:"table:email_domain_rules,alias:email_domain_rules"`
+
+	ID       uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID string    `bun:"tenant_id"`
+
+	// Domain is stored lowercased, and encoded in punycode.
+	Domain string            `bun:"domain"`
+	Action EmailDomainAction `bun:"action"`
+	Reason string            `bun:"reason,nullzero"`
+
+	// SetBy identifies the administrator who last set the rule.
+	SetBy     string     `bun:"set_by"`
+	CreatedAt time.Time  `bun:"created_at"`
+	UpdatedAt *time.Time `bun:"updated_at"`
+}
+
+// The list of disposable email domains is embedded, so it can be refreshed without changing the configuration. It is
+// regenerated from its upstream source with `make disposable-domains`.
+//
+//go:embed disposable_domains.txt
+var disposableDomainsFile string
+
+var disposableDomains = lo.SliceToMap(
+	lo.Filter(strings.Split(disposableDomainsFile, "\n"), func(line string, _ int) bool {
+		line = strings.TrimSpace(line)
+		return line != "" && !strings.HasPrefix(line, "#")
+	}),
+	func(line string) (string, struct{}) { return strings.ToLower(strings.TrimSpace(line)), struct{}{} },
+)
+
+// EmailDomain returns the part of the email after the last "@", normalized with NormalizeEmailDomain.
+func EmailDomain(email string) string {
+	return NormalizeEmailDomain(email[strings.LastIndex(email, "@")+1:])
+}
+
+// DomainSuffixes returns the domain, followed by each of its parent domains.
+func DomainSuffixes(domain string) []string {
+	suffixes := []string{domain}
+
+	for i := strings.Index(domain, "."); i >= 0; i = strings.Index(domain, ".") {
+		domain = domain[i+1:]
+		suffixes = append(suffixes, domain)
+	}
+
+	return suffixes
+}
+
+// IsDisposableDomain tells whether the domain, or one of its parents, belongs to a disposable email provider.
+func IsDisposableDomain(domain string) bool {
+	return lo.SomeBy(DomainSuffixes(domain), func(item string) bool {
+		_, ok := disposableDomains[item]
+		return ok
+	})
+}
+
+// EmailDomainPolicy restricts the email domains credentials can use. Entries apply to subdomains as well.
+type EmailDomainPolicy struct {
+	// AllowlistOnly rejects every domain that is not explicitly allowed.
+	AllowlistOnly bool     `yaml:"allowlistOnly"`
+	Allow         []string `yaml:"allow"`
+	Deny          []string `yaml:"deny"`
+	// BlockDisposable rejects the domains of disposable email providers, unless they are explicitly allowed.
+	BlockDisposable bool `yaml:"blockDisposable"`
+}
+
+// Accepts tells whether the domain can be used, given the custom rules set for the domain or its parents. Denied
+// domains are always rejected, even if they are also allowed.
+func (policy EmailDomainPolicy) Accepts(domain string, rules []*EmailDomainRule) bool {
+	suffixes := DomainSuffixes(domain)
+
+	hasRule := func(action EmailDomainAction, configured []string) bool {
+		return lo.SomeBy(suffixes, func(suffix string) bool {
+			return lo.ContainsBy(configured, func(item string) bool { return NormalizeEmailDomain(item) == suffix })
+		}) || lo.SomeBy(rules, func(item *EmailDomainRule) bool {
+			return item.Action == action && lo.Contains(suffixes, item.Domain)
+		})
+	}
+
+	if hasRule(EmailDomainActionDeny, policy.Deny) {
+		return false
+	}
+
+	if hasRule(EmailDomainActionAllow, policy.Allow) {
+		return true
+	}
+
+	if policy.AllowlistOnly {
+		return false
+	}
+
+	return !policy.BlockDisposable || !IsDisposableDomain(domain)
+}
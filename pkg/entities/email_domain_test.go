@@ -0,0 +1,206 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+func TestIsDisposableDomain(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		domain string
+
+		expect bool
+	}{
+		{
+			name: "Listed",
+
+			domain: "mailinator.com",
+
+			expect: true,
+		},
+		{
+			name: "Subdomain",
+
+			domain: "inbox.yopmail.com",
+
+			expect: true,
+		},
+		{
+			name: "NotListed",
+
+			domain: "example.com",
+		},
+		{
+			// Only whole labels match: the domain is not a subdomain of a listed one.
+			name: "NotListed/Suffix",
+
+			domain: "notmailinator.com.example.com",
+		},
+		{
+			name: "NotListed/Parent",
+
+			domain: "com",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, entities.IsDisposableDomain(testCase.domain))
+		})
+	}
+}
+
+func TestEmailDomainPolicyAccepts(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		policy entities.EmailDomainPolicy
+		domain string
+		rules  []*entities.EmailDomainRule
+
+		expect bool
+	}{
+		{
+			name: "EmptyPolicy",
+
+			domain: "example.com",
+
+			expect: true,
+		},
+		{
+			name: "Deny",
+
+			policy: entities.EmailDomainPolicy{Deny: []string{"example.com"}},
+			domain: "example.com",
+		},
+		{
+			name: "Deny/Subdomain",
+
+			policy: entities.EmailDomainPolicy{Deny: []string{"example.com"}},
+			domain: "mail.example.com",
+		},
+		{
+			name: "Deny/OtherDomainWithSameSuffix",
+
+			policy: entities.EmailDomainPolicy{Deny: []string{"example.com"}},
+			domain: "badexample.com",
+
+			expect: true,
+		},
+		{
+			name: "Deny/Normalized",
+
+			policy: entities.EmailDomainPolicy{Deny: []string{"Bücher.DE"}},
+			domain: "xn--bcher-kva.de",
+		},
+		{
+			name: "Deny/BeatsAllow",
+
+			policy: entities.EmailDomainPolicy{Allow: []string{"example.com"}, Deny: []string{"example.com"}},
+			domain: "example.com",
+		},
+		{
+			name: "Deny/Parent/BeatsAllow",
+
+			policy: entities.EmailDomainPolicy{Allow: []string{"mail.example.com"}, Deny: []string{"example.com"}},
+			domain: "mail.example.com",
+		},
+		{
+			name: "Deny/Rule/BeatsAllow",
+
+			policy: entities.EmailDomainPolicy{Allow: []string{"example.com"}},
+			domain: "example.com",
+			rules: []*entities.EmailDomainRule{
+				{Domain: "example.com", Action: entities.EmailDomainActionDeny},
+			},
+		},
+		{
+			name: "Deny/BeatsRuleAllow",
+
+			policy: entities.EmailDomainPolicy{Deny: []string{"example.com"}},
+			domain: "mail.example.com",
+			rules: []*entities.EmailDomainRule{
+				{Domain: "mail.example.com", Action: entities.EmailDomainActionAllow},
+			},
+		},
+		{
+			name: "AllowlistOnly",
+
+			policy: entities.EmailDomainPolicy{AllowlistOnly: true, Allow: []string{"example.com"}},
+			domain: "other.com",
+		},
+		{
+			name: "AllowlistOnly/Allowed",
+
+			policy: entities.EmailDomainPolicy{AllowlistOnly: true, Allow: []string{"example.com"}},
+			domain: "example.com",
+
+			expect: true,
+		},
+		{
+			name: "AllowlistOnly/Subdomain",
+
+			policy: entities.EmailDomainPolicy{AllowlistOnly: true, Allow: []string{"example.com"}},
+			domain: "mail.example.com",
+
+			expect: true,
+		},
+		{
+			name: "AllowlistOnly/Rule",
+
+			policy: entities.EmailDomainPolicy{AllowlistOnly: true},
+			domain: "mail.example.com",
+			rules: []*entities.EmailDomainRule{
+				{Domain: "example.com", Action: entities.EmailDomainActionAllow},
+			},
+
+			expect: true,
+		},
+		{
+			name: "BlockDisposable",
+
+			policy: entities.EmailDomainPolicy{BlockDisposable: true},
+			domain: "mailinator.com",
+		},
+		{
+			name: "BlockDisposable/Subdomain",
+
+			policy: entities.EmailDomainPolicy{BlockDisposable: true},
+			domain: "inbox.mailinator.com",
+		},
+		{
+			name: "BlockDisposable/NotDisposable",
+
+			policy: entities.EmailDomainPolicy{BlockDisposable: true},
+			domain: "example.com",
+
+			expect: true,
+		},
+		{
+			name: "BlockDisposable/Allowed",
+
+			policy: entities.EmailDomainPolicy{BlockDisposable: true, Allow: []string{"mailinator.com"}},
+			domain: "mailinator.com",
+
+			expect: true,
+		},
+		{
+			name: "Disposable/NotBlocked",
+
+			domain: "mailinator.com",
+
+			expect: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, testCase.policy.Accepts(testCase.domain, testCase.rules))
+		})
+	}
+}
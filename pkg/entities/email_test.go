@@ -0,0 +1,386 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	// maxLengthDomain is the longest domain that fits in an email with a 64 bytes local part.
+	maxLengthDomain = strings.Repeat(strings.Repeat("b", 62)+".", 2) + strings.Repeat("b", 59) + ".com"
+	// longIDNDomain is 206 bytes long once encoded in punycode, but only typed with 134 characters.
+	longIDNDomain = strings.Repeat("üüüüüüüüüü.", 12) + "de"
+)
+
+func TestParseEmail(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		email string
+
+		expectLocal  string
+		expectDomain string
+		expectErr    error
+	}{
+		{
+			name: "ASCII",
+
+			email: "john.doe@example.com",
+
+			expectLocal:  "john.doe",
+			expectDomain: "example.com",
+		},
+		{
+			name: "MixedCase",
+
+			email: "John.Doe@Example.COM",
+
+			expectLocal:  "John.Doe",
+			expectDomain: "example.com",
+		},
+		{
+			name: "IDN",
+
+			email: "user@Bücher.de",
+
+			expectLocal:  "user",
+			expectDomain: "xn--bcher-kva.de",
+		},
+		{
+			name: "IDN/Punycode",
+
+			email: "user@XN--BCHER-KVA.de",
+
+			expectLocal:  "user",
+			expectDomain: "xn--bcher-kva.de",
+		},
+		{
+			name: "SMTPUTF8",
+
+			email: "josé@example.com",
+
+			expectLocal:  "josé",
+			expectDomain: "example.com",
+		},
+		{
+			name: "NFD",
+
+			email: "jose\u0301@bu\u0308cher.de",
+
+			expectLocal:  "josé",
+			expectDomain: "xn--bcher-kva.de",
+		},
+		{
+			name: "QuotedLocal",
+
+			email: `"john@doe"@example.com`,
+
+			expectLocal:  `"john@doe"`,
+			expectDomain: "example.com",
+		},
+		{
+			name: "MaxLength",
+
+			email: strings.Repeat("a", 64) + "@" + maxLengthDomain,
+
+			expectLocal:  strings.Repeat("a", 64),
+			expectDomain: maxLengthDomain,
+		},
+		{
+			name: "Invalid/MissingAt",
+
+			email: "john.example.com",
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+		{
+			name: "Invalid/MultipleAt",
+
+			email: "john@doe@example.com",
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+		{
+			name: "Invalid/EmptyLocal",
+
+			email: "@example.com",
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+		{
+			name: "Invalid/Punycode",
+
+			email: "user@xn--a.com",
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+		{
+			name: "Invalid/NoTopLevelDomain",
+
+			email: "user@localhost",
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+		{
+			name: "Invalid/LocalTooLong",
+
+			email: strings.Repeat("a", 65) + "@example.com",
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+		{
+			name: "Invalid/LabelTooLong",
+
+			email: "user@" + strings.Repeat("b", 64) + ".com",
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+		{
+			name: "Invalid/TooLong",
+
+			email: strings.Repeat("a", 64) + "@b" + maxLengthDomain,
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+		{
+			// Each part fits, but the whole address exceeds the limit once the domain is encoded in punycode.
+			name: "Invalid/TooLongOnceNormalized",
+
+			email: strings.Repeat("a", 64) + "@" + longIDNDomain,
+
+			expectErr: entities.ErrInvalidEmail,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			local, domain, err := entities.ParseEmail(testCase.email)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expectLocal, local)
+			require.Equal(t, testCase.expectDomain, domain)
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		email string
+
+		expect string
+	}{
+		{
+			name: "ASCII",
+
+			email: "john.doe@example.com",
+
+			expect: "john.doe@example.com",
+		},
+		{
+			name: "MixedCase",
+
+			email: "John.Doe@Example.COM",
+
+			expect: "John.Doe@example.com",
+		},
+		{
+			name: "IDN",
+
+			email: "user@Bücher.de",
+
+			expect: "user@xn--bcher-kva.de",
+		},
+		{
+			name: "NFD",
+
+			email: "jose\u0301@bu\u0308cher.de",
+
+			expect: "josé@xn--bcher-kva.de",
+		},
+		{
+			name: "Invalid/Punycode",
+
+			email: "user@XN--A.com",
+
+			expect: "user@XN--A.com",
+		},
+		{
+			name: "Invalid/MultipleAt",
+
+			email: "john@doe@Example.com",
+
+			expect: "john@doe@Example.com",
+		},
+		{
+			name: "Invalid/TooLongOnceNormalized",
+
+			email: strings.Repeat("a", 64) + "@" + longIDNDomain,
+
+			expect: strings.Repeat("a", 64) + "@" + longIDNDomain,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, entities.NormalizeEmail(testCase.email))
+		})
+	}
+}
+
+func TestNormalizeEmailDomain(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		domain string
+
+		expect string
+	}{
+		{
+			name: "ASCII",
+
+			domain: "example.com",
+
+			expect: "example.com",
+		},
+		{
+			name: "MixedCase",
+
+			domain: "Example.COM",
+
+			expect: "example.com",
+		},
+		{
+			name: "IDN",
+
+			domain: "Bücher.de",
+
+			expect: "xn--bcher-kva.de",
+		},
+		{
+			name: "NFD",
+
+			domain: "bu\u0308cher.de",
+
+			expect: "xn--bcher-kva.de",
+		},
+		{
+			name: "Invalid/Punycode",
+
+			domain: "XN--A.com",
+
+			expect: "xn--a.com",
+		},
+		{
+			name: "Invalid/TooLong",
+
+			domain: strings.Repeat(strings.Repeat("B", 62)+".", 4) + "com",
+
+			expect: strings.Repeat(strings.Repeat("b", 62)+".", 4) + "com",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expect, entities.NormalizeEmailDomain(testCase.domain))
+		})
+	}
+}
+
+func TestRegisterEmail(t *testing.T) {
+	type request struct {
+		Email  string `validate:"email_address"`
+		Domain string `validate:"email_domain"`
+	}
+
+	testCases := []struct {
+		name string
+
+		request *request
+
+		expectErr bool
+	}{
+		{
+			name: "ASCII",
+
+			request: &request{Email: "john.doe@example.com", Domain: "example.com"},
+		},
+		{
+			name: "MixedCase",
+
+			request: &request{Email: "John.Doe@Example.COM", Domain: "Example.COM"},
+		},
+		{
+			name: "IDN",
+
+			request: &request{Email: "josé@Bücher.de", Domain: "Bücher.de"},
+		},
+		{
+			name: "NFD",
+
+			request: &request{Email: "jose\u0301@bu\u0308cher.de", Domain: "bu\u0308cher.de"},
+		},
+		{
+			name: "Invalid/Email/Punycode",
+
+			request: &request{Email: "user@xn--a.com", Domain: "example.com"},
+
+			expectErr: true,
+		},
+		{
+			name: "Invalid/Email/MultipleAt",
+
+			request: &request{Email: "john@doe@example.com", Domain: "example.com"},
+
+			expectErr: true,
+		},
+		{
+			name: "Invalid/Email/TooLongOnceNormalized",
+
+			request: &request{Email: strings.Repeat("a", 64) + "@" + longIDNDomain, Domain: "example.com"},
+
+			expectErr: true,
+		},
+		{
+			name: "Invalid/Domain/Punycode",
+
+			request: &request{Email: "john.doe@example.com", Domain: "xn--a.com"},
+
+			expectErr: true,
+		},
+		{
+			name: "Invalid/Domain/Email",
+
+			request: &request{Email: "john.doe@example.com", Domain: "john.doe@example.com"},
+
+			expectErr: true,
+		},
+		{
+			name: "Invalid/Domain/TooLong",
+
+			request: &request{
+				Email:  "john.doe@example.com",
+				Domain: strings.Repeat(strings.Repeat("b", 62)+".", 4) + "com",
+			},
+
+			expectErr: true,
+		},
+	}
+
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	entities.RegisterEmail(validate)
+	entities.RegisterEmailDomain(validate)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validate.Struct(testCase.request)
+			require.Equal(t, testCase.expectErr, err != nil, err)
+		})
+	}
+}
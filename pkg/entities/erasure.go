@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// ErasedEmailDomain is used by the placeholder emails of erased credentials. The ".invalid" top-level domain is
+// reserved, so placeholders can never reach a real mailbox.
+const ErasedEmailDomain = "erased.invalid"
+
+const erasureSaltSize = 32
+
+// CredentialErasure records the erasure of the personal data of credentials. Erasure records are kept as proof of
+// compliance, and are not subject to history retention.
+type CredentialErasure struct {
+	bun.BaseModel `bun:"table:credential_erasures,alias:credential_erasures"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+
+	// Reason is an optional, free-form justification of the erasure, for example a ticket reference.
+	Reason   string    `bun:"reason,nullzero"`
+	ErasedAt time.Time `bun:"erased_at"`
+}
+
+// NewErasurePlaceholder returns a placeholder that replaces the identifying values of erased credentials. It is
+// derived from a random salt that is discarded, so it cannot be traced back to the original values.
+func NewErasurePlaceholder(id uuid.UUID) (string, error) {
+	salt := make([]byte, erasureSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+
+	hash := sha256.Sum256(append(salt, id[:]...))
+
+	return "erased-" + hex.EncodeToString(hash[:16]), nil
+}
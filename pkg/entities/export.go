@@ -0,0 +1,52 @@
+package entities
+
+// ExportSchemaVersion is the version of the layout of credential data exports. It must be bumped whenever a field
+// is removed or changes meaning, so consumers of the exports can tell documents apart.
+const ExportSchemaVersion = 1
+
+// RedactedValue replaces masked values in data exports.
+const RedactedValue = "[REDACTED]"
+
+// RedactionMode tells how a sensitive field is rendered in data exports.
+type RedactionMode string
+
+const (
+	// RedactionModeOmit removes the field from the export. It is the default for unknown modes.
+	RedactionModeOmit RedactionMode = "omit"
+	// RedactionModeMask replaces the value with RedactedValue, so the export still tells whether it is set.
+	RedactionModeMask RedactionMode = "mask"
+	// RedactionModeKeep exports the value as is.
+	RedactionModeKeep RedactionMode = "keep"
+)
+
+// Redact renders a value according to the mode. Empty values are always omitted.
+func (mode RedactionMode) Redact(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	var output string
+
+	switch mode {
+	case RedactionModeKeep:
+		output = value
+	case RedactionModeMask:
+		output = RedactedValue
+	default:
+		return nil
+	}
+
+	return &output
+}
+
+// ExportRedactionPolicy configures how sensitive internal fields are rendered in data exports. Secret hashes of API
+// keys are never exported, regardless of the policy.
+type ExportRedactionPolicy struct {
+	// TokenIDs applies to the IDs of the email validation, password and reset password tokens.
+	TokenIDs RedactionMode `yaml:"tokenIDs"`
+	// FactorSecrets applies to the secret references and WebAuthn key material of factors.
+	FactorSecrets RedactionMode `yaml:"factorSecrets"`
+	// Notes applies to the author and body of internal notes. Notes are always listed, so the owner of the credential
+	// knows they exist, even when their content is omitted.
+	Notes RedactionMode `yaml:"notes"`
+}
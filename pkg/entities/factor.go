@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/golib/database"
+)
+
+type FactorType string
+
+const (
+	// FactorTypeTOTP is a time-based one time password. The secret is kept by the authentication service, and
+	// referenced by SecretRef.
+	FactorTypeTOTP FactorType = "totp"
+	// FactorTypeWebAuthn is a WebAuthn (passkey / security key) credential.
+	FactorTypeWebAuthn FactorType = "webauthn"
+	// FactorTypeRecoveryCodes is a set of single use recovery codes. The hashed codes are kept by the authentication
+	// service, and referenced by SecretRef.
+	FactorTypeRecoveryCodes FactorType = "recovery_codes"
+)
+
+func RegisterFactorType(customValidator *validator.Validate) {
+	database.MustRegisterValidation(
+		customValidator, "factor_type",
+		database.ValidateEnum(FactorTypeTOTP, FactorTypeWebAuthn, FactorTypeRecoveryCodes),
+	)
+}
+
+// CredentialFactor is an additional authentication factor, enrolled by the owner of a credential.
+type CredentialFactor struct {
+	bun.BaseModel `bun:"table:credential_factors,alias:credential_factors"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+
+	Type  FactorType `bun:"type"`
+	Label string     `bun:"label"`
+
+	SecretRef string `bun:"secret_ref,nullzero"`
+
+	WebAuthnCredentialID string `bun:"webauthn_credential_id,nullzero"`
+	WebAuthnPublicKey    []byte `bun:"webauthn_public_key,nullzero"`
+
+	CreatedAt  time.Time  `bun:"created_at"`
+	LastUsedAt *time.Time `bun:"last_used_at"`
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/a-novel/golib/database"
+)
+
+// CredentialIdentity links a credential to an account on an external identity provider (OAuth / OIDC), so the user
+// can sign in with it.
+type CredentialIdentity struct {
+	bun.BaseModel `bun:"table:credential_identities,alias:credential_identities"`
+
+	TenantID string `bun:"tenant_id,pk"`
+	// Provider is the slug of the identity provider, for example "google" or "github".
+	Provider string `bun:"provider,pk"`
+	// Subject is the unique identifier of the user on the provider ("sub" claim).
+	Subject string `bun:"subject,pk"`
+
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+
+	// EmailAtLinkTime is the email reported by the provider when the identity was linked. Providers may not
+	// share an email, in which case it is empty.
+	EmailAtLinkTime string    `bun:"email_at_link_time,nullzero"`
+	LinkedAt        time.Time `bun:"linked_at"`
+}
+
+var identityProviderRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+func RegisterIdentityProvider(customValidator *validator.Validate) {
+	database.MustRegisterValidation(customValidator, "identity_provider", func(fl validator.FieldLevel) bool {
+		return identityProviderRegexp.MatchString(fl.Field().String())
+	})
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const invitationCodeSize = 10
+
+// Uppercase base32, without padding, so codes are easy to read out and type.
+var invitationCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Invitation is a code that grants a role to the credentials redeeming it. A code can be redeemed up to MaxUses
+// times, by different credentials.
+type Invitation struct {
+	bun.BaseModel `bun:"table:invitations,alias:invitations"`
+
+	ID       uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID string    `bun:"tenant_id"`
+
+	Code    string `bun:"code"`
+	Role    Role   `bun:"role,type:credentials_role"`
+	MaxUses int    `bun:"max_uses"`
+	Uses    int    `bun:"uses"`
+
+	// CreatedBy is the user credential that issued the invitation. It is empty once those credentials are deleted.
+	CreatedBy uuid.UUID  `bun:"created_by,type:uuid,nullzero"`
+	ExpiresAt *time.Time `bun:"expires_at"`
+	CreatedAt time.Time  `bun:"created_at"`
+
+	// Redemptions are only populated by queries that load them.
+	Redemptions []*InvitationRedemption `bun:"-"`
+}
+
+// Available tells whether the invitation can still be redeemed at the given time.
+func (invitation *Invitation) Available(now time.Time) bool {
+	if invitation.ExpiresAt != nil && !invitation.ExpiresAt.After(now) {
+		return false
+	}
+
+	return invitation.Uses < invitation.MaxUses
+}
+
+// InvitationRedemption records the credentials that redeemed an invitation.
+type InvitationRedemption struct {
+	bun.BaseModel `bun:"table:invitation_redemptions,alias:invitation_redemptions"`
+
+	InvitationID uuid.UUID `bun:"invitation_id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,pk,type:uuid"`
+
+	RedeemedAt time.Time `bun:"redeemed_at"`
+}
+
+// GenerateInvitationCode returns a new random invitation code.
+func GenerateInvitationCode() (string, error) {
+	raw := make([]byte, invitationCodeSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate code: %w", err)
+	}
+
+	return invitationCodeEncoding.EncodeToString(raw), nil
+}
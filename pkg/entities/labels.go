@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/golib/database"
+)
+
+const (
+	LabelKeyMaxLength   = 63
+	LabelValueMaxLength = 256
+)
+
+var ErrInvalidLabelSelector = errors.New("invalid label selector")
+
+// Label keys are lowercase alphanumeric strings, that may contain dots, dashes and underscores in the middle.
+var labelKeyRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+
+var labelSelectorInRegexp = regexp.MustCompile(`^(\S+)\s+in\s+\((.*)\)$`)
+
+func IsValidLabelKey(key string) bool {
+	return len(key) <= LabelKeyMaxLength && labelKeyRegexp.MatchString(key)
+}
+
+func RegisterLabelKey(customValidator *validator.Validate) {
+	database.MustRegisterValidation(customValidator, "label_key", func(fl validator.FieldLevel) bool {
+		return IsValidLabelKey(fl.Field().String())
+	})
+}
+
+type LabelSelectorOperator string
+
+const (
+	// LabelSelectorOperatorEquals matches credentials with the label set to the exact value: "key=value".
+	LabelSelectorOperatorEquals LabelSelectorOperator = "="
+	// LabelSelectorOperatorIn matches credentials with the label set to any of the values: "key in (v1,v2)".
+	LabelSelectorOperatorIn LabelSelectorOperator = "in"
+	// LabelSelectorOperatorNotExists matches credentials that do not have the label: "!key".
+	LabelSelectorOperatorNotExists LabelSelectorOperator = "!"
+)
+
+type LabelSelector struct {
+	Key      string
+	Operator LabelSelectorOperator
+	Values   []string
+}
+
+func ParseLabelSelector(raw string) (*LabelSelector, error) {
+	raw = strings.TrimSpace(raw)
+
+	var selector *LabelSelector
+
+	if key, ok := strings.CutPrefix(raw, "!"); ok {
+		selector = &LabelSelector{Key: strings.TrimSpace(key), Operator: LabelSelectorOperatorNotExists}
+	} else if matches := labelSelectorInRegexp.FindStringSubmatch(raw); matches != nil {
+		values := strings.Split(matches[2], ",")
+		for i, value := range values {
+			values[i] = strings.TrimSpace(value)
+		}
+
+		selector = &LabelSelector{Key: matches[1], Operator: LabelSelectorOperatorIn, Values: values}
+	} else if key, value, ok := strings.Cut(raw, "="); ok {
+		selector = &LabelSelector{
+			Key:      strings.TrimSpace(key),
+			Operator: LabelSelectorOperatorEquals,
+			Values:   []string{strings.TrimSpace(value)},
+		}
+	} else {
+		return nil, fmt.Errorf("%w: unknown operator in '%s'", ErrInvalidLabelSelector, raw)
+	}
+
+	if !IsValidLabelKey(selector.Key) {
+		return nil, fmt.Errorf("%w: invalid key '%s'", ErrInvalidLabelSelector, selector.Key)
+	}
+
+	for _, value := range selector.Values {
+		if len(value) > LabelValueMaxLength {
+			return nil, fmt.Errorf("%w: value for key '%s' is too long", ErrInvalidLabelSelector, selector.Key)
+		}
+	}
+
+	return selector, nil
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// CredentialMerge redirects merged credentials to the credentials that absorbed them. Redirects are always a single
+// hop: merging a survivor into other credentials updates the redirects that pointed to it.
+type CredentialMerge struct {
+	bun.BaseModel `bun:"table:credential_merges,alias:credential_merges"`
+
+	LoserID    uuid.UUID `bun:"loser_id,pk,type:uuid"`
+	TenantID   string    `bun:"tenant_id"`
+	SurvivorID uuid.UUID `bun:"survivor_id,type:uuid"`
+
+	MergedAt time.Time `bun:"merged_at"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// CredentialNote is an internal note written by a support agent about a credential. Notes are not meant for the
+// owner of the credential, and are left out of data exports unless configured otherwise.
+type CredentialNote struct {
+	bun.BaseModel `bun:"table:credential_notes,alias:credential_notes"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+
+	// Author identifies the agent who wrote the note.
+	Author string `bun:"author"`
+	Body   string `bun:"body"`
+	// Pinned notes are listed first.
+	Pinned bool `bun:"pinned"`
+
+	CreatedAt time.Time  `bun:"created_at"`
+	UpdatedAt *time.Time `bun:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type RoleChangeReason string
+
+const (
+	// RoleChangeReasonGrantExpired is recorded when a temporary role expires, and the credentials revert to their
+	// fallback role.
+	RoleChangeReasonGrantExpired RoleChangeReason = "grant_expired"
+	// RoleChangeReasonInvitationRedeemed is recorded when redeeming an invitation upgrades the role of the
+	// credentials.
+	RoleChangeReasonInvitationRedeemed RoleChangeReason = "invitation_redeemed"
+	// RoleChangeReasonWaitlistPromoted is recorded when credentials are handed a seat they were waiting for.
+	RoleChangeReasonWaitlistPromoted RoleChangeReason = "waitlist_promoted"
+	// RoleChangeReasonRequestApproved is recorded when a role change request is approved, and applied.
+	RoleChangeReasonRequestApproved RoleChangeReason = "request_approved"
+)
+
+// CredentialRoleChange records a change of role that was not requested directly, so it can be traced afterward.
+type CredentialRoleChange struct {
+	bun.BaseModel `bun:"table:credential_role_changes,alias:credential_role_changes"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+
+	FromRole Role             `bun:"from_role,type:credentials_role"`
+	ToRole   Role             `bun:"to_role,type:credentials_role"`
+	Reason   RoleChangeReason `bun:"reason"`
+
+	ChangedAt time.Time `bun:"changed_at"`
+}
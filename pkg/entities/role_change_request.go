@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type RoleChangeRequestStatus string
+
+const (
+	RoleChangeRequestStatusPending  RoleChangeRequestStatus = "pending"
+	RoleChangeRequestStatusApproved RoleChangeRequestStatus = "approved"
+	RoleChangeRequestStatusRejected RoleChangeRequestStatus = "rejected"
+	// RoleChangeRequestStatusExpired is stored once a new request replaces an expired one. Until then, expired
+	// requests are still stored as pending, and Status must be read through StatusAt.
+	RoleChangeRequestStatusExpired RoleChangeRequestStatus = "expired"
+)
+
+// RoleChangeRequest is a change of role proposed by one actor, that only applies once approved by another.
+type RoleChangeRequest struct {
+	bun.BaseModel `bun:"table:role_change_requests,alias:role_change_requests"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+	Role         Role      `bun:"role,type:credentials_role"`
+
+	Status RoleChangeRequestStatus `bun:"status"`
+
+	// ProposedBy and DecidedBy are the user credentials that proposed, and approved or rejected the request. They are
+	// empty once those credentials are deleted.
+	ProposedBy uuid.UUID  `bun:"proposed_by,type:uuid,nullzero"`
+	DecidedBy  *uuid.UUID `bun:"decided_by,type:uuid"`
+	ExpiresAt  time.Time  `bun:"expires_at"`
+	DecidedAt  *time.Time `bun:"decided_at"`
+	CreatedAt  time.Time  `bun:"created_at"`
+}
+
+// StatusAt returns the status of the request at the given time, accounting for pending requests that expired.
+func (request *RoleChangeRequest) StatusAt(now time.Time) RoleChangeRequestStatus {
+	if request.Status == RoleChangeRequestStatusPending && !request.ExpiresAt.After(now) {
+		return RoleChangeRequestStatusExpired
+	}
+
+	return request.Status
+}
@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/golib/database"
+)
+
+// RoleRule assigns a default role to the credentials whose email matches it. A rule matches either a domain, or a
+// regular expression.
+//
+// Patterns are compiled once, when the rule is loaded from the configuration or built with NewRoleRule. Rules
+// declared as literals only match their domain.
+type RoleRule struct {
+	// Domain matches the part of the email after the last "@", ignoring case. IDN domains match in either their
+	// Unicode or punycode form. Subdomains do not match.
+	Domain string `yaml:"domain"`
+	// Pattern is a regular expression, that matches anywhere in the email unless anchored.
+	Pattern string `yaml:"pattern"`
+	Role    Role   `yaml:"role"`
+
+	compiled *regexp.Regexp
+}
+
+// NewRoleRule returns a rule matching either the domain or the pattern, and compiles the pattern.
+func NewRoleRule(domain, pattern string, role Role) (RoleRule, error) {
+	rule := RoleRule{Domain: domain, Pattern: pattern, Role: role}
+
+	if pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return RoleRule{}, fmt.Errorf("compile pattern %q: %w", pattern, err)
+		}
+
+		rule.compiled = compiled
+	}
+
+	return rule, nil
+}
+
+// UnmarshalYAML compiles the pattern of rules loaded from the configuration. Invalid patterns fail the loading.
+func (rule *RoleRule) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain RoleRule
+
+	if err := unmarshal((*plain)(rule)); err != nil {
+		return err
+	}
+
+	compiled, err := NewRoleRule(rule.Domain, rule.Pattern, rule.Role)
+	if err != nil {
+		return err
+	}
+
+	*rule = compiled
+
+	return nil
+}
+
+func (rule RoleRule) Matches(email string) bool {
+	if rule.Domain != "" {
+		return strings.Contains(email, "@") && EmailDomain(email) == NormalizeEmailDomain(rule.Domain)
+	}
+
+	return rule.compiled != nil && rule.compiled.MatchString(email)
+}
+
+// RoleRules assign default roles to credentials, based on their email.
+type RoleRules struct {
+	// Rules are evaluated in order, and the first match wins.
+	Rules []RoleRule `yaml:"rules"`
+	// DemoteOnLeave removes the role given by a rule, once the email of the credentials no longer matches it.
+	DemoteOnLeave bool `yaml:"demoteOnLeave"`
+}
+
+// Match returns the role of the first rule matching the email, and whether any rule matched.
+func (rules RoleRules) Match(email string) (Role, bool) {
+	rule, ok := lo.Find(rules.Rules, func(item RoleRule) bool { return item.Matches(email) })
+
+	return rule.Role, ok
+}
+
+// Reassign returns the role of credentials whose email changes from previous to email. Credentials gain the role
+// matching their new email when it ranks above their current role. If DemoteOnLeave is set, credentials that hold
+// the role matching their previous email fall back to the role matching their new email, or to no role.
+func (rules RoleRules) Reassign(role Role, previous, email string) Role {
+	next, matched := rules.Match(email)
+	if matched && lo.IndexOf(RolesSorted, next) > lo.IndexOf(RolesSorted, role) {
+		return next
+	}
+
+	if !rules.DemoteOnLeave {
+		return role
+	}
+
+	if before, ok := rules.Match(previous); !ok || before != role {
+		return role
+	}
+
+	return next
+}
+
+func IsValidRoleRulePattern(pattern string) bool {
+	_, err := regexp.Compile(pattern)
+	return err == nil
+}
+
+func RegisterRoleRulePattern(customValidator *validator.Validate) {
+	database.MustRegisterValidation(customValidator, "role_rule_pattern", func(fl validator.FieldLevel) bool {
+		return IsValidRoleRulePattern(fl.Field().String())
+	})
+}
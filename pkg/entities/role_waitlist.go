@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// RoleCapacities limits the number of credentials that can hold a role in each tenant. Roles missing from the map
+// are unlimited.
+type RoleCapacities map[Role]int
+
+// Limit returns the capacity of the role, and whether it is limited.
+func (capacities RoleCapacities) Limit(role Role) (int, bool) {
+	// Every credential holds at least the default role, so it cannot be limited.
+	if role == RoleNone {
+		return 0, false
+	}
+
+	limit, ok := capacities[role]
+
+	return limit, ok
+}
+
+// RoleWaitlistEntry is a credential waiting for a seat of a limited role. Seats are handed out in the order the
+// credentials joined the waitlist.
+type RoleWaitlistEntry struct {
+	bun.BaseModel `bun:"table:role_waitlist,alias:role_waitlist"`
+
+	ID           uuid.UUID `bun:"id,pk,type:uuid"`
+	TenantID     string    `bun:"tenant_id"`
+	CredentialID uuid.UUID `bun:"credential_id,type:uuid"`
+	Role         Role      `bun:"role,type:credentials_role"`
+
+	// Seq orders the waitlist: lower values were enqueued first. It is assigned by the database.
+	Seq        int64     `bun:"seq,nullzero"`
+	EnqueuedAt time.Time `bun:"enqueued_at"`
+}
+
+// RoleWaitlistPosition is the place of a credential in the waitlist of a role.
+type RoleWaitlistPosition struct {
+	CredentialID uuid.UUID
+	Role         Role
+
+	// Position starts at 1 for the next credential to be promoted. It is 0 once the credential has been promoted.
+	Position int
+	// Waiting is the number of credentials in the waitlist of the role.
+	Waiting int
+
+	EnqueuedAt time.Time
+}
+
+// RoleSeats summarizes the occupation of a role in a tenant.
+type RoleSeats struct {
+	Role Role
+	// Capacity is 0 for unlimited roles.
+	Capacity int
+	// Used counts the credentials holding the role, including those that will revert to it after a temporary grant.
+	Used    int
+	Waiting int
+}
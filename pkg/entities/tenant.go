@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	ErrMissingTenant = errors.New("missing tenant")
+	ErrInvalidTenant = errors.New("invalid tenant")
+)
+
+// Tenant IDs are lowercase slugs, that can safely be passed around in metadata and database settings.
+var tenantIDRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,62}[a-z0-9])?$`)
+
+type tenantContextKey struct{}
+
+func ValidateTenantID(tenantID string) error {
+	if tenantID == "" {
+		return ErrMissingTenant
+	}
+
+	if !tenantIDRegexp.MatchString(tenantID) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidTenant, tenantID)
+	}
+
+	return nil
+}
+
+func ContextWithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+func TenantFromContext(ctx context.Context) (string, error) {
+	tenantID, _ := ctx.Value(tenantContextKey{}).(string)
+	if err := ValidateTenantID(tenantID); err != nil {
+		return "", err
+	}
+
+	return tenantID, nil
+}
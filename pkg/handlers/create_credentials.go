@@ -30,6 +30,9 @@ type createCredentialsImpl struct {
 var handleCreateCredentialsError = grpc.HandleError(codes.Internal).
 	Is(services.ErrInvalidCreateCredentialsRequest, codes.InvalidArgument).
 	Is(dao.ErrCredentialsAlreadyExist, codes.AlreadyExists).
+	Is(dao.ErrCanonicalEmailTaken, codes.AlreadyExists).
+	Is(dao.ErrRoleCapacityReached, codes.ResourceExhausted).
+	Is(dao.ErrEmailDomainNotAllowed, codes.PermissionDenied).
 	Handle
 
 func (handler *createCredentialsImpl) Exec(
@@ -97,6 +97,49 @@ func TestCreateCredentials(t *testing.T) {
 
 			expectCode: codes.AlreadyExists,
 		},
+		{
+			name: "AlreadyExists/CanonicalEmailTaken",
+
+			request: &credentialsv1.CreateServiceExecRequest{
+				Email:                  "email",
+				EmailValidationTokenId: "email-validation",
+				PasswordTokenId:        "password",
+				ResetPasswordTokenId:   "reset-password",
+			},
+
+			serviceErr: dao.ErrCanonicalEmailTaken,
+
+			expectCode: codes.AlreadyExists,
+		},
+		{
+			name: "ResourceExhausted",
+
+			request: &credentialsv1.CreateServiceExecRequest{
+				Email:                  "email",
+				Role:                   commonv1.UserRole_USER_ROLE_EARLY_ACCESS_PROGRAM,
+				EmailValidationTokenId: "email-validation",
+				PasswordTokenId:        "password",
+				ResetPasswordTokenId:   "reset-password",
+			},
+
+			serviceErr: dao.ErrRoleCapacityReached,
+
+			expectCode: codes.ResourceExhausted,
+		},
+		{
+			name: "PermissionDenied",
+
+			request: &credentialsv1.CreateServiceExecRequest{
+				Email:                  "email",
+				EmailValidationTokenId: "email-validation",
+				PasswordTokenId:        "password",
+				ResetPasswordTokenId:   "reset-password",
+			},
+
+			serviceErr: dao.ErrEmailDomainNotAllowed,
+
+			expectCode: codes.PermissionDenied,
+		},
 		{
 			name: "InternalError",
 
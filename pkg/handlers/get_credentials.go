@@ -53,6 +53,7 @@ func (handler *getCredentialsImpl) Exec(
 		ResetPasswordTokenId:          res.ResetPasswordTokenID,
 		CreatedAt:                     timestamppb.New(res.CreatedAt),
 		UpdatedAt:                     grpc.TimestampOptional(res.UpdatedAt),
+		// EmailVerifiedAt is not exposed: the credentials proto has no field for it yet.
 	}, nil
 }
 
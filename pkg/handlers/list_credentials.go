@@ -44,6 +44,7 @@ func credentialToListElementProto(
 		ResetPasswordTokenId:          item.ResetPasswordTokenID,
 		CreatedAt:                     timestamppb.New(item.CreatedAt),
 		UpdatedAt:                     grpc.TimestampOptional(item.UpdatedAt),
+		// EmailVerifiedAt is not exposed: the credentials proto has no field for it yet.
 	}
 }
 
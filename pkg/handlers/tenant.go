@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+// TenantMetadataKey is the gRPC metadata key used by clients to select the tenant of their requests.
+const TenantMetadataKey = "x-tenant-id"
+
+// Only the credentials services are scoped to a tenant. Other services, such as health checks or reflection, are
+// shared by every tenant.
+const tenantScopedServicesPrefix = "/credentials.v1."
+
+// NewTenantInterceptor resolves the tenant of each request from its metadata, and exposes it in the request context.
+// Requests with no tenant fall back to the default tenant, if one is provided.
+func NewTenantInterceptor(defaultTenantID string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, tenantScopedServicesPrefix) {
+			return handler(ctx, req)
+		}
+
+		tenantID := defaultTenantID
+		if values := metadata.ValueFromIncomingContext(ctx, TenantMetadataKey); len(values) > 0 {
+			tenantID = values[0]
+		}
+
+		if err := entities.ValidateTenantID(tenantID); err != nil {
+			if errors.Is(err, entities.ErrMissingTenant) {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return handler(entities.ContextWithTenant(ctx, tenantID), req)
+	}
+}
@@ -0,0 +1,101 @@
+package handlers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/handlers"
+)
+
+func TestTenantInterceptor(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		defaultTenantID string
+		fullMethod      string
+		metadata        metadata.MD
+
+		shouldCallHandler bool
+		expectTenantID    string
+		expectCode        codes.Code
+	}{
+		{
+			name: "FromMetadata",
+
+			defaultTenantID: "default",
+			fullMethod:      "/credentials.v1.GetService/Exec",
+			metadata:        metadata.Pairs(handlers.TenantMetadataKey, "partner-1"),
+
+			shouldCallHandler: true,
+			expectTenantID:    "partner-1",
+		},
+		{
+			name: "DefaultTenant",
+
+			defaultTenantID: "default",
+			fullMethod:      "/credentials.v1.GetService/Exec",
+
+			shouldCallHandler: true,
+			expectTenantID:    "default",
+		},
+		{
+			name: "NotScoped",
+
+			fullMethod: "/grpc.health.v1.Health/Check",
+
+			shouldCallHandler: true,
+		},
+		{
+			name: "MissingTenant",
+
+			fullMethod: "/credentials.v1.GetService/Exec",
+
+			expectCode: codes.Unauthenticated,
+		},
+		{
+			name: "InvalidTenant",
+
+			defaultTenantID: "default",
+			fullMethod:      "/credentials.v1.GetService/Exec",
+			metadata:        metadata.Pairs(handlers.TenantMetadataKey, "Partner 1"),
+
+			expectCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+			if testCase.metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, testCase.metadata)
+			}
+
+			var handlerCalled bool
+
+			interceptor := handlers.NewTenantInterceptor(testCase.defaultTenantID)
+			_, err := interceptor(
+				ctx,
+				nil,
+				&grpc.UnaryServerInfo{FullMethod: testCase.fullMethod},
+				func(ctx context.Context, _ interface{}) (interface{}, error) {
+					handlerCalled = true
+
+					tenantID, _ := entities.TenantFromContext(ctx)
+					require.Equal(t, testCase.expectTenantID, tenantID)
+
+					return nil, nil
+				},
+			)
+
+			require.Equal(t, testCase.expectCode, status.Code(err))
+			require.Equal(t, testCase.shouldCallHandler, handlerCalled)
+		})
+	}
+}
@@ -30,6 +30,12 @@ type updateCredentialsImpl struct {
 var handleUpdateCredentialsError = grpc.HandleError(codes.Internal).
 	Is(services.ErrInvalidUpdateCredentialsRequest, codes.InvalidArgument).
 	Is(dao.ErrCredentialsNotFound, codes.NotFound).
+	Is(dao.ErrCanonicalEmailTaken, codes.AlreadyExists).
+	Is(dao.ErrCredentialsOnLegalHold, codes.FailedPrecondition).
+	Is(dao.ErrLastProtectedRoleHolder, codes.FailedPrecondition).
+	Is(dao.ErrRoleCapacityReached, codes.ResourceExhausted).
+	Is(dao.ErrPrivilegedRoleChange, codes.PermissionDenied).
+	Is(dao.ErrEmailDomainNotAllowed, codes.PermissionDenied).
 	Handle
 
 func (handler *updateCredentialsImpl) Exec(
@@ -18,6 +18,7 @@ import (
 	adaptersmocks "github.com/a-novel/golib/loggers/adapters/mocks"
 	"github.com/a-novel/golib/testutils"
 
+	"github.com/a-novel/uservice-credentials/pkg/dao"
 	"github.com/a-novel/uservice-credentials/pkg/entities"
 	"github.com/a-novel/uservice-credentials/pkg/handlers"
 	"github.com/a-novel/uservice-credentials/pkg/services"
@@ -90,6 +91,106 @@ func TestUpdateCredentials(t *testing.T) {
 
 			expectCode: codes.InvalidArgument,
 		},
+		{
+			name: "FailedPrecondition",
+
+			request: &credentialsv1.UpdateServiceExecRequest{
+				Id:                            "id",
+				Email:                         "email",
+				Role:                          commonv1.UserRole_USER_ROLE_CORE,
+				EmailValidationTokenId:        "email-validation",
+				PendingEmailValidationTokenId: "pending-email-validation",
+				PasswordTokenId:               "password",
+				ResetPasswordTokenId:          "reset-password",
+			},
+
+			serviceErr: dao.ErrCredentialsOnLegalHold,
+
+			expectCode: codes.FailedPrecondition,
+		},
+		{
+			name: "AlreadyExists",
+
+			request: &credentialsv1.UpdateServiceExecRequest{
+				Id:                            "id",
+				Email:                         "email",
+				EmailValidationTokenId:        "email-validation",
+				PendingEmailValidationTokenId: "pending-email-validation",
+				PasswordTokenId:               "password",
+				ResetPasswordTokenId:          "reset-password",
+			},
+
+			serviceErr: dao.ErrCanonicalEmailTaken,
+
+			expectCode: codes.AlreadyExists,
+		},
+		{
+			name: "FailedPrecondition/LastProtectedRoleHolder",
+
+			request: &credentialsv1.UpdateServiceExecRequest{
+				Id:                            "id",
+				Email:                         "email",
+				Role:                          commonv1.UserRole_USER_ROLE_CORE,
+				EmailValidationTokenId:        "email-validation",
+				PendingEmailValidationTokenId: "pending-email-validation",
+				PasswordTokenId:               "password",
+				ResetPasswordTokenId:          "reset-password",
+			},
+
+			serviceErr: dao.ErrLastProtectedRoleHolder,
+
+			expectCode: codes.FailedPrecondition,
+		},
+		{
+			name: "ResourceExhausted",
+
+			request: &credentialsv1.UpdateServiceExecRequest{
+				Id:                            "id",
+				Email:                         "email",
+				Role:                          commonv1.UserRole_USER_ROLE_CORE,
+				EmailValidationTokenId:        "email-validation",
+				PendingEmailValidationTokenId: "pending-email-validation",
+				PasswordTokenId:               "password",
+				ResetPasswordTokenId:          "reset-password",
+			},
+
+			serviceErr: dao.ErrRoleCapacityReached,
+
+			expectCode: codes.ResourceExhausted,
+		},
+		{
+			name: "PermissionDenied",
+
+			request: &credentialsv1.UpdateServiceExecRequest{
+				Id:                            "id",
+				Email:                         "email",
+				Role:                          commonv1.UserRole_USER_ROLE_CORE,
+				EmailValidationTokenId:        "email-validation",
+				PendingEmailValidationTokenId: "pending-email-validation",
+				PasswordTokenId:               "password",
+				ResetPasswordTokenId:          "reset-password",
+			},
+
+			serviceErr: dao.ErrPrivilegedRoleChange,
+
+			expectCode: codes.PermissionDenied,
+		},
+		{
+			name: "PermissionDenied/EmailDomainNotAllowed",
+
+			request: &credentialsv1.UpdateServiceExecRequest{
+				Id:                            "id",
+				Email:                         "email",
+				EmailValidationTokenId:        "email-validation",
+				PendingEmailValidationTokenId: "pending-email-validation",
+				PasswordTokenId:               "password",
+				ResetPasswordTokenId:          "reset-password",
+			},
+
+			serviceErr: dao.ErrEmailDomainNotAllowed,
+
+			expectCode: codes.PermissionDenied,
+		},
 		{
 			name: "Internal",
 
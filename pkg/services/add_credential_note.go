@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidAddCredentialNoteRequest = errors.New("invalid add credential note request")
+	ErrAddCredentialNote               = errors.New("add credential note")
+)
+
+var addCredentialNoteValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type AddCredentialNoteRequest struct {
+	CredentialID string `validate:"required,len=36"`
+	// Author identifies the agent who writes the note.
+	Author string `validate:"required,max=256"`
+	Body   string `validate:"required,max=4096"`
+	Pinned bool
+}
+
+type AddCredentialNoteResponse struct {
+	ID           string
+	CredentialID string
+
+	Author string
+	Body   string
+	Pinned bool
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type AddCredentialNote interface {
+	Exec(ctx context.Context, data *AddCredentialNoteRequest) (*AddCredentialNoteResponse, error)
+}
+
+type addCredentialNoteImpl struct {
+	dao dao.AddCredentialNote
+}
+
+func (service *addCredentialNoteImpl) Exec(
+	ctx context.Context, data *AddCredentialNoteRequest,
+) (*AddCredentialNoteResponse, error) {
+	if err := addCredentialNoteValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidAddCredentialNoteRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidAddCredentialNoteRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	note, err := service.dao.Exec(ctx, uuid.New(), time.Now(), &dao.AddCredentialNoteRequest{
+		CredentialID: credentialID,
+		Author:       data.Author,
+		Body:         data.Body,
+		Pinned:       data.Pinned,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrAddCredentialNote, err)
+	}
+
+	return &AddCredentialNoteResponse{
+		ID:           note.ID.String(),
+		CredentialID: note.CredentialID.String(),
+
+		Author: note.Author,
+		Body:   note.Body,
+		Pinned: note.Pinned,
+
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}, nil
+}
+
+func NewAddCredentialNote(dao dao.AddCredentialNote) AddCredentialNote {
+	return &addCredentialNoteImpl{dao: dao}
+}
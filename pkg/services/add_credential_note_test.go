@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestAddCredentialNote(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.AddCredentialNoteRequest
+
+		shouldCallAddCredentialNoteDAO bool
+		addCredentialNoteDAOResponse   *entities.CredentialNote
+		addCredentialNoteDAOError      error
+
+		expect    *services.AddCredentialNoteResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.AddCredentialNoteRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+				Pinned:       true,
+			},
+
+			shouldCallAddCredentialNoteDAO: true,
+			addCredentialNoteDAOResponse: &entities.CredentialNote{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+				Pinned:       true,
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.AddCredentialNoteResponse{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+				Pinned:       true,
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.AddCredentialNoteRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+			},
+
+			shouldCallAddCredentialNoteDAO: true,
+			addCredentialNoteDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrAddCredentialNote,
+		},
+		{
+			name: "MissingAuthor",
+
+			request: &services.AddCredentialNoteRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Body:         "refund issued",
+			},
+
+			expectErr: services.ErrInvalidAddCredentialNoteRequest,
+		},
+		{
+			name: "MissingBody",
+
+			request: &services.AddCredentialNoteRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Author:       "agent@example.com",
+			},
+
+			expectErr: services.ErrInvalidAddCredentialNoteRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.AddCredentialNoteRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Author:       "agent@example.com",
+				Body:         "refund issued",
+			},
+
+			expectErr: services.ErrInvalidAddCredentialNoteRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			addCredentialNoteDAO := daomocks.NewMockAddCredentialNote(t)
+
+			if testCase.shouldCallAddCredentialNoteDAO {
+				addCredentialNoteDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.Anything,
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.AddCredentialNoteRequest{
+							CredentialID: uuid.MustParse(testCase.request.CredentialID),
+							Author:       testCase.request.Author,
+							Body:         testCase.request.Body,
+							Pinned:       testCase.request.Pinned,
+						},
+					).
+					Return(testCase.addCredentialNoteDAOResponse, testCase.addCredentialNoteDAOError)
+			}
+
+			service := services.NewAddCredentialNote(addCredentialNoteDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			addCredentialNoteDAO.AssertExpectations(t)
+		})
+	}
+}
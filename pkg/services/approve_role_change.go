@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidApproveRoleChangeRequest = errors.New("invalid approve role change request")
+	ErrApproveRoleChange               = errors.New("approve role change")
+)
+
+var approveRoleChangeValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ApproveRoleChangeRequest struct {
+	ID string `validate:"required,len=36"`
+	// ApprovedBy is the user credential approving the change. It must differ from the proposer, and from the
+	// credentials concerned.
+	ApprovedBy string `validate:"required,len=36"`
+}
+
+type ApproveRoleChangeResponse struct {
+	ID           string
+	CredentialID string
+	Role         entities.Role
+	Status       entities.RoleChangeRequestStatus
+
+	ProposedBy string
+	DecidedBy  string
+	ExpiresAt  time.Time
+	DecidedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type ApproveRoleChange interface {
+	Exec(ctx context.Context, data *ApproveRoleChangeRequest) (*ApproveRoleChangeResponse, error)
+}
+
+type approveRoleChangeImpl struct {
+	dao dao.ApproveRoleChange
+}
+
+func (service *approveRoleChangeImpl) Exec(
+	ctx context.Context, data *ApproveRoleChangeRequest,
+) (*ApproveRoleChangeResponse, error) {
+	if err := approveRoleChangeValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidApproveRoleChangeRequest, err)
+	}
+
+	requestID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidApproveRoleChangeRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err),
+		)
+	}
+
+	actorID, err := uuid.Parse(data.ApprovedBy)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidApproveRoleChangeRequest, fmt.Errorf("uuid value: '%s': %w", data.ApprovedBy, err),
+		)
+	}
+
+	request, err := service.dao.Exec(ctx, requestID, time.Now(), &dao.ApproveRoleChangeRequest{
+		ApprovedBy: actorID,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrApproveRoleChange, err)
+	}
+
+	return &ApproveRoleChangeResponse{
+		ID:           request.ID.String(),
+		CredentialID: request.CredentialID.String(),
+		Role:         request.Role,
+		Status:       request.Status,
+
+		ProposedBy: lo.Ternary(request.ProposedBy == uuid.Nil, "", request.ProposedBy.String()),
+		DecidedBy:  lo.FromPtr(request.DecidedBy).String(),
+		ExpiresAt:  request.ExpiresAt,
+		DecidedAt:  request.DecidedAt,
+		CreatedAt:  request.CreatedAt,
+	}, nil
+}
+
+func NewApproveRoleChange(dao dao.ApproveRoleChange) ApproveRoleChange {
+	return &approveRoleChangeImpl{dao: dao}
+}
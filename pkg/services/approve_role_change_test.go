@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestApproveRoleChange(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ApproveRoleChangeRequest
+
+		shouldCallApproveRoleChangeDAO bool
+		approveRoleChangeDAORequest    *dao.ApproveRoleChangeRequest
+		approveRoleChangeDAOResponse   *entities.RoleChangeRequest
+		approveRoleChangeDAOError      error
+
+		expect    *services.ApproveRoleChangeResponse
+		expectErr error
+	}{
+		{
+			name: "Approve",
+
+			request: &services.ApproveRoleChangeRequest{
+				ID:         "00000000-0000-0000-0007-000000000001",
+				ApprovedBy: "00000000-0000-0000-0000-000000000003",
+			},
+
+			shouldCallApproveRoleChangeDAO: true,
+			approveRoleChangeDAORequest: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+			approveRoleChangeDAOResponse: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusApproved,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000003")),
+				ExpiresAt:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+				DecidedAt:    lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.ApproveRoleChangeResponse{
+				ID:           "00000000-0000-0000-0007-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusApproved,
+				ProposedBy:   "00000000-0000-0000-0000-000000000001",
+				DecidedBy:    "00000000-0000-0000-0000-000000000003",
+				ExpiresAt:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+				DecidedAt:    lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.ApproveRoleChangeRequest{
+				ID:         "00000000-0000-0000-0007-000000000001",
+				ApprovedBy: "00000000-0000-0000-0000-000000000003",
+			},
+
+			shouldCallApproveRoleChangeDAO: true,
+			approveRoleChangeDAORequest: &dao.ApproveRoleChangeRequest{
+				ApprovedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+			approveRoleChangeDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrApproveRoleChange,
+		},
+		{
+			name: "MissingApprovedBy",
+
+			request: &services.ApproveRoleChangeRequest{
+				ID: "00000000-0000-0000-0007-000000000001",
+			},
+
+			expectErr: services.ErrInvalidApproveRoleChangeRequest,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.ApproveRoleChangeRequest{
+				ID:         "00000000x0000x0000x0007x000000000001",
+				ApprovedBy: "00000000-0000-0000-0000-000000000003",
+			},
+
+			expectErr: services.ErrInvalidApproveRoleChangeRequest,
+		},
+		{
+			name: "InvalidApprovedBy",
+
+			request: &services.ApproveRoleChangeRequest{
+				ID:         "00000000-0000-0000-0007-000000000001",
+				ApprovedBy: "00000000x0000x0000x0000x000000000003",
+			},
+
+			expectErr: services.ErrInvalidApproveRoleChangeRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			approveRoleChangeDAO := daomocks.NewMockApproveRoleChange(t)
+
+			if testCase.shouldCallApproveRoleChangeDAO {
+				approveRoleChangeDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.approveRoleChangeDAORequest,
+					).
+					Return(testCase.approveRoleChangeDAOResponse, testCase.approveRoleChangeDAOError)
+			}
+
+			service := services.NewApproveRoleChange(approveRoleChangeDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			approveRoleChangeDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var ErrBackfillCanonicalEmails = errors.New("backfill canonical emails")
+
+type BackfillCanonicalEmailsResponse struct {
+	Backfilled int
+}
+
+type BackfillCanonicalEmails interface {
+	Exec(ctx context.Context) (*BackfillCanonicalEmailsResponse, error)
+}
+
+type backfillCanonicalEmailsImpl struct {
+	dao dao.BackfillCanonicalEmails
+	// batchSize is the number of credentials backfilled per transaction.
+	batchSize int
+}
+
+// Exec backfills batches of credentials, until a batch comes back incomplete.
+func (service *backfillCanonicalEmailsImpl) Exec(ctx context.Context) (*BackfillCanonicalEmailsResponse, error) {
+	response := new(BackfillCanonicalEmailsResponse)
+
+	for {
+		credentials, err := service.dao.Exec(ctx, service.batchSize)
+		if err != nil {
+			return nil, errors.Join(ErrBackfillCanonicalEmails, err)
+		}
+
+		response.Backfilled += len(credentials)
+
+		if len(credentials) < service.batchSize {
+			return response, nil
+		}
+	}
+}
+
+func NewBackfillCanonicalEmails(dao dao.BackfillCanonicalEmails, batchSize int) BackfillCanonicalEmails {
+	return &backfillCanonicalEmailsImpl{dao: dao, batchSize: batchSize}
+}
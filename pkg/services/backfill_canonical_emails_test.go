@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestBackfillCanonicalEmails(t *testing.T) {
+	credential := &entities.Credential{
+		ID:             uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		TenantID:       "default",
+		Email:          "jane@bücher.example",
+		CanonicalEmail: "jane@xn--bcher-kva.example",
+	}
+
+	testCases := []struct {
+		name string
+
+		batchSize int
+
+		// backfillCanonicalEmailsDAOResponses are returned by the successive calls to the DAO.
+		backfillCanonicalEmailsDAOResponses [][]*entities.Credential
+		backfillCanonicalEmailsDAOError     error
+
+		expect    *services.BackfillCanonicalEmailsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			batchSize: 2,
+
+			backfillCanonicalEmailsDAOResponses: [][]*entities.Credential{
+				{credential, credential},
+				{credential},
+			},
+
+			expect: &services.BackfillCanonicalEmailsResponse{Backfilled: 3},
+		},
+		{
+			name: "OK/FullLastBatch",
+
+			batchSize: 2,
+
+			backfillCanonicalEmailsDAOResponses: [][]*entities.Credential{
+				{credential, credential},
+				{},
+			},
+
+			expect: &services.BackfillCanonicalEmailsResponse{Backfilled: 2},
+		},
+		{
+			name: "OK/Nothing",
+
+			batchSize: 2,
+
+			backfillCanonicalEmailsDAOResponses: [][]*entities.Credential{{}},
+
+			expect: &services.BackfillCanonicalEmailsResponse{Backfilled: 0},
+		},
+		{
+			name: "DAO/Error",
+
+			batchSize: 2,
+
+			backfillCanonicalEmailsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrBackfillCanonicalEmails,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			backfillCanonicalEmailsDAO := daomocks.NewMockBackfillCanonicalEmails(t)
+
+			for _, response := range testCase.backfillCanonicalEmailsDAOResponses {
+				backfillCanonicalEmailsDAO.
+					On("Exec", context.Background(), testCase.batchSize).
+					Return(response, nil).
+					Once()
+			}
+
+			if testCase.backfillCanonicalEmailsDAOError != nil {
+				backfillCanonicalEmailsDAO.
+					On("Exec", context.Background(), testCase.batchSize).
+					Return(nil, testCase.backfillCanonicalEmailsDAOError).
+					Once()
+			}
+
+			service := services.NewBackfillCanonicalEmails(backfillCanonicalEmailsDAO, testCase.batchSize)
+			response, err := service.Exec(context.Background())
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			backfillCanonicalEmailsDAO.AssertExpectations(t)
+		})
+	}
+}
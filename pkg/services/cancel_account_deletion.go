@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidCancelAccountDeletionRequest = errors.New("invalid cancel account deletion request")
+	ErrCancelAccountDeletion               = errors.New("cancel account deletion")
+)
+
+var cancelAccountDeletionValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type CancelAccountDeletionRequest struct {
+	ID string `validate:"required,len=36"`
+}
+
+type CancelAccountDeletionResponse struct {
+	ID        string
+	UpdatedAt *time.Time
+}
+
+type CancelAccountDeletion interface {
+	Exec(ctx context.Context, data *CancelAccountDeletionRequest) (*CancelAccountDeletionResponse, error)
+}
+
+type cancelAccountDeletionImpl struct {
+	dao dao.CancelAccountDeletion
+}
+
+func (service *cancelAccountDeletionImpl) Exec(
+	ctx context.Context, data *CancelAccountDeletionRequest,
+) (*CancelAccountDeletionResponse, error) {
+	if err := cancelAccountDeletionValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidCancelAccountDeletionRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidCancelAccountDeletionRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err),
+		)
+	}
+
+	credentials, err := service.dao.Exec(ctx, credentialsID, time.Now())
+	if err != nil {
+		return nil, errors.Join(ErrCancelAccountDeletion, err)
+	}
+
+	return &CancelAccountDeletionResponse{
+		ID:        credentials.ID.String(),
+		UpdatedAt: credentials.UpdatedAt,
+	}, nil
+}
+
+func NewCancelAccountDeletion(dao dao.CancelAccountDeletion) CancelAccountDeletion {
+	return &cancelAccountDeletionImpl{dao: dao}
+}
@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestCancelAccountDeletion(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.CancelAccountDeletionRequest
+
+		shouldCallCancelAccountDeletionDAO bool
+		cancelAccountDeletionDAOResponse   *entities.Credential
+		cancelAccountDeletionDAOError      error
+
+		expect    *services.CancelAccountDeletionResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.CancelAccountDeletionRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallCancelAccountDeletionDAO: true,
+			cancelAccountDeletionDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:     "user@gmail.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.CancelAccountDeletionResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.CancelAccountDeletionRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallCancelAccountDeletionDAO: true,
+			cancelAccountDeletionDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrCancelAccountDeletion,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.CancelAccountDeletionRequest{
+				ID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidCancelAccountDeletionRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cancelAccountDeletionDAO := daomocks.NewMockCancelAccountDeletion(t)
+
+			if testCase.shouldCallCancelAccountDeletionDAO {
+				cancelAccountDeletionDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+					).
+					Return(testCase.cancelAccountDeletionDAOResponse, testCase.cancelAccountDeletionDAOError)
+			}
+
+			service := services.NewCancelAccountDeletion(cancelAccountDeletionDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			cancelAccountDeletionDAO.AssertExpectations(t)
+		})
+	}
+}
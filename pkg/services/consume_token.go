@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidConsumeTokenRequest = errors.New("invalid consume token request")
+	ErrConsumeToken               = errors.New("consume token")
+)
+
+var consumeTokenValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterTokenField(consumeTokenValidate)
+}
+
+type ConsumeTokenRequest struct {
+	ID    string              `validate:"required,len=36"`
+	Field entities.TokenField `validate:"required,token_field"`
+	Value string              `validate:"required,min=1,max=128"`
+}
+
+type ConsumeTokenResponse struct {
+	ID    string
+	Email string
+	Role  entities.Role
+
+	EmailVerifiedAt *time.Time
+
+	Labels      map[string]string
+	MFARequired bool
+	TokenEpoch  int64
+
+	EmailValidationTokenID        string
+	PendingEmailValidationTokenID string
+	PasswordTokenID               string
+	ResetPasswordTokenID          string
+
+	EmailValidationTokenExpiresAt        *time.Time
+	PendingEmailValidationTokenExpiresAt *time.Time
+	PasswordTokenExpiresAt               *time.Time
+	ResetPasswordTokenExpiresAt          *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type ConsumeToken interface {
+	Exec(ctx context.Context, data *ConsumeTokenRequest) (*ConsumeTokenResponse, error)
+}
+
+type consumeTokenImpl struct {
+	dao dao.ConsumeToken
+}
+
+func (service *consumeTokenImpl) Exec(
+	ctx context.Context, data *ConsumeTokenRequest,
+) (*ConsumeTokenResponse, error) {
+	if err := consumeTokenValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidConsumeTokenRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidConsumeTokenRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	now := time.Now()
+
+	credentials, err := service.dao.Exec(ctx, credentialsID, now, &dao.ConsumeTokenRequest{
+		Field: data.Field,
+		Value: data.Value,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrConsumeToken, err)
+	}
+
+	credentials.ClearExpiredTokens(now)
+
+	return &ConsumeTokenResponse{
+		ID:    credentials.ID.String(),
+		Email: credentials.Email,
+		Role:  credentials.Role,
+
+		EmailVerifiedAt: credentials.EmailVerifiedAt,
+
+		Labels:      credentials.Labels,
+		MFARequired: credentials.MFARequired,
+		TokenEpoch:  credentials.TokenEpoch,
+
+		EmailValidationTokenID:        credentials.EmailValidationTokenID,
+		PendingEmailValidationTokenID: credentials.PendingEmailValidationTokenID,
+		PasswordTokenID:               credentials.PasswordTokenID,
+		ResetPasswordTokenID:          credentials.ResetPasswordTokenID,
+
+		EmailValidationTokenExpiresAt:        credentials.EmailValidationTokenExpiresAt,
+		PendingEmailValidationTokenExpiresAt: credentials.PendingEmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:               credentials.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:          credentials.ResetPasswordTokenExpiresAt,
+
+		CreatedAt: credentials.CreatedAt,
+		UpdatedAt: credentials.UpdatedAt,
+	}, nil
+}
+
+func NewConsumeToken(dao dao.ConsumeToken) ConsumeToken {
+	return &consumeTokenImpl{dao: dao}
+}
@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestConsumeToken(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ConsumeTokenRequest
+
+		shouldCallConsumeTokenDAO bool
+		consumeTokenDAOResponse   *entities.Credential
+		consumeTokenDAOError      error
+
+		expect    *services.ConsumeTokenResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ConsumeTokenRequest{
+				ID:    "00000000-0000-0000-0000-000000000004",
+				Field: entities.TokenFieldResetPassword,
+				Value: "00000000-0000-0000-0000-000000000003",
+			},
+
+			shouldCallConsumeTokenDAO: true,
+			consumeTokenDAOResponse: &entities.Credential{
+				ID:                     uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:                  "user@gmail.com",
+				Role:                   entities.RoleAdmin,
+				EmailValidationTokenID: "00000000-0000-0000-0000-000000000001",
+				PasswordTokenID:        "00000000-0000-0000-0000-000000000002",
+				CreatedAt:              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:              lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.ConsumeTokenResponse{
+				ID:                     "00000000-0000-0000-0000-000000000004",
+				Email:                  "user@gmail.com",
+				Role:                   entities.RoleAdmin,
+				EmailValidationTokenID: "00000000-0000-0000-0000-000000000001",
+				PasswordTokenID:        "00000000-0000-0000-0000-000000000002",
+				CreatedAt:              time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:              lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAO/Mismatch",
+
+			request: &services.ConsumeTokenRequest{
+				ID:    "00000000-0000-0000-0000-000000000004",
+				Field: entities.TokenFieldResetPassword,
+				Value: "00000000-0000-0000-0000-000000000003",
+			},
+
+			shouldCallConsumeTokenDAO: true,
+			consumeTokenDAOError:      dao.ErrTokenMismatch,
+
+			expectErr: dao.ErrTokenMismatch,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.ConsumeTokenRequest{
+				ID:    "00000000-0000-0000-0000-000000000004",
+				Field: entities.TokenFieldResetPassword,
+				Value: "00000000-0000-0000-0000-000000000003",
+			},
+
+			shouldCallConsumeTokenDAO: true,
+			consumeTokenDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrConsumeToken,
+		},
+		{
+			name: "Invalid/NoValue",
+
+			request: &services.ConsumeTokenRequest{
+				ID:    "00000000-0000-0000-0000-000000000004",
+				Field: entities.TokenFieldResetPassword,
+			},
+
+			expectErr: services.ErrInvalidConsumeTokenRequest,
+		},
+		{
+			name: "Invalid/Field",
+
+			request: &services.ConsumeTokenRequest{
+				ID:    "00000000-0000-0000-0000-000000000004",
+				Field: entities.TokenField("fake-field"),
+				Value: "00000000-0000-0000-0000-000000000003",
+			},
+
+			expectErr: services.ErrInvalidConsumeTokenRequest,
+		},
+		{
+			name: "Invalid/ID",
+
+			request: &services.ConsumeTokenRequest{
+				ID:    "00000000x0000x0000x0000x000000000004",
+				Field: entities.TokenFieldResetPassword,
+				Value: "00000000-0000-0000-0000-000000000003",
+			},
+
+			expectErr: services.ErrInvalidConsumeTokenRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			consumeTokenDAO := daomocks.NewMockConsumeToken(t)
+
+			if testCase.shouldCallConsumeTokenDAO {
+				consumeTokenDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.ConsumeTokenRequest{
+							Field: testCase.request.Field,
+							Value: testCase.request.Value,
+						},
+					).
+					Return(testCase.consumeTokenDAOResponse, testCase.consumeTokenDAOError)
+			}
+
+			service := services.NewConsumeToken(consumeTokenDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			consumeTokenDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var ErrCountRoleSeats = errors.New("count role seats")
+
+type CountRoleSeatsResponseRole struct {
+	Role entities.Role
+	// Capacity is the number of seats of the role, or 0 if the role is not limited.
+	Capacity int
+	Used     int
+	Waiting  int
+}
+
+type CountRoleSeatsResponse struct {
+	Roles []*CountRoleSeatsResponseRole
+}
+
+type CountRoleSeats interface {
+	Exec(ctx context.Context) (*CountRoleSeatsResponse, error)
+}
+
+type countRoleSeatsImpl struct {
+	dao dao.CountRoleSeats
+}
+
+func (service *countRoleSeatsImpl) Exec(ctx context.Context) (*CountRoleSeatsResponse, error) {
+	seats, err := service.dao.Exec(ctx)
+	if err != nil {
+		return nil, errors.Join(ErrCountRoleSeats, err)
+	}
+
+	return &CountRoleSeatsResponse{
+		Roles: lo.Map(seats, func(item *entities.RoleSeats, _ int) *CountRoleSeatsResponseRole {
+			return &CountRoleSeatsResponseRole{
+				Role:     item.Role,
+				Capacity: item.Capacity,
+				Used:     item.Used,
+				Waiting:  item.Waiting,
+			}
+		}),
+	}, nil
+}
+
+func NewCountRoleSeats(dao dao.CountRoleSeats) CountRoleSeats {
+	return &countRoleSeatsImpl{dao: dao}
+}
@@ -0,0 +1,73 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestCountRoleSeats(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		countRoleSeatsDAOResponse []*entities.RoleSeats
+		countRoleSeatsDAOError    error
+
+		expect    *services.CountRoleSeatsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			countRoleSeatsDAOResponse: []*entities.RoleSeats{
+				{Role: entities.RoleEarlyAccessProgram, Capacity: 100, Used: 100, Waiting: 12},
+				{Role: entities.RoleCore, Used: 3, Waiting: 1},
+			},
+
+			expect: &services.CountRoleSeatsResponse{
+				Roles: []*services.CountRoleSeatsResponseRole{
+					{Role: entities.RoleEarlyAccessProgram, Capacity: 100, Used: 100, Waiting: 12},
+					{Role: entities.RoleCore, Used: 3, Waiting: 1},
+				},
+			},
+		},
+		{
+			name: "OK/Nothing",
+
+			expect: &services.CountRoleSeatsResponse{
+				Roles: []*services.CountRoleSeatsResponseRole{},
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			countRoleSeatsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrCountRoleSeats,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			countRoleSeatsDAO := daomocks.NewMockCountRoleSeats(t)
+
+			countRoleSeatsDAO.
+				On("Exec", context.Background()).
+				Return(testCase.countRoleSeatsDAOResponse, testCase.countRoleSeatsDAOError)
+
+			service := services.NewCountRoleSeats(countRoleSeatsDAO)
+			response, err := service.Exec(context.Background())
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			countRoleSeatsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 
 	"github.com/a-novel/uservice-credentials/pkg/dao"
 	"github.com/a-novel/uservice-credentials/pkg/entities"
@@ -21,14 +22,23 @@ var createCredentialsValidate = validator.New(validator.WithRequiredStructEnable
 
 func init() {
 	entities.RegisterRole(createCredentialsValidate)
+	entities.RegisterLabelKey(createCredentialsValidate)
+	entities.RegisterEmail(createCredentialsValidate)
 }
 
 type CreateCredentialsRequest struct {
-	Email                  string        `validate:"required,email,max=256"`
+	Email                  string        `validate:"required,email_address,max=256"`
 	Role                   entities.Role `validate:"omitempty,role"`
 	EmailValidationTokenID string        `validate:"omitempty,min=1,max=128"`
 	PasswordTokenID        string        `validate:"omitempty,min=1,max=128"`
 	ResetPasswordTokenID   string        `validate:"omitempty,min=1,max=128"`
+
+	Labels      map[string]string `validate:"omitempty,max=64,dive,keys,label_key,endkeys,max=256"`
+	MFARequired bool
+
+	EmailValidationTokenExpiresAt *time.Time `validate:"excluded_without=EmailValidationTokenID"`
+	PasswordTokenExpiresAt        *time.Time `validate:"excluded_without=PasswordTokenID"`
+	ResetPasswordTokenExpiresAt   *time.Time `validate:"excluded_without=ResetPasswordTokenID"`
 }
 
 type CreateCredentialsResponse struct {
@@ -36,10 +46,17 @@ type CreateCredentialsResponse struct {
 	Email string
 	Role  entities.Role
 
+	Labels      map[string]string
+	MFARequired bool
+
 	EmailValidationTokenID string
 	PasswordTokenID        string
 	ResetPasswordTokenID   string
 
+	EmailValidationTokenExpiresAt *time.Time
+	PasswordTokenExpiresAt        *time.Time
+	ResetPasswordTokenExpiresAt   *time.Time
+
 	CreatedAt time.Time
 }
 
@@ -49,6 +66,10 @@ type CreateCredentials interface {
 
 type createCredentialsImpl struct {
 	dao dao.CreateCredentials
+	// rules assign a default role to the credentials, when the request does not set one.
+	rules entities.RoleRules
+	// privileged roles are never assigned by rules.
+	privileged []entities.Role
 }
 
 func (service *createCredentialsImpl) Exec(
@@ -58,12 +79,32 @@ func (service *createCredentialsImpl) Exec(
 		return nil, errors.Join(ErrInvalidCreateCredentialsRequest, err)
 	}
 
+	email := entities.NormalizeEmail(data.Email)
+
+	// The email is not verified yet, so it cannot grant a privileged role on its own.
+	role := data.Role
+	roleFromRule := false
+
+	if role == entities.RoleNone {
+		if matched, ok := service.rules.Match(email); ok && !lo.Contains(service.privileged, matched) {
+			role = matched
+			roleFromRule = true
+		}
+	}
+
 	request := &dao.CreateCredentialsRequest{
-		Email:                  data.Email,
-		Role:                   data.Role,
+		Email:                  email,
+		Role:                   role,
+		RoleFromRule:           roleFromRule,
+		Labels:                 data.Labels,
+		MFARequired:            data.MFARequired,
 		EmailValidationTokenID: data.EmailValidationTokenID,
 		PasswordTokenID:        data.PasswordTokenID,
 		ResetPasswordTokenID:   data.ResetPasswordTokenID,
+
+		EmailValidationTokenExpiresAt: data.EmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:        data.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:   data.ResetPasswordTokenExpiresAt,
 	}
 
 	res, err := service.dao.Exec(ctx, uuid.New(), time.Now(), request)
@@ -76,14 +117,23 @@ func (service *createCredentialsImpl) Exec(
 		Email: res.Email,
 		Role:  res.Role,
 
+		Labels:      res.Labels,
+		MFARequired: res.MFARequired,
+
 		EmailValidationTokenID: res.EmailValidationTokenID,
 		PasswordTokenID:        res.PasswordTokenID,
 		ResetPasswordTokenID:   res.ResetPasswordTokenID,
 
+		EmailValidationTokenExpiresAt: res.EmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:        res.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:   res.ResetPasswordTokenExpiresAt,
+
 		CreatedAt: res.CreatedAt,
 	}, nil
 }
 
-func NewCreateCredentials(dao dao.CreateCredentials) CreateCredentials {
-	return &createCredentialsImpl{dao: dao}
+func NewCreateCredentials(
+	dao dao.CreateCredentials, rules entities.RoleRules, privileged []entities.Role,
+) CreateCredentials {
+	return &createCredentialsImpl{dao: dao, rules: rules, privileged: privileged}
 }
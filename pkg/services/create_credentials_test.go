@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -22,10 +23,15 @@ func TestCreateCredentials(t *testing.T) {
 		name string
 
 		request *services.CreateCredentialsRequest
+		rules   entities.RoleRules
 
 		shouldCallCreateCredentialsDAO bool
-		createCredentialsDAOResponse   *entities.Credential
-		createCredentialsDAOError      error
+		// createCredentialsDAOEmail is the email expected by the DAO, when it differs from the requested one.
+		createCredentialsDAOEmail string
+		// createCredentialsDAORole is the role expected by the DAO, when a role rule assigns it.
+		createCredentialsDAORole     entities.Role
+		createCredentialsDAOResponse *entities.Credential
+		createCredentialsDAOError    error
 
 		expect    *services.CreateCredentialsResponse
 		expectErr error
@@ -85,6 +91,186 @@ func TestCreateCredentials(t *testing.T) {
 				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 			},
 		},
+		{
+			name: "OK/InternationalEmail",
+
+			request: &services.CreateCredentialsRequest{
+				Email: "Jose\u0301@Bücher.de",
+			},
+
+			shouldCallCreateCredentialsDAO: true,
+			createCredentialsDAOEmail:      "Jos\u00e9@xn--bcher-kva.de",
+			createCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:     "Jos\u00e9@xn--bcher-kva.de",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "Jos\u00e9@xn--bcher-kva.de",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/TokensExpiration",
+
+			request: &services.CreateCredentialsRequest{
+				Email:                         "user@gmail.com",
+				Role:                          entities.RoleNone,
+				EmailValidationTokenID:        "00000000-0000-0000-0000-000000000001",
+				EmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			shouldCallCreateCredentialsDAO: true,
+			createCredentialsDAOResponse: &entities.Credential{
+				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:                         "user@gmail.com",
+				Role:                          entities.RoleNone,
+				EmailValidationTokenID:        "00000000-0000-0000-0000-000000000001",
+				EmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateCredentialsResponse{
+				ID:                            "00000000-0000-0000-0000-000000000004",
+				Email:                         "user@gmail.com",
+				Role:                          entities.RoleNone,
+				EmailValidationTokenID:        "00000000-0000-0000-0000-000000000001",
+				EmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/Labels",
+
+			request: &services.CreateCredentialsRequest{
+				Email:  "user@gmail.com",
+				Role:   entities.RoleNone,
+				Labels: map[string]string{"signup-source": "ads", "beta.cohort": "1"},
+			},
+
+			shouldCallCreateCredentialsDAO: true,
+			createCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:     "user@gmail.com",
+				Role:      entities.RoleNone,
+				Labels:    map[string]string{"signup-source": "ads", "beta.cohort": "1"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "user@gmail.com",
+				Role:      entities.RoleNone,
+				Labels:    map[string]string{"signup-source": "ads", "beta.cohort": "1"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/MFARequired",
+
+			request: &services.CreateCredentialsRequest{
+				Email:       "user@gmail.com",
+				Role:        entities.RoleNone,
+				MFARequired: true,
+			},
+
+			shouldCallCreateCredentialsDAO: true,
+			createCredentialsDAOResponse: &entities.Credential{
+				ID:          uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:       "user@gmail.com",
+				Role:        entities.RoleNone,
+				MFARequired: true,
+				CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateCredentialsResponse{
+				ID:          "00000000-0000-0000-0000-000000000004",
+				Email:       "user@gmail.com",
+				Role:        entities.RoleNone,
+				MFARequired: true,
+				CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/RoleRules",
+
+			request: &services.CreateCredentialsRequest{
+				Email: "jane@staff.example.com",
+			},
+			rules: entities.RoleRules{
+				Rules: []entities.RoleRule{
+					{Domain: "example.com", Role: entities.RoleEarlyAccessProgram},
+					{Domain: "staff.example.com", Role: entities.RoleCore},
+				},
+			},
+
+			shouldCallCreateCredentialsDAO: true,
+			createCredentialsDAORole:       entities.RoleCore,
+			createCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:     "jane@staff.example.com",
+				Role:      entities.RoleCore,
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "jane@staff.example.com",
+				Role:      entities.RoleCore,
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/RoleRules/Privileged",
+
+			request: &services.CreateCredentialsRequest{
+				Email: "jane@staff.example.com",
+			},
+			rules: entities.RoleRules{
+				Rules: []entities.RoleRule{{Domain: "staff.example.com", Role: entities.RoleAdmin}},
+			},
+
+			shouldCallCreateCredentialsDAO: true,
+			createCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:     "jane@staff.example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "jane@staff.example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/RoleRules/ExplicitRole",
+
+			request: &services.CreateCredentialsRequest{
+				Email: "jane@staff.example.com",
+				Role:  entities.RoleEarlyAccessProgram,
+			},
+			rules: entities.RoleRules{
+				Rules: []entities.RoleRule{{Domain: "staff.example.com", Role: entities.RoleCore}},
+			},
+
+			shouldCallCreateCredentialsDAO: true,
+			createCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:     "jane@staff.example.com",
+				Role:      entities.RoleEarlyAccessProgram,
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "jane@staff.example.com",
+				Role:      entities.RoleEarlyAccessProgram,
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
 		{
 			name: "DAO/Error",
 
@@ -101,6 +287,17 @@ func TestCreateCredentials(t *testing.T) {
 
 			expectErr: services.ErrCreateCredentials,
 		},
+		{
+			name: "Invalid/ExpirationWithoutToken",
+
+			request: &services.CreateCredentialsRequest{
+				Email:                       "user@gmail.com",
+				Role:                        entities.RoleNone,
+				ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expectErr: services.ErrInvalidCreateCredentialsRequest,
+		},
 		{
 			name: "Invalid/EmailMissing",
 
@@ -139,6 +336,37 @@ func TestCreateCredentials(t *testing.T) {
 
 			expectErr: services.ErrInvalidCreateCredentialsRequest,
 		},
+		{
+			name: "Invalid/EmailDomain",
+
+			request: &services.CreateCredentialsRequest{
+				Email: "user@bücher_de",
+			},
+
+			expectErr: services.ErrInvalidCreateCredentialsRequest,
+		},
+		{
+			name: "Invalid/LabelKey",
+
+			request: &services.CreateCredentialsRequest{
+				Email:  "user@gmail.com",
+				Role:   entities.RoleNone,
+				Labels: map[string]string{"Signup Source": "ads"},
+			},
+
+			expectErr: services.ErrInvalidCreateCredentialsRequest,
+		},
+		{
+			name: "Invalid/LabelValueTooLong",
+
+			request: &services.CreateCredentialsRequest{
+				Email:  "user@gmail.com",
+				Role:   entities.RoleNone,
+				Labels: map[string]string{"signup-source": strings.Repeat("a", 257)},
+			},
+
+			expectErr: services.ErrInvalidCreateCredentialsRequest,
+		},
 		{
 			name: "Invalid/Role",
 
@@ -166,17 +394,26 @@ func TestCreateCredentials(t *testing.T) {
 						mock.MatchedBy(func(id uuid.UUID) bool { return id != uuid.Nil }),
 						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
 						&dao.CreateCredentialsRequest{
-							Email:                  testCase.request.Email,
-							Role:                   testCase.request.Role,
+							Email:                  lo.CoalesceOrEmpty(testCase.createCredentialsDAOEmail, testCase.request.Email),
+							Role:                   lo.CoalesceOrEmpty(testCase.createCredentialsDAORole, testCase.request.Role),
+							RoleFromRule:           testCase.createCredentialsDAORole != "",
+							Labels:                 testCase.request.Labels,
+							MFARequired:            testCase.request.MFARequired,
 							EmailValidationTokenID: testCase.request.EmailValidationTokenID,
 							PasswordTokenID:        testCase.request.PasswordTokenID,
 							ResetPasswordTokenID:   testCase.request.ResetPasswordTokenID,
+
+							EmailValidationTokenExpiresAt: testCase.request.EmailValidationTokenExpiresAt,
+							PasswordTokenExpiresAt:        testCase.request.PasswordTokenExpiresAt,
+							ResetPasswordTokenExpiresAt:   testCase.request.ResetPasswordTokenExpiresAt,
 						},
 					).
 					Return(testCase.createCredentialsDAOResponse, testCase.createCredentialsDAOError)
 			}
 
-			service := services.NewCreateCredentials(createCredentialsDAO)
+			service := services.NewCreateCredentials(
+				createCredentialsDAO, testCase.rules, []entities.Role{entities.RoleAdmin},
+			)
 			response, err := service.Exec(context.Background(), testCase.request)
 
 			require.ErrorIs(t, err, testCase.expectErr)
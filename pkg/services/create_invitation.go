@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidCreateInvitationRequest = errors.New("invalid create invitation request")
+	ErrCreateInvitation               = errors.New("create invitation")
+)
+
+var createInvitationValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterRole(createInvitationValidate)
+}
+
+type CreateInvitationRequest struct {
+	// CreatedBy is the user credential issuing the invitation.
+	CreatedBy string `validate:"required,len=36"`
+	// Role cannot be privileged. Privileged roles require an approved role change request.
+	Role entities.Role `validate:"required,role"`
+	// MaxUses is the number of credentials that can redeem the code. Use 1 for a single-use invitation.
+	MaxUses int `validate:"required,min=1,max=100000"`
+	// ExpiresAt must be in the future. Leave nil for an invitation that never expires.
+	ExpiresAt *time.Time `validate:"omitempty,gt"`
+}
+
+type CreateInvitationResponse struct {
+	ID      string
+	Code    string
+	Role    entities.Role
+	MaxUses int
+	Uses    int
+
+	CreatedBy string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+type CreateInvitation interface {
+	Exec(ctx context.Context, data *CreateInvitationRequest) (*CreateInvitationResponse, error)
+}
+
+type createInvitationImpl struct {
+	dao dao.CreateInvitation
+}
+
+func (service *createInvitationImpl) Exec(
+	ctx context.Context, data *CreateInvitationRequest,
+) (*CreateInvitationResponse, error) {
+	if err := createInvitationValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidCreateInvitationRequest, err)
+	}
+
+	createdBy, err := uuid.Parse(data.CreatedBy)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidCreateInvitationRequest, fmt.Errorf("uuid value: '%s': %w", data.CreatedBy, err),
+		)
+	}
+
+	code, err := entities.GenerateInvitationCode()
+	if err != nil {
+		return nil, errors.Join(ErrCreateInvitation, err)
+	}
+
+	invitation, err := service.dao.Exec(ctx, uuid.New(), time.Now(), &dao.CreateInvitationRequest{
+		Code:      code,
+		Role:      data.Role,
+		MaxUses:   data.MaxUses,
+		CreatedBy: createdBy,
+		ExpiresAt: data.ExpiresAt,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrCreateInvitation, err)
+	}
+
+	return &CreateInvitationResponse{
+		ID:      invitation.ID.String(),
+		Code:    invitation.Code,
+		Role:    invitation.Role,
+		MaxUses: invitation.MaxUses,
+		Uses:    invitation.Uses,
+
+		CreatedBy: invitation.CreatedBy.String(),
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}, nil
+}
+
+func NewCreateInvitation(dao dao.CreateInvitation) CreateInvitation {
+	return &createInvitationImpl{dao: dao}
+}
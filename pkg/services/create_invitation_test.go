@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestCreateInvitation(t *testing.T) {
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	testCases := []struct {
+		name string
+
+		request *services.CreateInvitationRequest
+
+		shouldCallCreateInvitationDAO bool
+		createInvitationDAOResponse   *entities.Invitation
+		createInvitationDAOError      error
+
+		expect    *services.CreateInvitationResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.CreateInvitationRequest{
+				CreatedBy: "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   10,
+				ExpiresAt: lo.ToPtr(expiresAt),
+			},
+
+			shouldCallCreateInvitationDAO: true,
+			createInvitationDAOResponse: &entities.Invitation{
+				ID:        uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+				TenantID:  "default",
+				Code:      "CODE",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   10,
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt: lo.ToPtr(expiresAt),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateInvitationResponse{
+				ID:        "00000000-0000-0000-0005-000000000001",
+				Code:      "CODE",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   10,
+				CreatedBy: "00000000-0000-0000-0000-000000000001",
+				ExpiresAt: lo.ToPtr(expiresAt),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.CreateInvitationRequest{
+				CreatedBy: "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   1,
+			},
+
+			shouldCallCreateInvitationDAO: true,
+			createInvitationDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrCreateInvitation,
+		},
+		{
+			name: "NoRole",
+
+			request: &services.CreateInvitationRequest{
+				CreatedBy: "00000000-0000-0000-0000-000000000001",
+				MaxUses:   1,
+			},
+
+			expectErr: services.ErrInvalidCreateInvitationRequest,
+		},
+		{
+			name: "InvalidRole",
+
+			request: &services.CreateInvitationRequest{
+				CreatedBy: "00000000-0000-0000-0000-000000000001",
+				Role:      "fake-role",
+				MaxUses:   1,
+			},
+
+			expectErr: services.ErrInvalidCreateInvitationRequest,
+		},
+		{
+			name: "NoMaxUses",
+
+			request: &services.CreateInvitationRequest{
+				CreatedBy: "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleEarlyAccessProgram,
+			},
+
+			expectErr: services.ErrInvalidCreateInvitationRequest,
+		},
+		{
+			name: "ExpiresInThePast",
+
+			request: &services.CreateInvitationRequest{
+				CreatedBy: "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   1,
+				ExpiresAt: lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expectErr: services.ErrInvalidCreateInvitationRequest,
+		},
+		{
+			name: "InvalidCreatedBy",
+
+			request: &services.CreateInvitationRequest{
+				CreatedBy: "00000000x0000x0000x0000x000000000001",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   1,
+			},
+
+			expectErr: services.ErrInvalidCreateInvitationRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			createInvitationDAO := daomocks.NewMockCreateInvitation(t)
+
+			if testCase.shouldCallCreateInvitationDAO {
+				createInvitationDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.Anything,
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						mock.MatchedBy(func(request *dao.CreateInvitationRequest) bool {
+							return request.Code != "" &&
+								request.Role == testCase.request.Role &&
+								request.MaxUses == testCase.request.MaxUses &&
+								request.CreatedBy == uuid.MustParse(testCase.request.CreatedBy) &&
+								request.ExpiresAt == testCase.request.ExpiresAt
+						}),
+					).
+					Return(testCase.createInvitationDAOResponse, testCase.createInvitationDAOError)
+			}
+
+			service := services.NewCreateInvitation(createInvitationDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			createInvitationDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidCreateServiceAccountRequest = errors.New("invalid create service account request")
+	ErrCreateServiceAccount               = errors.New("create service account")
+)
+
+var createServiceAccountValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterRole(createServiceAccountValidate)
+	entities.RegisterLabelKey(createServiceAccountValidate)
+}
+
+type CreateServiceAccountRequest struct {
+	Name    string        `validate:"required,max=128"`
+	OwnerID string        `validate:"required,len=36"`
+	Role    entities.Role `validate:"omitempty,role"`
+
+	Labels map[string]string `validate:"omitempty,max=64,dive,keys,label_key,endkeys,max=256"`
+}
+
+type CreateServiceAccountResponse struct {
+	ID      string
+	Name    string
+	OwnerID string
+	Role    entities.Role
+
+	Labels map[string]string
+
+	CreatedAt time.Time
+}
+
+type CreateServiceAccount interface {
+	Exec(ctx context.Context, data *CreateServiceAccountRequest) (*CreateServiceAccountResponse, error)
+}
+
+type createServiceAccountImpl struct {
+	dao dao.CreateServiceAccount
+}
+
+func (service *createServiceAccountImpl) Exec(
+	ctx context.Context, data *CreateServiceAccountRequest,
+) (*CreateServiceAccountResponse, error) {
+	if err := createServiceAccountValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidCreateServiceAccountRequest, err)
+	}
+
+	ownerID, err := uuid.Parse(data.OwnerID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidCreateServiceAccountRequest, fmt.Errorf("uuid value: '%s': %w", data.OwnerID, err),
+		)
+	}
+
+	credentials, err := service.dao.Exec(ctx, uuid.New(), time.Now(), &dao.CreateServiceAccountRequest{
+		Name:    data.Name,
+		OwnerID: ownerID,
+		Role:    data.Role,
+		Labels:  data.Labels,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrCreateServiceAccount, err)
+	}
+
+	return &CreateServiceAccountResponse{
+		ID:        credentials.ID.String(),
+		Name:      credentials.Name,
+		OwnerID:   credentials.OwnerID.String(),
+		Role:      credentials.Role,
+		Labels:    credentials.Labels,
+		CreatedAt: credentials.CreatedAt,
+	}, nil
+}
+
+func NewCreateServiceAccount(dao dao.CreateServiceAccount) CreateServiceAccount {
+	return &createServiceAccountImpl{dao: dao}
+}
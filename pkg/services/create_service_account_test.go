@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestCreateServiceAccount(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.CreateServiceAccountRequest
+
+		shouldCallCreateServiceAccountDAO bool
+		createServiceAccountDAOResponse   *entities.Credential
+		createServiceAccountDAOError      error
+
+		expect    *services.CreateServiceAccountResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.CreateServiceAccountRequest{
+				Name:    "service-1",
+				OwnerID: "00000000-0000-0000-0000-000000000001",
+				Role:    entities.RoleCore,
+				Labels:  map[string]string{"team": "billing"},
+			},
+
+			shouldCallCreateServiceAccountDAO: true,
+			createServiceAccountDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000010"),
+				TenantID:  "default",
+				Kind:      entities.CredentialKindService,
+				Name:      "service-1",
+				OwnerID:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:      entities.RoleCore,
+				Labels:    map[string]string{"team": "billing"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.CreateServiceAccountResponse{
+				ID:        "00000000-0000-0000-0000-000000000010",
+				Name:      "service-1",
+				OwnerID:   "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleCore,
+				Labels:    map[string]string{"team": "billing"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.CreateServiceAccountRequest{
+				Name:    "service-1",
+				OwnerID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallCreateServiceAccountDAO: true,
+			createServiceAccountDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrCreateServiceAccount,
+		},
+		{
+			name: "NoName",
+
+			request: &services.CreateServiceAccountRequest{
+				OwnerID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidCreateServiceAccountRequest,
+		},
+		{
+			name: "InvalidOwnerID",
+
+			request: &services.CreateServiceAccountRequest{
+				Name:    "service-1",
+				OwnerID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidCreateServiceAccountRequest,
+		},
+		{
+			name: "InvalidRole",
+
+			request: &services.CreateServiceAccountRequest{
+				Name:    "service-1",
+				OwnerID: "00000000-0000-0000-0000-000000000001",
+				Role:    entities.Role("fake-role"),
+			},
+
+			expectErr: services.ErrInvalidCreateServiceAccountRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			createServiceAccountDAO := daomocks.NewMockCreateServiceAccount(t)
+
+			if testCase.shouldCallCreateServiceAccountDAO {
+				createServiceAccountDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.Anything,
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.CreateServiceAccountRequest{
+							Name:    testCase.request.Name,
+							OwnerID: uuid.MustParse(testCase.request.OwnerID),
+							Role:    testCase.request.Role,
+							Labels:  testCase.request.Labels,
+						},
+					).
+					Return(testCase.createServiceAccountDAOResponse, testCase.createServiceAccountDAOError)
+			}
+
+			service := services.NewCreateServiceAccount(createServiceAccountDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			createServiceAccountDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidDeleteCredentialNoteRequest = errors.New("invalid delete credential note request")
+	ErrDeleteCredentialNote               = errors.New("delete credential note")
+)
+
+var deleteCredentialNoteValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type DeleteCredentialNoteRequest struct {
+	ID           string `validate:"required,len=36"`
+	CredentialID string `validate:"required,len=36"`
+}
+
+type DeleteCredentialNote interface {
+	Exec(ctx context.Context, data *DeleteCredentialNoteRequest) error
+}
+
+type deleteCredentialNoteImpl struct {
+	dao dao.DeleteCredentialNote
+}
+
+func (service *deleteCredentialNoteImpl) Exec(ctx context.Context, data *DeleteCredentialNoteRequest) error {
+	if err := deleteCredentialNoteValidate.Struct(data); err != nil {
+		return errors.Join(ErrInvalidDeleteCredentialNoteRequest, err)
+	}
+
+	noteID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return errors.Join(ErrInvalidDeleteCredentialNoteRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return errors.Join(
+			ErrInvalidDeleteCredentialNoteRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	err = service.dao.Exec(ctx, noteID, &dao.DeleteCredentialNoteRequest{CredentialID: credentialID})
+	if err != nil {
+		return errors.Join(ErrDeleteCredentialNote, err)
+	}
+
+	return nil
+}
+
+func NewDeleteCredentialNote(dao dao.DeleteCredentialNote) DeleteCredentialNote {
+	return &deleteCredentialNoteImpl{dao: dao}
+}
@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestDeleteCredentialNote(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.DeleteCredentialNoteRequest
+
+		shouldCallDeleteCredentialNoteDAO bool
+		deleteCredentialNoteDAOError      error
+
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.DeleteCredentialNoteRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallDeleteCredentialNoteDAO: true,
+		},
+		{
+			name: "DAOError",
+
+			request: &services.DeleteCredentialNoteRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallDeleteCredentialNoteDAO: true,
+			deleteCredentialNoteDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrDeleteCredentialNote,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.DeleteCredentialNoteRequest{
+				ID:           "10000000x0000x0000x0000x000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidDeleteCredentialNoteRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.DeleteCredentialNoteRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidDeleteCredentialNoteRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			deleteCredentialNoteDAO := daomocks.NewMockDeleteCredentialNote(t)
+
+			if testCase.shouldCallDeleteCredentialNoteDAO {
+				deleteCredentialNoteDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						&dao.DeleteCredentialNoteRequest{CredentialID: uuid.MustParse(testCase.request.CredentialID)},
+					).
+					Return(testCase.deleteCredentialNoteDAOError)
+			}
+
+			service := services.NewDeleteCredentialNote(deleteCredentialNoteDAO)
+			err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			deleteCredentialNoteDAO.AssertExpectations(t)
+		})
+	}
+}
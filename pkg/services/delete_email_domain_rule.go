@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidDeleteEmailDomainRuleRequest = errors.New("invalid delete email domain rule request")
+	ErrDeleteEmailDomainRule               = errors.New("delete email domain rule")
+)
+
+var deleteEmailDomainRuleValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterEmailDomain(deleteEmailDomainRuleValidate)
+}
+
+type DeleteEmailDomainRuleRequest struct {
+	// Domain is case-insensitive.
+	Domain string `validate:"required,email_domain,max=253"`
+}
+
+type DeleteEmailDomainRule interface {
+	Exec(ctx context.Context, data *DeleteEmailDomainRuleRequest) error
+}
+
+type deleteEmailDomainRuleImpl struct {
+	dao dao.DeleteEmailDomainRule
+}
+
+func (service *deleteEmailDomainRuleImpl) Exec(ctx context.Context, data *DeleteEmailDomainRuleRequest) error {
+	if err := deleteEmailDomainRuleValidate.Struct(data); err != nil {
+		return errors.Join(ErrInvalidDeleteEmailDomainRuleRequest, err)
+	}
+
+	if err := service.dao.Exec(ctx, entities.NormalizeEmailDomain(data.Domain)); err != nil {
+		return errors.Join(ErrDeleteEmailDomainRule, err)
+	}
+
+	return nil
+}
+
+func NewDeleteEmailDomainRule(dao dao.DeleteEmailDomainRule) DeleteEmailDomainRule {
+	return &deleteEmailDomainRuleImpl{dao: dao}
+}
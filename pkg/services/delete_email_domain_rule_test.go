@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestDeleteEmailDomainRule(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.DeleteEmailDomainRuleRequest
+
+		shouldCallDeleteEmailDomainRuleDAO bool
+		deleteEmailDomainRuleDAODomain     string
+		deleteEmailDomainRuleDAOError      error
+
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.DeleteEmailDomainRuleRequest{
+				Domain: "Spam.Example.com",
+			},
+
+			shouldCallDeleteEmailDomainRuleDAO: true,
+			deleteEmailDomainRuleDAODomain:     "spam.example.com",
+		},
+		{
+			name: "DAOError",
+
+			request: &services.DeleteEmailDomainRuleRequest{
+				Domain: "example.com",
+			},
+
+			shouldCallDeleteEmailDomainRuleDAO: true,
+			deleteEmailDomainRuleDAODomain:     "example.com",
+			deleteEmailDomainRuleDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrDeleteEmailDomainRule,
+		},
+		{
+			name: "MissingDomain",
+
+			request: &services.DeleteEmailDomainRuleRequest{},
+
+			expectErr: services.ErrInvalidDeleteEmailDomainRuleRequest,
+		},
+		{
+			name: "InvalidDomain",
+
+			request: &services.DeleteEmailDomainRuleRequest{
+				Domain: "not a domain",
+			},
+
+			expectErr: services.ErrInvalidDeleteEmailDomainRuleRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			deleteEmailDomainRuleDAO := daomocks.NewMockDeleteEmailDomainRule(t)
+
+			if testCase.shouldCallDeleteEmailDomainRuleDAO {
+				deleteEmailDomainRuleDAO.
+					On("Exec", context.Background(), testCase.deleteEmailDomainRuleDAODomain).
+					Return(testCase.deleteEmailDomainRuleDAOError)
+			}
+
+			service := services.NewDeleteEmailDomainRule(deleteEmailDomainRuleDAO)
+			err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			deleteEmailDomainRuleDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidEditCredentialNoteRequest = errors.New("invalid edit credential note request")
+	ErrEditCredentialNote               = errors.New("edit credential note")
+)
+
+var editCredentialNoteValidate = validator.New(validator.WithRequiredStructEnabled())
+
+// EditCredentialNoteRequest replaces the body and pinned flag of a note. The author of a note never changes.
+type EditCredentialNoteRequest struct {
+	ID           string `validate:"required,len=36"`
+	CredentialID string `validate:"required,len=36"`
+	Body         string `validate:"required,max=4096"`
+	Pinned       bool
+}
+
+type EditCredentialNoteResponse struct {
+	ID           string
+	CredentialID string
+
+	Author string
+	Body   string
+	Pinned bool
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type EditCredentialNote interface {
+	Exec(ctx context.Context, data *EditCredentialNoteRequest) (*EditCredentialNoteResponse, error)
+}
+
+type editCredentialNoteImpl struct {
+	dao dao.EditCredentialNote
+}
+
+func (service *editCredentialNoteImpl) Exec(
+	ctx context.Context, data *EditCredentialNoteRequest,
+) (*EditCredentialNoteResponse, error) {
+	if err := editCredentialNoteValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidEditCredentialNoteRequest, err)
+	}
+
+	noteID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidEditCredentialNoteRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidEditCredentialNoteRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	note, err := service.dao.Exec(ctx, noteID, time.Now(), &dao.EditCredentialNoteRequest{
+		CredentialID: credentialID,
+		Body:         data.Body,
+		Pinned:       data.Pinned,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrEditCredentialNote, err)
+	}
+
+	return &EditCredentialNoteResponse{
+		ID:           note.ID.String(),
+		CredentialID: note.CredentialID.String(),
+
+		Author: note.Author,
+		Body:   note.Body,
+		Pinned: note.Pinned,
+
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}, nil
+}
+
+func NewEditCredentialNote(dao dao.EditCredentialNote) EditCredentialNote {
+	return &editCredentialNoteImpl{dao: dao}
+}
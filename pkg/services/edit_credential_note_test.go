@@ -0,0 +1,139 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestEditCredentialNote(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.EditCredentialNoteRequest
+
+		shouldCallEditCredentialNoteDAO bool
+		editCredentialNoteDAOResponse   *entities.CredentialNote
+		editCredentialNoteDAOError      error
+
+		expect    *services.EditCredentialNoteResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.EditCredentialNoteRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Body:         "refund issued twice",
+			},
+
+			shouldCallEditCredentialNoteDAO: true,
+			editCredentialNoteDAOResponse: &entities.CredentialNote{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Author:       "agent@example.com",
+				Body:         "refund issued twice",
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.EditCredentialNoteResponse{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Author:       "agent@example.com",
+				Body:         "refund issued twice",
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:    lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.EditCredentialNoteRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Body:         "refund issued twice",
+				Pinned:       true,
+			},
+
+			shouldCallEditCredentialNoteDAO: true,
+			editCredentialNoteDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrEditCredentialNote,
+		},
+		{
+			name: "MissingBody",
+
+			request: &services.EditCredentialNoteRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidEditCredentialNoteRequest,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.EditCredentialNoteRequest{
+				ID:           "10000000x0000x0000x0000x000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Body:         "refund issued twice",
+			},
+
+			expectErr: services.ErrInvalidEditCredentialNoteRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.EditCredentialNoteRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Body:         "refund issued twice",
+			},
+
+			expectErr: services.ErrInvalidEditCredentialNoteRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			editCredentialNoteDAO := daomocks.NewMockEditCredentialNote(t)
+
+			if testCase.shouldCallEditCredentialNoteDAO {
+				editCredentialNoteDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.EditCredentialNoteRequest{
+							CredentialID: uuid.MustParse(testCase.request.CredentialID),
+							Body:         testCase.request.Body,
+							Pinned:       testCase.request.Pinned,
+						},
+					).
+					Return(testCase.editCredentialNoteDAOResponse, testCase.editCredentialNoteDAOError)
+			}
+
+			service := services.NewEditCredentialNote(editCredentialNoteDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			editCredentialNoteDAO.AssertExpectations(t)
+		})
+	}
+}
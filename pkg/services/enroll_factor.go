@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidEnrollFactorRequest = errors.New("invalid enroll factor request")
+	ErrEnrollFactor               = errors.New("enroll factor")
+)
+
+var enrollFactorValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterFactorType(enrollFactorValidate)
+}
+
+type EnrollFactorRequest struct {
+	CredentialID string              `validate:"required,len=36"`
+	Type         entities.FactorType `validate:"required,factor_type"`
+	Label        string              `validate:"required,max=64"`
+
+	// SecretRef is required for TOTP and recovery codes factors.
+	SecretRef string `validate:"required_unless=Type webauthn,excluded_if=Type webauthn,max=128"`
+
+	// WebAuthnCredentialID and WebAuthnPublicKey are required for WebAuthn factors.
+	WebAuthnCredentialID string `validate:"required_if=Type webauthn,excluded_unless=Type webauthn,max=1024"`
+	WebAuthnPublicKey    []byte `validate:"required_if=Type webauthn,excluded_unless=Type webauthn,max=1024"`
+}
+
+type EnrollFactorResponse struct {
+	ID           string
+	CredentialID string
+	Type         entities.FactorType
+	Label        string
+
+	SecretRef string
+
+	WebAuthnCredentialID string
+	WebAuthnPublicKey    []byte
+
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+type EnrollFactor interface {
+	Exec(ctx context.Context, data *EnrollFactorRequest) (*EnrollFactorResponse, error)
+}
+
+type enrollFactorImpl struct {
+	dao dao.EnrollFactor
+}
+
+func (service *enrollFactorImpl) Exec(ctx context.Context, data *EnrollFactorRequest) (*EnrollFactorResponse, error) {
+	if err := enrollFactorValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidEnrollFactorRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidEnrollFactorRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	factor, err := service.dao.Exec(ctx, uuid.New(), time.Now(), &dao.EnrollFactorRequest{
+		CredentialID:         credentialID,
+		Type:                 data.Type,
+		Label:                data.Label,
+		SecretRef:            data.SecretRef,
+		WebAuthnCredentialID: data.WebAuthnCredentialID,
+		WebAuthnPublicKey:    data.WebAuthnPublicKey,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrEnrollFactor, err)
+	}
+
+	return &EnrollFactorResponse{
+		ID:           factor.ID.String(),
+		CredentialID: factor.CredentialID.String(),
+		Type:         factor.Type,
+		Label:        factor.Label,
+
+		SecretRef: factor.SecretRef,
+
+		WebAuthnCredentialID: factor.WebAuthnCredentialID,
+		WebAuthnPublicKey:    factor.WebAuthnPublicKey,
+
+		CreatedAt:  factor.CreatedAt,
+		LastUsedAt: factor.LastUsedAt,
+	}, nil
+}
+
+func NewEnrollFactor(dao dao.EnrollFactor) EnrollFactor {
+	return &enrollFactorImpl{dao: dao}
+}
@@ -0,0 +1,244 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestEnrollFactor(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.EnrollFactorRequest
+
+		shouldCallEnrollFactorDAO bool
+		enrollFactorDAOResponse   *entities.CredentialFactor
+		enrollFactorDAOError      error
+
+		expect    *services.EnrollFactorResponse
+		expectErr error
+	}{
+		{
+			name: "TOTP",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorTypeTOTP,
+				Label:        "phone",
+				SecretRef:    "totp-secret-ref",
+			},
+
+			shouldCallEnrollFactorDAO: true,
+			enrollFactorDAOResponse: &entities.CredentialFactor{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Type:         entities.FactorTypeTOTP,
+				Label:        "phone",
+				SecretRef:    "totp-secret-ref",
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.EnrollFactorResponse{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorTypeTOTP,
+				Label:        "phone",
+				SecretRef:    "totp-secret-ref",
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "WebAuthn",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID:         "00000000-0000-0000-0000-000000000001",
+				Type:                 entities.FactorTypeWebAuthn,
+				Label:                "security key",
+				WebAuthnCredentialID: "webauthn-credential-id",
+				WebAuthnPublicKey:    []byte("public-key"),
+			},
+
+			shouldCallEnrollFactorDAO: true,
+			enrollFactorDAOResponse: &entities.CredentialFactor{
+				ID:                   uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				TenantID:             "default",
+				CredentialID:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Type:                 entities.FactorTypeWebAuthn,
+				Label:                "security key",
+				WebAuthnCredentialID: "webauthn-credential-id",
+				WebAuthnPublicKey:    []byte("public-key"),
+				CreatedAt:            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.EnrollFactorResponse{
+				ID:                   "10000000-0000-0000-0000-000000000001",
+				CredentialID:         "00000000-0000-0000-0000-000000000001",
+				Type:                 entities.FactorTypeWebAuthn,
+				Label:                "security key",
+				WebAuthnCredentialID: "webauthn-credential-id",
+				WebAuthnPublicKey:    []byte("public-key"),
+				CreatedAt:            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAO/AlreadyExists",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorTypeRecoveryCodes,
+				Label:        "recovery codes",
+				SecretRef:    "recovery-codes-ref",
+			},
+
+			shouldCallEnrollFactorDAO: true,
+			enrollFactorDAOError:      dao.ErrFactorAlreadyExists,
+
+			expectErr: dao.ErrFactorAlreadyExists,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorTypeRecoveryCodes,
+				Label:        "recovery codes",
+				SecretRef:    "recovery-codes-ref",
+			},
+
+			shouldCallEnrollFactorDAO: true,
+			enrollFactorDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrEnrollFactor,
+		},
+		{
+			name: "Invalid/Type",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorType("sms"),
+				Label:        "phone",
+				SecretRef:    "totp-secret-ref",
+			},
+
+			expectErr: services.ErrInvalidEnrollFactorRequest,
+		},
+		{
+			name: "Invalid/TOTP/NoSecretRef",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorTypeTOTP,
+				Label:        "phone",
+			},
+
+			expectErr: services.ErrInvalidEnrollFactorRequest,
+		},
+		{
+			name: "Invalid/TOTP/WebAuthnData",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID:         "00000000-0000-0000-0000-000000000001",
+				Type:                 entities.FactorTypeTOTP,
+				Label:                "phone",
+				SecretRef:            "totp-secret-ref",
+				WebAuthnCredentialID: "webauthn-credential-id",
+			},
+
+			expectErr: services.ErrInvalidEnrollFactorRequest,
+		},
+		{
+			name: "Invalid/WebAuthn/NoPublicKey",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID:         "00000000-0000-0000-0000-000000000001",
+				Type:                 entities.FactorTypeWebAuthn,
+				Label:                "security key",
+				WebAuthnCredentialID: "webauthn-credential-id",
+			},
+
+			expectErr: services.ErrInvalidEnrollFactorRequest,
+		},
+		{
+			name: "Invalid/WebAuthn/SecretRef",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID:         "00000000-0000-0000-0000-000000000001",
+				Type:                 entities.FactorTypeWebAuthn,
+				Label:                "security key",
+				SecretRef:            "totp-secret-ref",
+				WebAuthnCredentialID: "webauthn-credential-id",
+				WebAuthnPublicKey:    []byte("public-key"),
+			},
+
+			expectErr: services.ErrInvalidEnrollFactorRequest,
+		},
+		{
+			name: "Invalid/NoLabel",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorTypeTOTP,
+				SecretRef:    "totp-secret-ref",
+			},
+
+			expectErr: services.ErrInvalidEnrollFactorRequest,
+		},
+		{
+			name: "Invalid/CredentialID",
+
+			request: &services.EnrollFactorRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Type:         entities.FactorTypeTOTP,
+				Label:        "phone",
+				SecretRef:    "totp-secret-ref",
+			},
+
+			expectErr: services.ErrInvalidEnrollFactorRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			enrollFactorDAO := daomocks.NewMockEnrollFactor(t)
+
+			if testCase.shouldCallEnrollFactorDAO {
+				enrollFactorDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.Anything,
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.EnrollFactorRequest{
+							CredentialID:         uuid.MustParse(testCase.request.CredentialID),
+							Type:                 testCase.request.Type,
+							Label:                testCase.request.Label,
+							SecretRef:            testCase.request.SecretRef,
+							WebAuthnCredentialID: testCase.request.WebAuthnCredentialID,
+							WebAuthnPublicKey:    testCase.request.WebAuthnPublicKey,
+						},
+					).
+					Return(testCase.enrollFactorDAOResponse, testCase.enrollFactorDAOError)
+			}
+
+			service := services.NewEnrollFactor(enrollFactorDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			enrollFactorDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidEraseCredentialsRequest = errors.New("invalid erase credentials request")
+	ErrEraseCredentials               = errors.New("erase credentials")
+)
+
+var eraseCredentialsValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type EraseCredentialsRequest struct {
+	ID     string `validate:"required,len=36"`
+	Reason string `validate:"omitempty,max=256"`
+}
+
+type EraseCredentialsResponse struct {
+	ID        string
+	ErasedAt  *time.Time
+	UpdatedAt *time.Time
+}
+
+type EraseCredentials interface {
+	Exec(ctx context.Context, data *EraseCredentialsRequest) (*EraseCredentialsResponse, error)
+}
+
+type eraseCredentialsImpl struct {
+	dao dao.EraseCredentials
+}
+
+func (service *eraseCredentialsImpl) Exec(
+	ctx context.Context, data *EraseCredentialsRequest,
+) (*EraseCredentialsResponse, error) {
+	if err := eraseCredentialsValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidEraseCredentialsRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidEraseCredentialsRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err),
+		)
+	}
+
+	placeholder, err := entities.NewErasurePlaceholder(credentialsID)
+	if err != nil {
+		return nil, errors.Join(ErrEraseCredentials, err)
+	}
+
+	credentials, err := service.dao.Exec(ctx, credentialsID, time.Now(), &dao.EraseCredentialsRequest{
+		ErasureID:   uuid.New(),
+		Placeholder: placeholder,
+		Reason:      data.Reason,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrEraseCredentials, err)
+	}
+
+	return &EraseCredentialsResponse{
+		ID:        credentials.ID.String(),
+		ErasedAt:  credentials.ErasedAt,
+		UpdatedAt: credentials.UpdatedAt,
+	}, nil
+}
+
+func NewEraseCredentials(dao dao.EraseCredentials) EraseCredentials {
+	return &eraseCredentialsImpl{dao: dao}
+}
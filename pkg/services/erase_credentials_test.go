@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestEraseCredentials(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.EraseCredentialsRequest
+
+		shouldCallEraseCredentialsDAO bool
+		eraseCredentialsDAOResponse   *entities.Credential
+		eraseCredentialsDAOError      error
+
+		expect    *services.EraseCredentialsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.EraseCredentialsRequest{
+				ID:     "00000000-0000-0000-0000-000000000001",
+				Reason: "ticket-1",
+			},
+
+			shouldCallEraseCredentialsDAO: true,
+			eraseCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:     "erased-0001@erased.invalid",
+				ErasedAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.EraseCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				ErasedAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.EraseCredentialsRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallEraseCredentialsDAO: true,
+			eraseCredentialsDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrEraseCredentials,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.EraseCredentialsRequest{
+				ID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidEraseCredentialsRequest,
+		},
+		{
+			name: "ReasonTooLong",
+
+			request: &services.EraseCredentialsRequest{
+				ID:     "00000000-0000-0000-0000-000000000001",
+				Reason: strings.Repeat("a", 257),
+			},
+
+			expectErr: services.ErrInvalidEraseCredentialsRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			eraseCredentialsDAO := daomocks.NewMockEraseCredentials(t)
+
+			if testCase.shouldCallEraseCredentialsDAO {
+				eraseCredentialsDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						mock.MatchedBy(func(request *dao.EraseCredentialsRequest) bool {
+							return request.ErasureID != uuid.Nil &&
+								strings.HasPrefix(request.Placeholder, "erased-") &&
+								request.Reason == testCase.request.Reason
+						}),
+					).
+					Return(testCase.eraseCredentialsDAOResponse, testCase.eraseCredentialsDAOError)
+			}
+
+			service := services.NewEraseCredentials(eraseCredentialsDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			eraseCredentialsDAO.AssertExpectations(t)
+		})
+	}
+}
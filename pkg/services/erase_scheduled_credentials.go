@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var ErrEraseScheduledCredentials = errors.New("erase scheduled credentials")
+
+type EraseScheduledCredentialsResponseDeletion struct {
+	TenantID     string
+	CredentialID string
+	ScheduledAt  time.Time
+	ErasedAt     time.Time
+}
+
+type EraseScheduledCredentialsResponse struct {
+	Deletions []*EraseScheduledCredentialsResponseDeletion
+}
+
+type EraseScheduledCredentials interface {
+	Exec(ctx context.Context) (*EraseScheduledCredentialsResponse, error)
+}
+
+type eraseScheduledCredentialsImpl struct {
+	dao dao.EraseScheduledCredentials
+}
+
+func (service *eraseScheduledCredentialsImpl) Exec(ctx context.Context) (*EraseScheduledCredentialsResponse, error) {
+	deletions, err := service.dao.Exec(ctx, time.Now())
+	if err != nil {
+		return nil, errors.Join(ErrEraseScheduledCredentials, err)
+	}
+
+	return &EraseScheduledCredentialsResponse{
+		Deletions: lo.Map(
+			deletions,
+			func(item *entities.CredentialDeletion, _ int) *EraseScheduledCredentialsResponseDeletion {
+				return &EraseScheduledCredentialsResponseDeletion{
+					TenantID:     item.TenantID,
+					CredentialID: item.CredentialID.String(),
+					ScheduledAt:  item.ScheduledAt,
+					ErasedAt:     item.ErasedAt,
+				}
+			},
+		),
+	}, nil
+}
+
+func NewEraseScheduledCredentials(dao dao.EraseScheduledCredentials) EraseScheduledCredentials {
+	return &eraseScheduledCredentialsImpl{dao: dao}
+}
@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestEraseScheduledCredentials(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		eraseScheduledCredentialsDAOResponse []*entities.CredentialDeletion
+		eraseScheduledCredentialsDAOError    error
+
+		expect    *services.EraseScheduledCredentialsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			eraseScheduledCredentialsDAOResponse: []*entities.CredentialDeletion{
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					ScheduledAt:  time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+					ErasedAt:     time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			expect: &services.EraseScheduledCredentialsResponse{
+				Deletions: []*services.EraseScheduledCredentialsResponseDeletion{
+					{
+						TenantID:     "default",
+						CredentialID: "00000000-0000-0000-0000-000000000001",
+						ScheduledAt:  time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+						ErasedAt:     time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "OK/Nothing",
+
+			expect: &services.EraseScheduledCredentialsResponse{
+				Deletions: []*services.EraseScheduledCredentialsResponseDeletion{},
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			eraseScheduledCredentialsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrEraseScheduledCredentials,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			eraseScheduledCredentialsDAO := daomocks.NewMockEraseScheduledCredentials(t)
+
+			eraseScheduledCredentialsDAO.
+				On(
+					"Exec",
+					context.Background(),
+					mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+				).
+				Return(testCase.eraseScheduledCredentialsDAOResponse, testCase.eraseScheduledCredentialsDAOError)
+
+			service := services.NewEraseScheduledCredentials(eraseScheduledCredentialsDAO)
+			response, err := service.Exec(context.Background())
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			eraseScheduledCredentialsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
 )
 
 var (
@@ -18,9 +19,13 @@ var (
 
 var existsCredentialsValidate = validator.New(validator.WithRequiredStructEnabled())
 
+func init() {
+	entities.RegisterEmail(existsCredentialsValidate)
+}
+
 type ExistsCredentialsRequest struct {
 	ID    string `validate:"required_without=Email,omitempty,len=36"`
-	Email string `validate:"required_without=ID,omitempty,email,max=256"`
+	Email string `validate:"required_without=ID,omitempty,email_address,max=256"`
 }
 
 type ExistsCredentialsResponse struct {
@@ -53,7 +58,7 @@ func (service *existsCredentialsImpl) Exec(
 	}
 
 	request := &dao.ExistsCredentialsRequest{
-		Email: data.Email,
+		Email: entities.NormalizeEmail(data.Email),
 		ID:    credentialsID,
 	}
 
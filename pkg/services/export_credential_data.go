@@ -0,0 +1,478 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidExportCredentialDataRequest = errors.New("invalid export credential data request")
+	ErrExportCredentialData               = errors.New("export credential data")
+)
+
+var exportCredentialDataValidate = validator.New(validator.WithRequiredStructEnabled())
+
+const (
+	// ExportCredentialDataEventErasure is the type of history events recorded when the personal data of credentials
+	// is erased.
+	ExportCredentialDataEventErasure = "erasure"
+	// ExportCredentialDataEventDeletion is the type of history events recorded when credentials pending deletion are
+	// permanently erased.
+	ExportCredentialDataEventDeletion = "deletion"
+	// ExportCredentialDataEventRoleChange is the type of history events recorded when the role of credentials changes
+	// without being requested directly.
+	ExportCredentialDataEventRoleChange = "role_change"
+	// ExportCredentialDataEventMerged is the type of history events recorded when credentials are merged into other
+	// credentials.
+	ExportCredentialDataEventMerged = "merged"
+	// ExportCredentialDataEventAbsorbed is the type of history events recorded when other credentials are merged into
+	// the credentials.
+	ExportCredentialDataEventAbsorbed = "absorbed"
+	// ExportCredentialDataEventInvitationRedeemed is the type of history events recorded when credentials redeem an
+	// invitation.
+	ExportCredentialDataEventInvitationRedeemed = "invitation_redeemed"
+)
+
+type ExportCredentialDataRequest struct {
+	ID string `validate:"required,len=36"`
+}
+
+type ExportCredentialDataResponse struct {
+	// Document is the JSON encoding of an ExportCredentialDataDocument.
+	Document []byte
+}
+
+// ExportCredentialDataDocument is the machine-readable export of everything the service holds about a credential.
+// Its layout is versioned by SchemaVersion.
+type ExportCredentialDataDocument struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	ExportedAt    time.Time `json:"exportedAt"`
+
+	Credential *ExportCredentialDataDocumentCredential `json:"credential"`
+	Identities []*ExportCredentialDataDocumentIdentity `json:"identities"`
+	Factors    []*ExportCredentialDataDocumentFactor   `json:"factors"`
+	APIKeys    []*ExportCredentialDataDocumentAPIKey   `json:"apiKeys"`
+	Consents   []*ExportCredentialDataDocumentConsent  `json:"consents"`
+	Notes      []*ExportCredentialDataDocumentNote     `json:"notes"`
+
+	Invitations        []*ExportCredentialDataDocumentInvitation        `json:"invitations"`
+	WaitlistEntries    []*ExportCredentialDataDocumentWaitlistEntry     `json:"waitlistEntries"`
+	RoleChangeRequests []*ExportCredentialDataDocumentRoleChangeRequest `json:"roleChangeRequests"`
+
+	// History lists the events of the credentials, from the oldest to the most recent.
+	History []*ExportCredentialDataDocumentEvent `json:"history"`
+}
+
+type ExportCredentialDataDocumentCredential struct {
+	ID             string            `json:"id"`
+	Kind           string            `json:"kind"`
+	Email          string            `json:"email,omitempty"`
+	CanonicalEmail string            `json:"canonicalEmail,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	OwnerID        string            `json:"ownerID,omitempty"`
+	Role           string            `json:"role"`
+	Labels         map[string]string `json:"labels,omitempty"`
+
+	// RoleExpiresAt and FallbackRole are set while Role is only granted temporarily.
+	RoleExpiresAt *time.Time `json:"roleExpiresAt,omitempty"`
+	FallbackRole  string     `json:"fallbackRole,omitempty"`
+
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+	MFARequired     bool       `json:"mfaRequired"`
+	TokenEpoch      int64      `json:"tokenEpoch"`
+
+	EmailValidationTokenID        *string `json:"emailValidationTokenID,omitempty"`
+	PendingEmailValidationTokenID *string `json:"pendingEmailValidationTokenID,omitempty"`
+	PasswordTokenID               *string `json:"passwordTokenID,omitempty"`
+	ResetPasswordTokenID          *string `json:"resetPasswordTokenID,omitempty"`
+
+	EmailValidationTokenExpiresAt        *time.Time `json:"emailValidationTokenExpiresAt,omitempty"`
+	PendingEmailValidationTokenExpiresAt *time.Time `json:"pendingEmailValidationTokenExpiresAt,omitempty"`
+	PasswordTokenExpiresAt               *time.Time `json:"passwordTokenExpiresAt,omitempty"`
+	ResetPasswordTokenExpiresAt          *time.Time `json:"resetPasswordTokenExpiresAt,omitempty"`
+
+	DeletionScheduledAt *time.Time `json:"deletionScheduledAt,omitempty"`
+	ErasedAt            *time.Time `json:"erasedAt,omitempty"`
+
+	// LegalHold tells whether the credentials are frozen by a legal hold. The reason of the hold, and whoever set it,
+	// are internal to the investigation, and are not exported.
+	LegalHold      bool       `json:"legalHold"`
+	LegalHoldSetAt *time.Time `json:"legalHoldSetAt,omitempty"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+type ExportCredentialDataDocumentIdentity struct {
+	Provider        string    `json:"provider"`
+	Subject         string    `json:"subject"`
+	EmailAtLinkTime string    `json:"emailAtLinkTime,omitempty"`
+	LinkedAt        time.Time `json:"linkedAt"`
+}
+
+type ExportCredentialDataDocumentFactor struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+
+	SecretRef            *string `json:"secretRef,omitempty"`
+	WebAuthnCredentialID *string `json:"webauthnCredentialID,omitempty"`
+	// WebAuthnPublicKey is base64 encoded.
+	WebAuthnPublicKey *string `json:"webauthnPublicKey,omitempty"`
+
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// ExportCredentialDataDocumentAPIKey never includes the secret hash of the key.
+type ExportCredentialDataDocumentAPIKey struct {
+	ID     string   `json:"id"`
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes,omitempty"`
+
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+type ExportCredentialDataDocumentConsent struct {
+	Document    string     `json:"document"`
+	Version     string     `json:"version"`
+	AcceptedAt  time.Time  `json:"acceptedAt"`
+	WithdrawnAt *time.Time `json:"withdrawnAt,omitempty"`
+}
+
+type ExportCredentialDataDocumentNote struct {
+	Author *string `json:"author,omitempty"`
+	Body   *string `json:"body,omitempty"`
+	Pinned bool    `json:"pinned"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ExportCredentialDataDocumentInvitation is an invitation issued by the credentials. Its code follows the same
+// redaction policy as token IDs.
+type ExportCredentialDataDocumentInvitation struct {
+	ID      string  `json:"id"`
+	Code    *string `json:"code,omitempty"`
+	Role    string  `json:"role"`
+	MaxUses int     `json:"maxUses"`
+	Uses    int     `json:"uses"`
+
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type ExportCredentialDataDocumentWaitlistEntry struct {
+	Role       string    `json:"role"`
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+}
+
+// ExportCredentialDataDocumentRoleChangeRequest is a role change request that targets the credentials. The actors
+// that proposed and decided the request are not exported.
+type ExportCredentialDataDocumentRoleChangeRequest struct {
+	ID     string `json:"id"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+
+	ExpiresAt time.Time  `json:"expiresAt"`
+	DecidedAt *time.Time `json:"decidedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type ExportCredentialDataDocumentEvent struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason,omitempty"`
+
+	// FromRole and ToRole are only set on role changes.
+	FromRole string `json:"fromRole,omitempty"`
+	ToRole   string `json:"toRole,omitempty"`
+	// CredentialID is the other credentials of a merge.
+	CredentialID string `json:"credentialID,omitempty"`
+	InvitationID string `json:"invitationID,omitempty"`
+	// ScheduledAt is the date a deletion was scheduled for.
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+type ExportCredentialData interface {
+	Exec(ctx context.Context, data *ExportCredentialDataRequest) (*ExportCredentialDataResponse, error)
+}
+
+type exportCredentialDataImpl struct {
+	dao    dao.ExportCredentialData
+	policy entities.ExportRedactionPolicy
+}
+
+func (service *exportCredentialDataImpl) Exec(
+	ctx context.Context, data *ExportCredentialDataRequest,
+) (*ExportCredentialDataResponse, error) {
+	if err := exportCredentialDataValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidExportCredentialDataRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidExportCredentialDataRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err),
+		)
+	}
+
+	res, err := service.dao.Exec(ctx, credentialsID)
+	if err != nil {
+		return nil, errors.Join(ErrExportCredentialData, err)
+	}
+
+	now := time.Now()
+	credentials := res.Credential
+	credentials.ClearExpiredTokens(now)
+	credentials.ClearExpiredRoleGrant(now)
+
+	var fallbackRole string
+	if credentials.FallbackRole != nil {
+		fallbackRole = credentials.FallbackRole.String()
+	}
+
+	document := &ExportCredentialDataDocument{
+		SchemaVersion: entities.ExportSchemaVersion,
+		ExportedAt:    now,
+
+		Credential: &ExportCredentialDataDocumentCredential{
+			ID:             credentials.ID.String(),
+			Kind:           credentials.Kind.String(),
+			Email:          credentials.Email,
+			CanonicalEmail: credentials.CanonicalEmail,
+			Name:           credentials.Name,
+			OwnerID:        lo.Ternary(credentials.OwnerID == uuid.Nil, "", credentials.OwnerID.String()),
+			Role:           credentials.Role.String(),
+			Labels:         credentials.Labels,
+
+			RoleExpiresAt: credentials.RoleExpiresAt,
+			FallbackRole:  fallbackRole,
+
+			EmailVerifiedAt: credentials.EmailVerifiedAt,
+			MFARequired:     credentials.MFARequired,
+			TokenEpoch:      credentials.TokenEpoch,
+
+			EmailValidationTokenID:        service.policy.TokenIDs.Redact(credentials.EmailValidationTokenID),
+			PendingEmailValidationTokenID: service.policy.TokenIDs.Redact(credentials.PendingEmailValidationTokenID),
+			PasswordTokenID:               service.policy.TokenIDs.Redact(credentials.PasswordTokenID),
+			ResetPasswordTokenID:          service.policy.TokenIDs.Redact(credentials.ResetPasswordTokenID),
+
+			EmailValidationTokenExpiresAt:        credentials.EmailValidationTokenExpiresAt,
+			PendingEmailValidationTokenExpiresAt: credentials.PendingEmailValidationTokenExpiresAt,
+			PasswordTokenExpiresAt:               credentials.PasswordTokenExpiresAt,
+			ResetPasswordTokenExpiresAt:          credentials.ResetPasswordTokenExpiresAt,
+
+			DeletionScheduledAt: credentials.DeletionScheduledAt,
+			ErasedAt:            credentials.ErasedAt,
+
+			LegalHold:      credentials.LegalHold,
+			LegalHoldSetAt: credentials.LegalHoldSetAt,
+
+			CreatedAt: credentials.CreatedAt,
+			UpdatedAt: credentials.UpdatedAt,
+		},
+
+		Identities: lo.Map(
+			res.Identities,
+			func(item *entities.CredentialIdentity, _ int) *ExportCredentialDataDocumentIdentity {
+				return &ExportCredentialDataDocumentIdentity{
+					Provider:        item.Provider,
+					Subject:         item.Subject,
+					EmailAtLinkTime: item.EmailAtLinkTime,
+					LinkedAt:        item.LinkedAt,
+				}
+			},
+		),
+
+		Factors: lo.Map(
+			res.Factors,
+			func(item *entities.CredentialFactor, _ int) *ExportCredentialDataDocumentFactor {
+				return &ExportCredentialDataDocumentFactor{
+					ID:    item.ID.String(),
+					Type:  string(item.Type),
+					Label: item.Label,
+
+					SecretRef:            service.policy.FactorSecrets.Redact(item.SecretRef),
+					WebAuthnCredentialID: service.policy.FactorSecrets.Redact(item.WebAuthnCredentialID),
+					WebAuthnPublicKey: service.policy.FactorSecrets.Redact(
+						base64.StdEncoding.EncodeToString(item.WebAuthnPublicKey),
+					),
+
+					CreatedAt:  item.CreatedAt,
+					LastUsedAt: item.LastUsedAt,
+				}
+			},
+		),
+
+		APIKeys: lo.Map(res.APIKeys, func(item *entities.APIKey, _ int) *ExportCredentialDataDocumentAPIKey {
+			return &ExportCredentialDataDocumentAPIKey{
+				ID:     item.ID.String(),
+				Prefix: item.Prefix,
+				Scopes: item.Scopes,
+
+				ExpiresAt:  item.ExpiresAt,
+				CreatedAt:  item.CreatedAt,
+				LastUsedAt: item.LastUsedAt,
+				RevokedAt:  item.RevokedAt,
+			}
+		}),
+
+		Consents: lo.Map(
+			res.Consents,
+			func(item *entities.CredentialConsent, _ int) *ExportCredentialDataDocumentConsent {
+				return &ExportCredentialDataDocumentConsent{
+					Document:    string(item.Document),
+					Version:     item.Version,
+					AcceptedAt:  item.AcceptedAt,
+					WithdrawnAt: item.WithdrawnAt,
+				}
+			},
+		),
+
+		Notes: lo.Map(res.Notes, func(item *entities.CredentialNote, _ int) *ExportCredentialDataDocumentNote {
+			return &ExportCredentialDataDocumentNote{
+				Author: service.policy.Notes.Redact(item.Author),
+				Body:   service.policy.Notes.Redact(item.Body),
+				Pinned: item.Pinned,
+
+				CreatedAt: item.CreatedAt,
+				UpdatedAt: item.UpdatedAt,
+			}
+		}),
+
+		Invitations: lo.Map(
+			res.Invitations,
+			func(item *entities.Invitation, _ int) *ExportCredentialDataDocumentInvitation {
+				return &ExportCredentialDataDocumentInvitation{
+					ID:      item.ID.String(),
+					Code:    service.policy.TokenIDs.Redact(item.Code),
+					Role:    item.Role.String(),
+					MaxUses: item.MaxUses,
+					Uses:    item.Uses,
+
+					ExpiresAt: item.ExpiresAt,
+					CreatedAt: item.CreatedAt,
+				}
+			},
+		),
+
+		WaitlistEntries: lo.Map(
+			res.WaitlistEntries,
+			func(item *entities.RoleWaitlistEntry, _ int) *ExportCredentialDataDocumentWaitlistEntry {
+				return &ExportCredentialDataDocumentWaitlistEntry{
+					Role:       item.Role.String(),
+					EnqueuedAt: item.EnqueuedAt,
+				}
+			},
+		),
+
+		RoleChangeRequests: lo.Map(
+			res.RoleChangeRequests,
+			func(item *entities.RoleChangeRequest, _ int) *ExportCredentialDataDocumentRoleChangeRequest {
+				return &ExportCredentialDataDocumentRoleChangeRequest{
+					ID:     item.ID.String(),
+					Role:   item.Role.String(),
+					Status: string(item.StatusAt(now)),
+
+					ExpiresAt: item.ExpiresAt,
+					DecidedAt: item.DecidedAt,
+					CreatedAt: item.CreatedAt,
+				}
+			},
+		),
+
+		History: exportCredentialDataHistory(credentials.ID, res),
+	}
+
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return nil, errors.Join(ErrExportCredentialData, fmt.Errorf("encode document: %w", err))
+	}
+
+	return &ExportCredentialDataResponse{Document: encoded}, nil
+}
+
+// exportCredentialDataHistory merges every recorded event of the credentials, in the order they occurred.
+func exportCredentialDataHistory(
+	id uuid.UUID, res *dao.ExportCredentialDataResult,
+) []*ExportCredentialDataDocumentEvent {
+	history := make([]*ExportCredentialDataDocumentEvent, 0)
+
+	for _, item := range res.Erasures {
+		history = append(history, &ExportCredentialDataDocumentEvent{
+			Type:       ExportCredentialDataEventErasure,
+			Reason:     item.Reason,
+			OccurredAt: item.ErasedAt,
+		})
+	}
+
+	for _, item := range res.Deletions {
+		history = append(history, &ExportCredentialDataDocumentEvent{
+			Type:        ExportCredentialDataEventDeletion,
+			ScheduledAt: lo.ToPtr(item.ScheduledAt),
+			OccurredAt:  item.ErasedAt,
+		})
+	}
+
+	for _, item := range res.RoleChanges {
+		history = append(history, &ExportCredentialDataDocumentEvent{
+			Type:       ExportCredentialDataEventRoleChange,
+			Reason:     string(item.Reason),
+			FromRole:   item.FromRole.String(),
+			ToRole:     item.ToRole.String(),
+			OccurredAt: item.ChangedAt,
+		})
+	}
+
+	for _, item := range res.Merges {
+		event := &ExportCredentialDataDocumentEvent{
+			Type:         ExportCredentialDataEventMerged,
+			CredentialID: item.SurvivorID.String(),
+			OccurredAt:   item.MergedAt,
+		}
+
+		if item.SurvivorID == id {
+			event.Type = ExportCredentialDataEventAbsorbed
+			event.CredentialID = item.LoserID.String()
+		}
+
+		history = append(history, event)
+	}
+
+	for _, item := range res.InvitationRedemptions {
+		history = append(history, &ExportCredentialDataDocumentEvent{
+			Type:         ExportCredentialDataEventInvitationRedeemed,
+			InvitationID: item.InvitationID.String(),
+			OccurredAt:   item.RedeemedAt,
+		})
+	}
+
+	slices.SortStableFunc(history, func(a, b *ExportCredentialDataDocumentEvent) int {
+		return a.OccurredAt.Compare(b.OccurredAt)
+	})
+
+	return history
+}
+
+func NewExportCredentialData(
+	dao dao.ExportCredentialData, policy entities.ExportRedactionPolicy,
+) ExportCredentialData {
+	return &exportCredentialDataImpl{dao: dao, policy: policy}
+}
@@ -0,0 +1,431 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestExportCredentialData(t *testing.T) {
+	daoResponse := &dao.ExportCredentialDataResult{
+		Credential: &entities.Credential{
+			ID:                   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Email:                "user@gmail.com",
+			CanonicalEmail:       "user@gmail.com",
+			Role:                 entities.RoleAdmin,
+			RoleExpiresAt:        lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+			FallbackRole:         lo.ToPtr(entities.RoleEarlyAccessProgram),
+			Labels:               map[string]string{"team": "core"},
+			TokenEpoch:           2,
+			PasswordTokenID:      "password-token",
+			ResetPasswordTokenID: "reset-password-token",
+			// Expired tokens are not exported.
+			ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			DeletionScheduledAt:         lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			LegalHold:                   true,
+			LegalHoldReason:             "dispute-1",
+			LegalHoldSetBy:              "agent@example.com",
+			LegalHoldSetAt:              lo.ToPtr(time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC)),
+			CreatedAt:                   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Identities: []*entities.CredentialIdentity{
+			{
+				Provider:        "github",
+				Subject:         "subject-1",
+				EmailAtLinkTime: "user@github.com",
+				LinkedAt:        time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Factors: []*entities.CredentialFactor{
+			{
+				ID:        uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+				Type:      entities.FactorTypeTOTP,
+				Label:     "phone",
+				SecretRef: "secret-ref-1",
+				CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		APIKeys: []*entities.APIKey{},
+		Consents: []*entities.CredentialConsent{
+			{
+				Document:   entities.ConsentDocumentTOS,
+				Version:    "v1",
+				AcceptedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Notes: []*entities.CredentialNote{
+			{
+				Author:    "agent@example.com",
+				Body:      "refund issued",
+				Pinned:    true,
+				CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Erasures: []*entities.CredentialErasure{
+			{
+				Reason:   "ticket-1",
+				ErasedAt: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		RoleChanges: []*entities.CredentialRoleChange{
+			{
+				FromRole:  entities.RoleEarlyAccessProgram,
+				ToRole:    entities.RoleAdmin,
+				Reason:    entities.RoleChangeReasonRequestApproved,
+				ChangedAt: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Merges: []*entities.CredentialMerge{
+			{
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				MergedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				LoserID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				SurvivorID: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				MergedAt:   time.Date(2021, 1, 7, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Deletions: []*entities.CredentialDeletion{
+			{
+				ScheduledAt: time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC),
+				ErasedAt:    time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Invitations: []*entities.Invitation{
+			{
+				ID:        uuid.MustParse("00000000-0000-0000-0006-000000000001"),
+				Code:      "CODE1",
+				Role:      entities.RoleEarlyAccessProgram,
+				MaxUses:   5,
+				Uses:      1,
+				CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		InvitationRedemptions: []*entities.InvitationRedemption{
+			{
+				InvitationID: uuid.MustParse("00000000-0000-0000-0006-000000000002"),
+				RedeemedAt:   time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		WaitlistEntries: []*entities.RoleWaitlistEntry{
+			{
+				Role:       entities.RoleCore,
+				Seq:        1,
+				EnqueuedAt: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		RoleChangeRequests: []*entities.RoleChangeRequest{
+			{
+				ID:         uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				Role:       entities.RoleAdmin,
+				Status:     entities.RoleChangeRequestStatusApproved,
+				ProposedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				DecidedBy:  lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000004")),
+				ExpiresAt:  time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+				DecidedAt:  lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:  time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				// Pending requests that expired are exported as such.
+				ID:         uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+				Role:       entities.RoleCore,
+				Status:     entities.RoleChangeRequestStatusPending,
+				ProposedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+				ExpiresAt:  time.Date(2021, 1, 12, 0, 0, 0, 0, time.UTC),
+				CreatedAt:  time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	expectDocument := func(
+		passwordTokenID, secretRef, invitationCode, noteAuthor, noteBody *string,
+	) *services.ExportCredentialDataDocument {
+		return &services.ExportCredentialDataDocument{
+			SchemaVersion: entities.ExportSchemaVersion,
+			Credential: &services.ExportCredentialDataDocumentCredential{
+				ID:                  "00000000-0000-0000-0000-000000000001",
+				Kind:                "user",
+				Email:               "user@gmail.com",
+				CanonicalEmail:      "user@gmail.com",
+				Role:                "admin",
+				RoleExpiresAt:       lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:        "early-access-program",
+				Labels:              map[string]string{"team": "core"},
+				TokenEpoch:          2,
+				PasswordTokenID:     passwordTokenID,
+				DeletionScheduledAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				LegalHold:           true,
+				LegalHoldSetAt:      lo.ToPtr(time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Identities: []*services.ExportCredentialDataDocumentIdentity{
+				{
+					Provider:        "github",
+					Subject:         "subject-1",
+					EmailAtLinkTime: "user@github.com",
+					LinkedAt:        time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			Factors: []*services.ExportCredentialDataDocumentFactor{
+				{
+					ID:        "00000000-0000-0000-0001-000000000001",
+					Type:      "totp",
+					Label:     "phone",
+					SecretRef: secretRef,
+					CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			APIKeys: []*services.ExportCredentialDataDocumentAPIKey{},
+			Consents: []*services.ExportCredentialDataDocumentConsent{
+				{
+					Document:   "tos",
+					Version:    "v1",
+					AcceptedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			Notes: []*services.ExportCredentialDataDocumentNote{
+				{
+					Author:    noteAuthor,
+					Body:      noteBody,
+					Pinned:    true,
+					CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			Invitations: []*services.ExportCredentialDataDocumentInvitation{
+				{
+					ID:        "00000000-0000-0000-0006-000000000001",
+					Code:      invitationCode,
+					Role:      "early-access-program",
+					MaxUses:   5,
+					Uses:      1,
+					CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			WaitlistEntries: []*services.ExportCredentialDataDocumentWaitlistEntry{
+				{
+					Role:       "core",
+					EnqueuedAt: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			RoleChangeRequests: []*services.ExportCredentialDataDocumentRoleChangeRequest{
+				{
+					ID:        "00000000-0000-0000-0007-000000000001",
+					Role:      "admin",
+					Status:    "approved",
+					ExpiresAt: time.Date(2021, 1, 10, 0, 0, 0, 0, time.UTC),
+					DecidedAt: lo.ToPtr(time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC)),
+					CreatedAt: time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:        "00000000-0000-0000-0007-000000000002",
+					Role:      "core",
+					Status:    "expired",
+					ExpiresAt: time.Date(2021, 1, 12, 0, 0, 0, 0, time.UTC),
+					CreatedAt: time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			// Events are sorted by date, regardless of their type.
+			History: []*services.ExportCredentialDataDocumentEvent{
+				{
+					Type:         services.ExportCredentialDataEventInvitationRedeemed,
+					InvitationID: "00000000-0000-0000-0006-000000000002",
+					OccurredAt:   time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+				},
+				{
+					Type:         services.ExportCredentialDataEventAbsorbed,
+					CredentialID: "00000000-0000-0000-0000-000000000002",
+					OccurredAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Type:       services.ExportCredentialDataEventErasure,
+					Reason:     "ticket-1",
+					OccurredAt: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Type:       services.ExportCredentialDataEventRoleChange,
+					Reason:     "request_approved",
+					FromRole:   "early-access-program",
+					ToRole:     "admin",
+					OccurredAt: time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Type:         services.ExportCredentialDataEventMerged,
+					CredentialID: "00000000-0000-0000-0000-000000000003",
+					OccurredAt:   time.Date(2021, 1, 7, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Type:        services.ExportCredentialDataEventDeletion,
+					ScheduledAt: lo.ToPtr(time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC)),
+					OccurredAt:  time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name string
+
+		request *services.ExportCredentialDataRequest
+		policy  entities.ExportRedactionPolicy
+
+		shouldCallExportCredentialDataDAO bool
+		exportCredentialDataDAOResponse   *dao.ExportCredentialDataResult
+		exportCredentialDataDAOError      error
+
+		expect    *services.ExportCredentialDataDocument
+		expectErr error
+	}{
+		{
+			name: "Omit",
+
+			request: &services.ExportCredentialDataRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+			policy: entities.ExportRedactionPolicy{
+				TokenIDs:      entities.RedactionModeOmit,
+				FactorSecrets: entities.RedactionModeOmit,
+			},
+
+			shouldCallExportCredentialDataDAO: true,
+			exportCredentialDataDAOResponse:   daoResponse,
+
+			expect: expectDocument(nil, nil, nil, nil, nil),
+		},
+		{
+			name: "Mask",
+
+			request: &services.ExportCredentialDataRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+			policy: entities.ExportRedactionPolicy{
+				TokenIDs:      entities.RedactionModeMask,
+				FactorSecrets: entities.RedactionModeMask,
+				Notes:         entities.RedactionModeMask,
+			},
+
+			shouldCallExportCredentialDataDAO: true,
+			exportCredentialDataDAOResponse:   daoResponse,
+
+			expect: expectDocument(
+				lo.ToPtr(entities.RedactedValue),
+				lo.ToPtr(entities.RedactedValue),
+				lo.ToPtr(entities.RedactedValue),
+				lo.ToPtr(entities.RedactedValue),
+				lo.ToPtr(entities.RedactedValue),
+			),
+		},
+		{
+			name: "Keep",
+
+			request: &services.ExportCredentialDataRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+			policy: entities.ExportRedactionPolicy{
+				TokenIDs:      entities.RedactionModeKeep,
+				FactorSecrets: entities.RedactionModeKeep,
+				Notes:         entities.RedactionModeKeep,
+			},
+
+			shouldCallExportCredentialDataDAO: true,
+			exportCredentialDataDAOResponse:   daoResponse,
+
+			expect: expectDocument(
+				lo.ToPtr("password-token"),
+				lo.ToPtr("secret-ref-1"),
+				lo.ToPtr("CODE1"),
+				lo.ToPtr("agent@example.com"),
+				lo.ToPtr("refund issued"),
+			),
+		},
+		{
+			name: "UnknownPolicy",
+
+			request: &services.ExportCredentialDataRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+			policy: entities.ExportRedactionPolicy{
+				TokenIDs:      "foo",
+				FactorSecrets: "",
+				Notes:         "foo",
+			},
+
+			shouldCallExportCredentialDataDAO: true,
+			exportCredentialDataDAOResponse:   daoResponse,
+
+			expect: expectDocument(nil, nil, nil, nil, nil),
+		},
+		{
+			name: "DAOError",
+
+			request: &services.ExportCredentialDataRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallExportCredentialDataDAO: true,
+			exportCredentialDataDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrExportCredentialData,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.ExportCredentialDataRequest{
+				ID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidExportCredentialDataRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			exportCredentialDataDAO := daomocks.NewMockExportCredentialData(t)
+
+			if testCase.shouldCallExportCredentialDataDAO {
+				// The service alters the credentials it receives, so each call gets a fresh copy.
+				var response *dao.ExportCredentialDataResult
+				if testCase.exportCredentialDataDAOResponse != nil {
+					credential := *testCase.exportCredentialDataDAOResponse.Credential
+					response = lo.ToPtr(*testCase.exportCredentialDataDAOResponse)
+					response.Credential = &credential
+				}
+
+				exportCredentialDataDAO.
+					On("Exec", context.Background(), uuid.MustParse(testCase.request.ID)).
+					Return(response, testCase.exportCredentialDataDAOError)
+			}
+
+			service := services.NewExportCredentialData(exportCredentialDataDAO, testCase.policy)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if testCase.expect == nil {
+				require.Nil(t, response)
+			} else {
+				document := new(services.ExportCredentialDataDocument)
+				require.NoError(t, json.Unmarshal(response.Document, document))
+
+				require.False(t, document.ExportedAt.IsZero())
+				document.ExportedAt = time.Time{}
+
+				require.Equal(t, testCase.expect, document)
+			}
+
+			exportCredentialDataDAO.AssertExpectations(t)
+		})
+	}
+}
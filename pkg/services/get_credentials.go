@@ -20,21 +20,50 @@ var (
 
 var getCredentialsValidate = validator.New(validator.WithRequiredStructEnabled())
 
+func init() {
+	entities.RegisterEmail(getCredentialsValidate)
+}
+
 type GetCredentialsRequest struct {
 	ID    string `validate:"required_without=Email,omitempty,len=36"`
-	Email string `validate:"required_without=ID,omitempty,email,max=256"`
+	Email string `validate:"required_without=ID,omitempty,email_address,max=256"`
 }
 
 type GetCredentialsResponse struct {
 	ID    string
 	Email string
 	Role  entities.Role
+	// RoleExpiresAt is set when Role is only granted for a limited time. The credentials revert to FallbackRole
+	// once it expires.
+	RoleExpiresAt *time.Time
+	FallbackRole  *entities.Role
+
+	EmailVerifiedAt *time.Time
+
+	Labels      map[string]string
+	MFARequired bool
+	TokenEpoch  int64
 
 	EmailValidationTokenID        string
 	PendingEmailValidationTokenID string
 	PasswordTokenID               string
 	ResetPasswordTokenID          string
 
+	EmailValidationTokenExpiresAt        *time.Time
+	PendingEmailValidationTokenExpiresAt *time.Time
+	PasswordTokenExpiresAt               *time.Time
+	ResetPasswordTokenExpiresAt          *time.Time
+
+	DeletionScheduledAt *time.Time
+	ErasedAt            *time.Time
+
+	LegalHold       bool
+	LegalHoldReason string
+	LegalHoldSetBy  string
+	LegalHoldSetAt  *time.Time
+
+	HasNotes bool
+
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 }
@@ -65,7 +94,7 @@ func (service *getCredentialsImpl) Exec(
 	}
 
 	request := &dao.GetCredentialsRequest{
-		Email: data.Email,
+		Email: entities.NormalizeEmail(data.Email),
 		ID:    credentialsID,
 	}
 
@@ -74,16 +103,44 @@ func (service *getCredentialsImpl) Exec(
 		return nil, errors.Join(ErrGetCredentials, err)
 	}
 
+	now := time.Now()
+	credentials.ClearExpiredTokens(now)
+	credentials.ClearExpiredRoleGrant(now)
+
 	return &GetCredentialsResponse{
 		ID:    credentials.ID.String(),
 		Email: credentials.Email,
 		Role:  credentials.Role,
 
+		RoleExpiresAt: credentials.RoleExpiresAt,
+		FallbackRole:  credentials.FallbackRole,
+
+		EmailVerifiedAt: credentials.EmailVerifiedAt,
+
+		Labels:      credentials.Labels,
+		MFARequired: credentials.MFARequired,
+		TokenEpoch:  credentials.TokenEpoch,
+
 		EmailValidationTokenID:        credentials.EmailValidationTokenID,
 		PendingEmailValidationTokenID: credentials.PendingEmailValidationTokenID,
 		PasswordTokenID:               credentials.PasswordTokenID,
 		ResetPasswordTokenID:          credentials.ResetPasswordTokenID,
 
+		EmailValidationTokenExpiresAt:        credentials.EmailValidationTokenExpiresAt,
+		PendingEmailValidationTokenExpiresAt: credentials.PendingEmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:               credentials.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:          credentials.ResetPasswordTokenExpiresAt,
+
+		DeletionScheduledAt: credentials.DeletionScheduledAt,
+		ErasedAt:            credentials.ErasedAt,
+
+		LegalHold:       credentials.LegalHold,
+		LegalHoldReason: credentials.LegalHoldReason,
+		LegalHoldSetBy:  credentials.LegalHoldSetBy,
+		LegalHoldSetAt:  credentials.LegalHoldSetAt,
+
+		HasNotes: credentials.HasNotes,
+
 		CreatedAt: credentials.CreatedAt,
 		UpdatedAt: credentials.UpdatedAt,
 	}, nil
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidGetCredentialsByIdentityRequest = errors.New("invalid get credentials by identity request")
+	ErrGetCredentialsByIdentity               = errors.New("get credentials by identity")
+)
+
+var getCredentialsByIdentityValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterIdentityProvider(getCredentialsByIdentityValidate)
+}
+
+type GetCredentialsByIdentityRequest struct {
+	Provider string `validate:"required,max=64,identity_provider"`
+	Subject  string `validate:"required,max=256"`
+}
+
+type GetCredentialsByIdentityResponse struct {
+	ID    string
+	Email string
+	Role  entities.Role
+	// RoleExpiresAt is set when Role is only granted for a limited time. The credentials revert to FallbackRole
+	// once it expires.
+	RoleExpiresAt *time.Time
+	FallbackRole  *entities.Role
+
+	EmailVerifiedAt *time.Time
+
+	Labels      map[string]string
+	MFARequired bool
+	TokenEpoch  int64
+
+	EmailValidationTokenID        string
+	PendingEmailValidationTokenID string
+	PasswordTokenID               string
+	ResetPasswordTokenID          string
+
+	EmailValidationTokenExpiresAt        *time.Time
+	PendingEmailValidationTokenExpiresAt *time.Time
+	PasswordTokenExpiresAt               *time.Time
+	ResetPasswordTokenExpiresAt          *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type GetCredentialsByIdentity interface {
+	Exec(ctx context.Context, data *GetCredentialsByIdentityRequest) (*GetCredentialsByIdentityResponse, error)
+}
+
+type getCredentialsByIdentityImpl struct {
+	dao dao.GetCredentialsByIdentity
+}
+
+func (service *getCredentialsByIdentityImpl) Exec(
+	ctx context.Context, data *GetCredentialsByIdentityRequest,
+) (*GetCredentialsByIdentityResponse, error) {
+	if err := getCredentialsByIdentityValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidGetCredentialsByIdentityRequest, err)
+	}
+
+	credentials, err := service.dao.Exec(ctx, &dao.GetCredentialsByIdentityRequest{
+		Provider: data.Provider,
+		Subject:  data.Subject,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrGetCredentialsByIdentity, err)
+	}
+
+	now := time.Now()
+	credentials.ClearExpiredTokens(now)
+	credentials.ClearExpiredRoleGrant(now)
+
+	return &GetCredentialsByIdentityResponse{
+		ID:    credentials.ID.String(),
+		Email: credentials.Email,
+		Role:  credentials.Role,
+
+		RoleExpiresAt: credentials.RoleExpiresAt,
+		FallbackRole:  credentials.FallbackRole,
+
+		EmailVerifiedAt: credentials.EmailVerifiedAt,
+
+		Labels:      credentials.Labels,
+		MFARequired: credentials.MFARequired,
+		TokenEpoch:  credentials.TokenEpoch,
+
+		EmailValidationTokenID:        credentials.EmailValidationTokenID,
+		PendingEmailValidationTokenID: credentials.PendingEmailValidationTokenID,
+		PasswordTokenID:               credentials.PasswordTokenID,
+		ResetPasswordTokenID:          credentials.ResetPasswordTokenID,
+
+		EmailValidationTokenExpiresAt:        credentials.EmailValidationTokenExpiresAt,
+		PendingEmailValidationTokenExpiresAt: credentials.PendingEmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:               credentials.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:          credentials.ResetPasswordTokenExpiresAt,
+
+		CreatedAt: credentials.CreatedAt,
+		UpdatedAt: credentials.UpdatedAt,
+	}, nil
+}
+
+func NewGetCredentialsByIdentity(dao dao.GetCredentialsByIdentity) GetCredentialsByIdentity {
+	return &getCredentialsByIdentityImpl{dao: dao}
+}
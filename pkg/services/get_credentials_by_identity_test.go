@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestGetCredentialsByIdentity(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.GetCredentialsByIdentityRequest
+
+		shouldCallGetCredentialsByIdentityDAO bool
+		getCredentialsByIdentityDAOResponse   *entities.Credential
+		getCredentialsByIdentityDAOError      error
+
+		expect    *services.GetCredentialsByIdentityResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.GetCredentialsByIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-1",
+			},
+
+			shouldCallGetCredentialsByIdentityDAO: true,
+			getCredentialsByIdentityDAOResponse: &entities.Credential{
+				ID:                          uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:                       "user@gmail.com",
+				Role:                        entities.RoleCore,
+				PasswordTokenID:             "00000000-0000-0000-0000-000000000002",
+				ResetPasswordTokenID:        "00000000-0000-0000-0000-000000000003",
+				ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                   lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.GetCredentialsByIdentityResponse{
+				ID:              "00000000-0000-0000-0000-000000000001",
+				Email:           "user@gmail.com",
+				Role:            entities.RoleCore,
+				PasswordTokenID: "00000000-0000-0000-0000-000000000002",
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAO/NotFound",
+
+			request: &services.GetCredentialsByIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-1",
+			},
+
+			shouldCallGetCredentialsByIdentityDAO: true,
+			getCredentialsByIdentityDAOError:      dao.ErrCredentialsNotFound,
+
+			expectErr: dao.ErrCredentialsNotFound,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.GetCredentialsByIdentityRequest{
+				Provider: "google",
+				Subject:  "subject-1",
+			},
+
+			shouldCallGetCredentialsByIdentityDAO: true,
+			getCredentialsByIdentityDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrGetCredentialsByIdentity,
+		},
+		{
+			name: "Invalid/NoProvider",
+
+			request: &services.GetCredentialsByIdentityRequest{
+				Subject: "subject-1",
+			},
+
+			expectErr: services.ErrInvalidGetCredentialsByIdentityRequest,
+		},
+		{
+			name: "Invalid/NoSubject",
+
+			request: &services.GetCredentialsByIdentityRequest{
+				Provider: "google",
+			},
+
+			expectErr: services.ErrInvalidGetCredentialsByIdentityRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			getCredentialsByIdentityDAO := daomocks.NewMockGetCredentialsByIdentity(t)
+
+			if testCase.shouldCallGetCredentialsByIdentityDAO {
+				getCredentialsByIdentityDAO.
+					On("Exec", context.Background(), &dao.GetCredentialsByIdentityRequest{
+						Provider: testCase.request.Provider,
+						Subject:  testCase.request.Subject,
+					}).
+					Return(testCase.getCredentialsByIdentityDAOResponse, testCase.getCredentialsByIdentityDAOError)
+			}
+
+			service := services.NewGetCredentialsByIdentity(getCredentialsByIdentityDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			getCredentialsByIdentityDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -24,8 +24,10 @@ func TestGetCredentials(t *testing.T) {
 		request *services.GetCredentialsRequest
 
 		shouldCallGetCredentialsDAO bool
-		getCredentialsDAOResponse   *entities.Credential
-		getCredentialsDAOError      error
+		// getCredentialsDAOEmail is the email expected by the DAO, when it differs from the requested one.
+		getCredentialsDAOEmail    string
+		getCredentialsDAOResponse *entities.Credential
+		getCredentialsDAOError    error
 
 		expect    *services.GetCredentialsResponse
 		expectErr error
@@ -40,24 +42,32 @@ func TestGetCredentials(t *testing.T) {
 			shouldCallGetCredentialsDAO: true,
 			getCredentialsDAOResponse: &entities.Credential{
 				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				TokenEpoch:                    2,
 				Email:                         "user@gmail.com",
 				Role:                          entities.RoleAdmin,
+				EmailVerifiedAt:               lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 				EmailValidationTokenID:        "00000000-0000-0000-0000-000000000001",
 				PendingEmailValidationTokenID: "00000000-0000-0000-0000-000000000005",
 				PasswordTokenID:               "00000000-0000-0000-0000-000000000002",
 				ResetPasswordTokenID:          "00000000-0000-0000-0000-000000000003",
+				DeletionScheduledAt:           lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				HasNotes:                      true,
 				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
 
 			expect: &services.GetCredentialsResponse{
 				ID:                            "00000000-0000-0000-0000-000000000004",
+				TokenEpoch:                    2,
 				Email:                         "user@gmail.com",
 				Role:                          entities.RoleAdmin,
+				EmailVerifiedAt:               lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 				EmailValidationTokenID:        "00000000-0000-0000-0000-000000000001",
 				PendingEmailValidationTokenID: "00000000-0000-0000-0000-000000000005",
 				PasswordTokenID:               "00000000-0000-0000-0000-000000000002",
 				ResetPasswordTokenID:          "00000000-0000-0000-0000-000000000003",
+				DeletionScheduledAt:           lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				HasNotes:                      true,
 				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
 				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
@@ -94,6 +104,116 @@ func TestGetCredentials(t *testing.T) {
 				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			// IDN domains are looked up in their punycode form.
+			name: "OK/Email/IDN",
+
+			request: &services.GetCredentialsRequest{
+				Email: "user@Bücher.de",
+			},
+
+			shouldCallGetCredentialsDAO: true,
+			getCredentialsDAOEmail:      "user@xn--bcher-kva.de",
+			getCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:     "user@xn--bcher-kva.de",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.GetCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "user@xn--bcher-kva.de",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/ExpiredTokens",
+
+			request: &services.GetCredentialsRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallGetCredentialsDAO: true,
+			getCredentialsDAOResponse: &entities.Credential{
+				ID:                            uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:                         "user@gmail.com",
+				Role:                          entities.RoleAdmin,
+				EmailValidationTokenID:        "00000000-0000-0000-0000-000000000001",
+				PendingEmailValidationTokenID: "00000000-0000-0000-0000-000000000005",
+				PasswordTokenID:               "00000000-0000-0000-0000-000000000002",
+				ResetPasswordTokenID:          "00000000-0000-0000-0000-000000000003",
+				EmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+				ResetPasswordTokenExpiresAt:   lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.GetCredentialsResponse{
+				ID:                            "00000000-0000-0000-0000-000000000004",
+				Email:                         "user@gmail.com",
+				Role:                          entities.RoleAdmin,
+				EmailValidationTokenID:        "00000000-0000-0000-0000-000000000001",
+				PendingEmailValidationTokenID: "00000000-0000-0000-0000-000000000005",
+				PasswordTokenID:               "00000000-0000-0000-0000-000000000002",
+				EmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "OK/RoleGrant",
+
+			request: &services.GetCredentialsRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallGetCredentialsDAO: true,
+			getCredentialsDAOResponse: &entities.Credential{
+				ID:            uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:         "user@gmail.com",
+				Role:          entities.RoleAdmin,
+				RoleExpiresAt: lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.GetCredentialsResponse{
+				ID:            "00000000-0000-0000-0000-000000000004",
+				Email:         "user@gmail.com",
+				Role:          entities.RoleAdmin,
+				RoleExpiresAt: lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "OK/ExpiredRoleGrant",
+
+			request: &services.GetCredentialsRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallGetCredentialsDAO: true,
+			getCredentialsDAOResponse: &entities.Credential{
+				ID:            uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:         "user@gmail.com",
+				Role:          entities.RoleAdmin,
+				RoleExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.GetCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "user@gmail.com",
+				Role:      entities.RoleEarlyAccessProgram,
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
 		{
 			name: "DAO/Error",
 
@@ -139,7 +259,9 @@ func TestGetCredentials(t *testing.T) {
 								id = uuid.MustParse(testCase.request.ID)
 							}
 
-							return request.ID == id && request.Email == testCase.request.Email
+							email := lo.CoalesceOrEmpty(testCase.getCredentialsDAOEmail, testCase.request.Email)
+
+							return request.ID == id && request.Email == email
 						}),
 					).
 					Return(testCase.getCredentialsDAOResponse, testCase.getCredentialsDAOError)
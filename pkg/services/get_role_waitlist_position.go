@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidGetRoleWaitlistPositionRequest = errors.New("invalid get role waitlist position request")
+	ErrGetRoleWaitlistPosition               = errors.New("get role waitlist position")
+)
+
+var getRoleWaitlistPositionValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterRole(getRoleWaitlistPositionValidate)
+}
+
+type GetRoleWaitlistPositionRequest struct {
+	CredentialID string        `validate:"required,len=36"`
+	Role         entities.Role `validate:"required,role"`
+}
+
+type GetRoleWaitlistPositionResponse struct {
+	CredentialID string
+	Role         entities.Role
+
+	// Position is the place of the credentials in the waitlist, starting at 1.
+	Position int
+	Waiting  int
+
+	EnqueuedAt time.Time
+}
+
+type GetRoleWaitlistPosition interface {
+	Exec(ctx context.Context, data *GetRoleWaitlistPositionRequest) (*GetRoleWaitlistPositionResponse, error)
+}
+
+type getRoleWaitlistPositionImpl struct {
+	dao dao.GetRoleWaitlistPosition
+}
+
+func (service *getRoleWaitlistPositionImpl) Exec(
+	ctx context.Context, data *GetRoleWaitlistPositionRequest,
+) (*GetRoleWaitlistPositionResponse, error) {
+	if err := getRoleWaitlistPositionValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidGetRoleWaitlistPositionRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidGetRoleWaitlistPositionRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	position, err := service.dao.Exec(ctx, &dao.GetRoleWaitlistPositionRequest{
+		CredentialID: credentialID,
+		Role:         data.Role,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrGetRoleWaitlistPosition, err)
+	}
+
+	return &GetRoleWaitlistPositionResponse{
+		CredentialID: position.CredentialID.String(),
+		Role:         position.Role,
+
+		Position: position.Position,
+		Waiting:  position.Waiting,
+
+		EnqueuedAt: position.EnqueuedAt,
+	}, nil
+}
+
+func NewGetRoleWaitlistPosition(dao dao.GetRoleWaitlistPosition) GetRoleWaitlistPosition {
+	return &getRoleWaitlistPositionImpl{dao: dao}
+}
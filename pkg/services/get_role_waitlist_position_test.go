@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestGetRoleWaitlistPosition(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.GetRoleWaitlistPositionRequest
+
+		shouldCallGetRoleWaitlistPositionDAO bool
+		getRoleWaitlistPositionDAORequest    *dao.GetRoleWaitlistPositionRequest
+		getRoleWaitlistPositionDAOResponse   *entities.RoleWaitlistPosition
+		getRoleWaitlistPositionDAOError      error
+
+		expect    *services.GetRoleWaitlistPositionResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.GetRoleWaitlistPositionRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+
+			shouldCallGetRoleWaitlistPositionDAO: true,
+			getRoleWaitlistPositionDAORequest: &dao.GetRoleWaitlistPositionRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+			getRoleWaitlistPositionDAOResponse: &entities.RoleWaitlistPosition{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleEarlyAccessProgram,
+				Position:     3,
+				Waiting:      5,
+				EnqueuedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.GetRoleWaitlistPositionResponse{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+				Position:     3,
+				Waiting:      5,
+				EnqueuedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.GetRoleWaitlistPositionRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+
+			shouldCallGetRoleWaitlistPositionDAO: true,
+			getRoleWaitlistPositionDAORequest: &dao.GetRoleWaitlistPositionRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+			getRoleWaitlistPositionDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrGetRoleWaitlistPosition,
+		},
+		{
+			name: "InvalidRole",
+
+			request: &services.GetRoleWaitlistPositionRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Role:         "fake-role",
+			},
+
+			expectErr: services.ErrInvalidGetRoleWaitlistPositionRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.GetRoleWaitlistPositionRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+
+			expectErr: services.ErrInvalidGetRoleWaitlistPositionRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			getRoleWaitlistPositionDAO := daomocks.NewMockGetRoleWaitlistPosition(t)
+
+			if testCase.shouldCallGetRoleWaitlistPositionDAO {
+				getRoleWaitlistPositionDAO.
+					On("Exec", context.Background(), testCase.getRoleWaitlistPositionDAORequest).
+					Return(testCase.getRoleWaitlistPositionDAOResponse, testCase.getRoleWaitlistPositionDAOError)
+			}
+
+			service := services.NewGetRoleWaitlistPosition(getRoleWaitlistPositionDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			getRoleWaitlistPositionDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidGrantRoleRequest = errors.New("invalid grant role request")
+	ErrGrantRole               = errors.New("grant role")
+)
+
+var grantRoleValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterRole(grantRoleValidate)
+}
+
+type GrantRoleRequest struct {
+	ID string `validate:"required,len=36"`
+	// Role cannot be privileged. Privileged roles require an approved role change request.
+	Role entities.Role `validate:"role"`
+	// ExpiresAt is the date the grant ends, and must be in the future.
+	ExpiresAt time.Time `validate:"required,gt"`
+	// FallbackRole is the role restored once the grant expires. Leave nil to revert to the role the credentials had
+	// before their first active grant.
+	FallbackRole *entities.Role `validate:"omitempty,role"`
+}
+
+type GrantRoleResponse struct {
+	ID string
+
+	Role          entities.Role
+	RoleExpiresAt *time.Time
+	FallbackRole  *entities.Role
+
+	UpdatedAt *time.Time
+}
+
+type GrantRole interface {
+	Exec(ctx context.Context, data *GrantRoleRequest) (*GrantRoleResponse, error)
+}
+
+type grantRoleImpl struct {
+	dao dao.GrantRole
+}
+
+func (service *grantRoleImpl) Exec(ctx context.Context, data *GrantRoleRequest) (*GrantRoleResponse, error) {
+	if err := grantRoleValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidGrantRoleRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidGrantRoleRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err),
+		)
+	}
+
+	credentials, err := service.dao.Exec(ctx, credentialsID, time.Now(), &dao.GrantRoleRequest{
+		Role:         data.Role,
+		ExpiresAt:    data.ExpiresAt,
+		FallbackRole: data.FallbackRole,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrGrantRole, err)
+	}
+
+	return &GrantRoleResponse{
+		ID: credentials.ID.String(),
+
+		Role:          credentials.Role,
+		RoleExpiresAt: credentials.RoleExpiresAt,
+		FallbackRole:  credentials.FallbackRole,
+
+		UpdatedAt: credentials.UpdatedAt,
+	}, nil
+}
+
+func NewGrantRole(dao dao.GrantRole) GrantRole {
+	return &grantRoleImpl{dao: dao}
+}
@@ -0,0 +1,202 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestGrantRole(t *testing.T) {
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	testCases := []struct {
+		name string
+
+		request *services.GrantRoleRequest
+
+		shouldCallGrantRoleDAO bool
+		grantRoleDAORequest    *dao.GrantRoleRequest
+		grantRoleDAOResponse   *entities.Credential
+		grantRoleDAOError      error
+
+		expect    *services.GrantRoleResponse
+		expectErr error
+	}{
+		{
+			name: "Grant",
+
+			request: &services.GrantRoleRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleAdmin,
+				ExpiresAt: expiresAt,
+			},
+
+			shouldCallGrantRoleDAO: true,
+			grantRoleDAORequest: &dao.GrantRoleRequest{
+				Role:      entities.RoleAdmin,
+				ExpiresAt: expiresAt,
+			},
+			grantRoleDAOResponse: &entities.Credential{
+				ID:            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:         "user@gmail.com",
+				Role:          entities.RoleAdmin,
+				RoleExpiresAt: lo.ToPtr(expiresAt),
+				FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.GrantRoleResponse{
+				ID:            "00000000-0000-0000-0000-000000000001",
+				Role:          entities.RoleAdmin,
+				RoleExpiresAt: lo.ToPtr(expiresAt),
+				FallbackRole:  lo.ToPtr(entities.RoleEarlyAccessProgram),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Grant/FallbackRole",
+
+			request: &services.GrantRoleRequest{
+				ID:           "00000000-0000-0000-0000-000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+				ExpiresAt:    expiresAt,
+				FallbackRole: lo.ToPtr(entities.RoleNone),
+			},
+
+			shouldCallGrantRoleDAO: true,
+			grantRoleDAORequest: &dao.GrantRoleRequest{
+				Role:         entities.RoleEarlyAccessProgram,
+				ExpiresAt:    expiresAt,
+				FallbackRole: lo.ToPtr(entities.RoleNone),
+			},
+			grantRoleDAOResponse: &entities.Credential{
+				ID:            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:         "user@gmail.com",
+				Role:          entities.RoleEarlyAccessProgram,
+				RoleExpiresAt: lo.ToPtr(expiresAt),
+				FallbackRole:  lo.ToPtr(entities.RoleNone),
+				CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.GrantRoleResponse{
+				ID:            "00000000-0000-0000-0000-000000000001",
+				Role:          entities.RoleEarlyAccessProgram,
+				RoleExpiresAt: lo.ToPtr(expiresAt),
+				FallbackRole:  lo.ToPtr(entities.RoleNone),
+				UpdatedAt:     lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.GrantRoleRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleAdmin,
+				ExpiresAt: expiresAt,
+			},
+
+			shouldCallGrantRoleDAO: true,
+			grantRoleDAORequest: &dao.GrantRoleRequest{
+				Role:      entities.RoleAdmin,
+				ExpiresAt: expiresAt,
+			},
+			grantRoleDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrGrantRole,
+		},
+		{
+			name: "ExpiresInThePast",
+
+			request: &services.GrantRoleRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleAdmin,
+				ExpiresAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expectErr: services.ErrInvalidGrantRoleRequest,
+		},
+		{
+			name: "MissingExpiresAt",
+
+			request: &services.GrantRoleRequest{
+				ID:   "00000000-0000-0000-0000-000000000001",
+				Role: entities.RoleAdmin,
+			},
+
+			expectErr: services.ErrInvalidGrantRoleRequest,
+		},
+		{
+			name: "InvalidRole",
+
+			request: &services.GrantRoleRequest{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Role:      "fake-role",
+				ExpiresAt: expiresAt,
+			},
+
+			expectErr: services.ErrInvalidGrantRoleRequest,
+		},
+		{
+			name: "InvalidFallbackRole",
+
+			request: &services.GrantRoleRequest{
+				ID:           "00000000-0000-0000-0000-000000000001",
+				Role:         entities.RoleAdmin,
+				ExpiresAt:    expiresAt,
+				FallbackRole: lo.ToPtr(entities.Role("fake-role")),
+			},
+
+			expectErr: services.ErrInvalidGrantRoleRequest,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.GrantRoleRequest{
+				ID:        "00000000x0000x0000x0000x000000000001",
+				Role:      entities.RoleAdmin,
+				ExpiresAt: expiresAt,
+			},
+
+			expectErr: services.ErrInvalidGrantRoleRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			grantRoleDAO := daomocks.NewMockGrantRole(t)
+
+			if testCase.shouldCallGrantRoleDAO {
+				grantRoleDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.grantRoleDAORequest,
+					).
+					Return(testCase.grantRoleDAOResponse, testCase.grantRoleDAOError)
+			}
+
+			service := services.NewGrantRole(grantRoleDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			grantRoleDAO.AssertExpectations(t)
+		})
+	}
+}
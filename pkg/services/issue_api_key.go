@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidIssueAPIKeyRequest = errors.New("invalid issue api key request")
+	ErrIssueAPIKey               = errors.New("issue api key")
+)
+
+var issueAPIKeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterAPIKeyScope(issueAPIKeyValidate)
+}
+
+type IssueAPIKeyRequest struct {
+	CredentialID string   `validate:"required,len=36"`
+	Scopes       []string `validate:"omitempty,max=32,dive,required,max=128,api_key_scope"`
+	// ExpiresAt must be in the future. Leave nil for a key that never expires.
+	ExpiresAt *time.Time `validate:"omitempty,gt"`
+}
+
+type IssueAPIKeyResponse struct {
+	ID           string
+	CredentialID string
+
+	// Key is only returned once, when the key is issued. It cannot be retrieved afterward.
+	Key    string
+	Prefix string
+	Scopes []string
+
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+type IssueAPIKey interface {
+	Exec(ctx context.Context, data *IssueAPIKeyRequest) (*IssueAPIKeyResponse, error)
+}
+
+type issueAPIKeyImpl struct {
+	dao dao.IssueAPIKey
+}
+
+func (service *issueAPIKeyImpl) Exec(ctx context.Context, data *IssueAPIKeyRequest) (*IssueAPIKeyResponse, error) {
+	if err := issueAPIKeyValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidIssueAPIKeyRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidIssueAPIKeyRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	key, prefix, hash, err := entities.GenerateAPIKey()
+	if err != nil {
+		return nil, errors.Join(ErrIssueAPIKey, err)
+	}
+
+	apiKey, err := service.dao.Exec(ctx, uuid.New(), time.Now(), &dao.IssueAPIKeyRequest{
+		CredentialID: credentialID,
+		Prefix:       prefix,
+		SecretHash:   hash,
+		Scopes:       data.Scopes,
+		ExpiresAt:    data.ExpiresAt,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrIssueAPIKey, err)
+	}
+
+	return &IssueAPIKeyResponse{
+		ID:           apiKey.ID.String(),
+		CredentialID: apiKey.CredentialID.String(),
+		Key:          key,
+		Prefix:       apiKey.Prefix,
+		Scopes:       apiKey.Scopes,
+		ExpiresAt:    apiKey.ExpiresAt,
+		CreatedAt:    apiKey.CreatedAt,
+	}, nil
+}
+
+func NewIssueAPIKey(dao dao.IssueAPIKey) IssueAPIKey {
+	return &issueAPIKeyImpl{dao: dao}
+}
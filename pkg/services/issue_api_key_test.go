@@ -0,0 +1,155 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestIssueAPIKey(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	testCases := []struct {
+		name string
+
+		request *services.IssueAPIKeyRequest
+
+		shouldCallIssueAPIKeyDAO bool
+		issueAPIKeyDAOResponse   *entities.APIKey
+		issueAPIKeyDAOError      error
+
+		expect    *services.IssueAPIKeyResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.IssueAPIKeyRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Scopes:       []string{"books:read"},
+				ExpiresAt:    &expiresAt,
+			},
+
+			shouldCallIssueAPIKeyDAO: true,
+			issueAPIKeyDAOResponse: &entities.APIKey{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Prefix:       "prefix-1",
+				SecretHash:   []byte("hash-1"),
+				Scopes:       []string{"books:read"},
+				ExpiresAt:    &expiresAt,
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.IssueAPIKeyResponse{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Prefix:       "prefix-1",
+				Scopes:       []string{"books:read"},
+				ExpiresAt:    &expiresAt,
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.IssueAPIKeyRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallIssueAPIKeyDAO: true,
+			issueAPIKeyDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrIssueAPIKey,
+		},
+		{
+			name: "InvalidScope",
+
+			request: &services.IssueAPIKeyRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Scopes:       []string{"Books Read"},
+			},
+
+			expectErr: services.ErrInvalidIssueAPIKeyRequest,
+		},
+		{
+			name: "ExpiresInThePast",
+
+			request: &services.IssueAPIKeyRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				ExpiresAt:    lo.ToPtr(time.Now().Add(-time.Hour)),
+			},
+
+			expectErr: services.ErrInvalidIssueAPIKeyRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.IssueAPIKeyRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidIssueAPIKeyRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			issueAPIKeyDAO := daomocks.NewMockIssueAPIKey(t)
+
+			var issued *dao.IssueAPIKeyRequest
+
+			if testCase.shouldCallIssueAPIKeyDAO {
+				issueAPIKeyDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.Anything,
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						mock.MatchedBy(func(request *dao.IssueAPIKeyRequest) bool {
+							issued = request
+
+							return request.CredentialID == uuid.MustParse(testCase.request.CredentialID) &&
+								request.Prefix != "" &&
+								len(request.SecretHash) > 0 &&
+								slices.Equal(request.Scopes, testCase.request.Scopes) &&
+								request.ExpiresAt == testCase.request.ExpiresAt
+						}),
+					).
+					Return(testCase.issueAPIKeyDAOResponse, testCase.issueAPIKeyDAOError)
+			}
+
+			service := services.NewIssueAPIKey(issueAPIKeyDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if response != nil {
+				// Only the hash of the returned key is sent to the database.
+				prefix, err := entities.ParseAPIKey(response.Key)
+				require.NoError(t, err)
+				require.Equal(t, issued.Prefix, prefix)
+				require.Equal(t, issued.SecretHash, entities.HashAPIKey(response.Key))
+
+				response.Key = ""
+			}
+
+			require.Equal(t, testCase.expect, response)
+
+			issueAPIKeyDAO.AssertExpectations(t)
+		})
+	}
+}
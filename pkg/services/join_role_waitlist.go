@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidJoinRoleWaitlistRequest = errors.New("invalid join role waitlist request")
+	ErrJoinRoleWaitlist               = errors.New("join role waitlist")
+)
+
+var joinRoleWaitlistValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterRole(joinRoleWaitlistValidate)
+}
+
+type JoinRoleWaitlistRequest struct {
+	CredentialID string        `validate:"required,len=36"`
+	Role         entities.Role `validate:"required,role"`
+}
+
+type JoinRoleWaitlistResponse struct {
+	CredentialID string
+	Role         entities.Role
+
+	// Position is the place of the credentials in the waitlist, starting at 1. It is 0 when the credentials were
+	// promoted right away.
+	Position int
+	Waiting  int
+
+	EnqueuedAt time.Time
+}
+
+type JoinRoleWaitlist interface {
+	Exec(ctx context.Context, data *JoinRoleWaitlistRequest) (*JoinRoleWaitlistResponse, error)
+}
+
+type joinRoleWaitlistImpl struct {
+	dao dao.JoinRoleWaitlist
+}
+
+func (service *joinRoleWaitlistImpl) Exec(
+	ctx context.Context, data *JoinRoleWaitlistRequest,
+) (*JoinRoleWaitlistResponse, error) {
+	if err := joinRoleWaitlistValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidJoinRoleWaitlistRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidJoinRoleWaitlistRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	position, err := service.dao.Exec(ctx, uuid.New(), time.Now(), &dao.JoinRoleWaitlistRequest{
+		CredentialID: credentialID,
+		Role:         data.Role,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrJoinRoleWaitlist, err)
+	}
+
+	return &JoinRoleWaitlistResponse{
+		CredentialID: position.CredentialID.String(),
+		Role:         position.Role,
+
+		Position: position.Position,
+		Waiting:  position.Waiting,
+
+		EnqueuedAt: position.EnqueuedAt,
+	}, nil
+}
+
+func NewJoinRoleWaitlist(dao dao.JoinRoleWaitlist) JoinRoleWaitlist {
+	return &joinRoleWaitlistImpl{dao: dao}
+}
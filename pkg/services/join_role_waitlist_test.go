@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestJoinRoleWaitlist(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.JoinRoleWaitlistRequest
+
+		shouldCallJoinRoleWaitlistDAO bool
+		joinRoleWaitlistDAORequest    *dao.JoinRoleWaitlistRequest
+		joinRoleWaitlistDAOResponse   *entities.RoleWaitlistPosition
+		joinRoleWaitlistDAOError      error
+
+		expect    *services.JoinRoleWaitlistResponse
+		expectErr error
+	}{
+		{
+			name: "Join",
+
+			request: &services.JoinRoleWaitlistRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+
+			shouldCallJoinRoleWaitlistDAO: true,
+			joinRoleWaitlistDAORequest: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+			joinRoleWaitlistDAOResponse: &entities.RoleWaitlistPosition{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleEarlyAccessProgram,
+				Position:     3,
+				Waiting:      5,
+				EnqueuedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.JoinRoleWaitlistResponse{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+				Position:     3,
+				Waiting:      5,
+				EnqueuedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.JoinRoleWaitlistRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+
+			shouldCallJoinRoleWaitlistDAO: true,
+			joinRoleWaitlistDAORequest: &dao.JoinRoleWaitlistRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+			joinRoleWaitlistDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrJoinRoleWaitlist,
+		},
+		{
+			name: "MissingRole",
+
+			request: &services.JoinRoleWaitlistRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidJoinRoleWaitlistRequest,
+		},
+		{
+			name: "InvalidRole",
+
+			request: &services.JoinRoleWaitlistRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Role:         "fake-role",
+			},
+
+			expectErr: services.ErrInvalidJoinRoleWaitlistRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.JoinRoleWaitlistRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Role:         entities.RoleEarlyAccessProgram,
+			},
+
+			expectErr: services.ErrInvalidJoinRoleWaitlistRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			joinRoleWaitlistDAO := daomocks.NewMockJoinRoleWaitlist(t)
+
+			if testCase.shouldCallJoinRoleWaitlistDAO {
+				joinRoleWaitlistDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.Anything,
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.joinRoleWaitlistDAORequest,
+					).
+					Return(testCase.joinRoleWaitlistDAOResponse, testCase.joinRoleWaitlistDAOError)
+			}
+
+			service := services.NewJoinRoleWaitlist(joinRoleWaitlistDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			joinRoleWaitlistDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidLinkIdentityRequest = errors.New("invalid link identity request")
+	ErrLinkIdentity               = errors.New("link identity")
+)
+
+var linkIdentityValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterIdentityProvider(linkIdentityValidate)
+	entities.RegisterEmail(linkIdentityValidate)
+}
+
+type LinkIdentityRequest struct {
+	CredentialID    string `validate:"required,len=36"`
+	Provider        string `validate:"required,max=64,identity_provider"`
+	Subject         string `validate:"required,max=256"`
+	EmailAtLinkTime string `validate:"omitempty,email_address,max=256"`
+}
+
+type LinkIdentityResponse struct {
+	CredentialID    string
+	Provider        string
+	Subject         string
+	EmailAtLinkTime string
+	LinkedAt        time.Time
+}
+
+type LinkIdentity interface {
+	Exec(ctx context.Context, data *LinkIdentityRequest) (*LinkIdentityResponse, error)
+}
+
+type linkIdentityImpl struct {
+	dao dao.LinkIdentity
+}
+
+func (service *linkIdentityImpl) Exec(ctx context.Context, data *LinkIdentityRequest) (*LinkIdentityResponse, error) {
+	if err := linkIdentityValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidLinkIdentityRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidLinkIdentityRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	identity, err := service.dao.Exec(ctx, credentialID, time.Now(), &dao.LinkIdentityRequest{
+		Provider:        data.Provider,
+		Subject:         data.Subject,
+		EmailAtLinkTime: entities.NormalizeEmail(data.EmailAtLinkTime),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrLinkIdentity, err)
+	}
+
+	return &LinkIdentityResponse{
+		CredentialID:    identity.CredentialID.String(),
+		Provider:        identity.Provider,
+		Subject:         identity.Subject,
+		EmailAtLinkTime: identity.EmailAtLinkTime,
+		LinkedAt:        identity.LinkedAt,
+	}, nil
+}
+
+func NewLinkIdentity(dao dao.LinkIdentity) LinkIdentity {
+	return &linkIdentityImpl{dao: dao}
+}
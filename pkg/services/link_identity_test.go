@@ -0,0 +1,188 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestLinkIdentity(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.LinkIdentityRequest
+
+		shouldCallLinkIdentityDAO bool
+		linkIdentityDAOResponse   *entities.CredentialIdentity
+		linkIdentityDAOError      error
+
+		expect    *services.LinkIdentityResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.LinkIdentityRequest{
+				CredentialID:    "00000000-0000-0000-0000-000000000001",
+				Provider:        "google",
+				Subject:         "subject-1",
+				EmailAtLinkTime: "user@gmail.com",
+			},
+
+			shouldCallLinkIdentityDAO: true,
+			linkIdentityDAOResponse: &entities.CredentialIdentity{
+				TenantID:        "default",
+				Provider:        "google",
+				Subject:         "subject-1",
+				CredentialID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				EmailAtLinkTime: "user@gmail.com",
+				LinkedAt:        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.LinkIdentityResponse{
+				CredentialID:    "00000000-0000-0000-0000-000000000001",
+				Provider:        "google",
+				Subject:         "subject-1",
+				EmailAtLinkTime: "user@gmail.com",
+				LinkedAt:        time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "OK/NoEmail",
+
+			request: &services.LinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "github",
+				Subject:      "subject-1",
+			},
+
+			shouldCallLinkIdentityDAO: true,
+			linkIdentityDAOResponse: &entities.CredentialIdentity{
+				TenantID:     "default",
+				Provider:     "github",
+				Subject:      "subject-1",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.LinkIdentityResponse{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "github",
+				Subject:      "subject-1",
+				LinkedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAO/AlreadyLinked",
+
+			request: &services.LinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "google",
+				Subject:      "subject-1",
+			},
+
+			shouldCallLinkIdentityDAO: true,
+			linkIdentityDAOError:      dao.ErrIdentityAlreadyLinked,
+
+			expectErr: dao.ErrIdentityAlreadyLinked,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.LinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "google",
+				Subject:      "subject-1",
+			},
+
+			shouldCallLinkIdentityDAO: true,
+			linkIdentityDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrLinkIdentity,
+		},
+		{
+			name: "Invalid/Provider",
+
+			request: &services.LinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "Google",
+				Subject:      "subject-1",
+			},
+
+			expectErr: services.ErrInvalidLinkIdentityRequest,
+		},
+		{
+			name: "Invalid/NoSubject",
+
+			request: &services.LinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "google",
+			},
+
+			expectErr: services.ErrInvalidLinkIdentityRequest,
+		},
+		{
+			name: "Invalid/Email",
+
+			request: &services.LinkIdentityRequest{
+				CredentialID:    "00000000-0000-0000-0000-000000000001",
+				Provider:        "google",
+				Subject:         "subject-1",
+				EmailAtLinkTime: "not-an-email",
+			},
+
+			expectErr: services.ErrInvalidLinkIdentityRequest,
+		},
+		{
+			name: "Invalid/CredentialID",
+
+			request: &services.LinkIdentityRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Provider:     "google",
+				Subject:      "subject-1",
+			},
+
+			expectErr: services.ErrInvalidLinkIdentityRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			linkIdentityDAO := daomocks.NewMockLinkIdentity(t)
+
+			if testCase.shouldCallLinkIdentityDAO {
+				linkIdentityDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.CredentialID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.LinkIdentityRequest{
+							Provider:        testCase.request.Provider,
+							Subject:         testCase.request.Subject,
+							EmailAtLinkTime: testCase.request.EmailAtLinkTime,
+						},
+					).
+					Return(testCase.linkIdentityDAOResponse, testCase.linkIdentityDAOError)
+			}
+
+			service := services.NewLinkIdentity(linkIdentityDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			linkIdentityDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidListCanonicalEmailClustersRequest = errors.New("invalid list canonical email clusters request")
+	ErrListCanonicalEmailClusters               = errors.New("list canonical email clusters")
+)
+
+var listCanonicalEmailClustersValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ListCanonicalEmailClustersRequest struct {
+	Limit  int `validate:"required,min=1,max=128"`
+	Offset int `validate:"omitempty,min=0"`
+}
+
+type ListCanonicalEmailClustersResponseCredential struct {
+	ID              string
+	Email           string
+	Role            entities.Role
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+}
+
+type ListCanonicalEmailClustersResponseCluster struct {
+	CanonicalEmail string
+	// Credentials are sorted from the oldest to the newest.
+	Credentials []*ListCanonicalEmailClustersResponseCredential
+}
+
+type ListCanonicalEmailClustersResponse struct {
+	Clusters []*ListCanonicalEmailClustersResponseCluster
+}
+
+type ListCanonicalEmailClusters interface {
+	Exec(ctx context.Context, data *ListCanonicalEmailClustersRequest) (*ListCanonicalEmailClustersResponse, error)
+}
+
+type listCanonicalEmailClustersImpl struct {
+	dao dao.ListCanonicalEmailClusters
+}
+
+func (service *listCanonicalEmailClustersImpl) Exec(
+	ctx context.Context, data *ListCanonicalEmailClustersRequest,
+) (*ListCanonicalEmailClustersResponse, error) {
+	if err := listCanonicalEmailClustersValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListCanonicalEmailClustersRequest, err)
+	}
+
+	clusters, err := service.dao.Exec(ctx, &dao.ListCanonicalEmailClustersRequest{
+		Limit:  data.Limit,
+		Offset: data.Offset,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListCanonicalEmailClusters, err)
+	}
+
+	return &ListCanonicalEmailClustersResponse{
+		Clusters: lo.Map(
+			clusters,
+			func(item *entities.CanonicalEmailCluster, _ int) *ListCanonicalEmailClustersResponseCluster {
+				return &ListCanonicalEmailClustersResponseCluster{
+					CanonicalEmail: item.CanonicalEmail,
+					Credentials: lo.Map(
+						item.Credentials,
+						func(credential *entities.Credential, _ int) *ListCanonicalEmailClustersResponseCredential {
+							return &ListCanonicalEmailClustersResponseCredential{
+								ID:              credential.ID.String(),
+								Email:           credential.Email,
+								Role:            credential.Role,
+								EmailVerifiedAt: credential.EmailVerifiedAt,
+								CreatedAt:       credential.CreatedAt,
+							}
+						},
+					),
+				}
+			},
+		),
+	}, nil
+}
+
+func NewListCanonicalEmailClusters(dao dao.ListCanonicalEmailClusters) ListCanonicalEmailClusters {
+	return &listCanonicalEmailClustersImpl{dao: dao}
+}
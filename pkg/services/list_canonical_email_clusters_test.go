@@ -0,0 +1,159 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestListCanonicalEmailClusters(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListCanonicalEmailClustersRequest
+
+		shouldCallListCanonicalEmailClustersDAO bool
+		listCanonicalEmailClustersDAORequest    *dao.ListCanonicalEmailClustersRequest
+		listCanonicalEmailClustersDAOResponse   []*entities.CanonicalEmailCluster
+		listCanonicalEmailClustersDAOError      error
+
+		expect    *services.ListCanonicalEmailClustersResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListCanonicalEmailClustersRequest{
+				Limit:  10,
+				Offset: 5,
+			},
+
+			shouldCallListCanonicalEmailClustersDAO: true,
+			listCanonicalEmailClustersDAORequest: &dao.ListCanonicalEmailClustersRequest{
+				Limit:  10,
+				Offset: 5,
+			},
+			listCanonicalEmailClustersDAOResponse: []*entities.CanonicalEmailCluster{
+				{
+					CanonicalEmail: "johndoe@gmail.com",
+					Credentials: []*entities.Credential{
+						{
+							ID:             uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+							Email:          "johndoe+promo@googlemail.com",
+							CanonicalEmail: "johndoe@gmail.com",
+							Role:           entities.RoleEarlyAccessProgram,
+							CreatedAt:      time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						},
+						{
+							ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+							Email:           "John.Doe@gmail.com",
+							CanonicalEmail:  "johndoe@gmail.com",
+							EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+							CreatedAt:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+
+			expect: &services.ListCanonicalEmailClustersResponse{
+				Clusters: []*services.ListCanonicalEmailClustersResponseCluster{
+					{
+						CanonicalEmail: "johndoe@gmail.com",
+						Credentials: []*services.ListCanonicalEmailClustersResponseCredential{
+							{
+								ID:        "00000000-0000-0000-0000-000000000002",
+								Email:     "johndoe+promo@googlemail.com",
+								Role:      entities.RoleEarlyAccessProgram,
+								CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+							},
+							{
+								ID:              "00000000-0000-0000-0000-000000000001",
+								Email:           "John.Doe@gmail.com",
+								EmailVerifiedAt: lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+								CreatedAt:       time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Empty",
+
+			request: &services.ListCanonicalEmailClustersRequest{
+				Limit: 10,
+			},
+
+			shouldCallListCanonicalEmailClustersDAO: true,
+			listCanonicalEmailClustersDAORequest: &dao.ListCanonicalEmailClustersRequest{
+				Limit: 10,
+			},
+			listCanonicalEmailClustersDAOResponse: []*entities.CanonicalEmailCluster{},
+
+			expect: &services.ListCanonicalEmailClustersResponse{
+				Clusters: []*services.ListCanonicalEmailClustersResponseCluster{},
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.ListCanonicalEmailClustersRequest{
+				Limit: 10,
+			},
+
+			shouldCallListCanonicalEmailClustersDAO: true,
+			listCanonicalEmailClustersDAORequest: &dao.ListCanonicalEmailClustersRequest{
+				Limit: 10,
+			},
+			listCanonicalEmailClustersDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrListCanonicalEmailClusters,
+		},
+		{
+			name: "MissingLimit",
+
+			request: &services.ListCanonicalEmailClustersRequest{},
+
+			expectErr: services.ErrInvalidListCanonicalEmailClustersRequest,
+		},
+		{
+			name: "LimitTooHigh",
+
+			request: &services.ListCanonicalEmailClustersRequest{
+				Limit: 129,
+			},
+
+			expectErr: services.ErrInvalidListCanonicalEmailClustersRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listCanonicalEmailClustersDAO := daomocks.NewMockListCanonicalEmailClusters(t)
+
+			if testCase.shouldCallListCanonicalEmailClustersDAO {
+				listCanonicalEmailClustersDAO.
+					On("Exec", context.Background(), testCase.listCanonicalEmailClustersDAORequest).
+					Return(testCase.listCanonicalEmailClustersDAOResponse, testCase.listCanonicalEmailClustersDAOError)
+			}
+
+			service := services.NewListCanonicalEmailClusters(listCanonicalEmailClustersDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			listCanonicalEmailClustersDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidListCredentialNotesRequest = errors.New("invalid list credential notes request")
+	ErrListCredentialNotes               = errors.New("list credential notes")
+)
+
+var listCredentialNotesValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ListCredentialNotesRequest struct {
+	CredentialID string `validate:"required,len=36"`
+}
+
+type ListCredentialNotesResponseNote struct {
+	ID           string
+	CredentialID string
+
+	Author string
+	Body   string
+	Pinned bool
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type ListCredentialNotesResponse struct {
+	// Notes are sorted with pinned notes first, then the most recent ones.
+	Notes []*ListCredentialNotesResponseNote
+}
+
+type ListCredentialNotes interface {
+	Exec(ctx context.Context, data *ListCredentialNotesRequest) (*ListCredentialNotesResponse, error)
+}
+
+type listCredentialNotesImpl struct {
+	dao dao.ListCredentialNotes
+}
+
+func (service *listCredentialNotesImpl) Exec(
+	ctx context.Context, data *ListCredentialNotesRequest,
+) (*ListCredentialNotesResponse, error) {
+	if err := listCredentialNotesValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListCredentialNotesRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidListCredentialNotesRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	notes, err := service.dao.Exec(ctx, credentialID)
+	if err != nil {
+		return nil, errors.Join(ErrListCredentialNotes, err)
+	}
+
+	return &ListCredentialNotesResponse{
+		Notes: lo.Map(notes, func(item *entities.CredentialNote, _ int) *ListCredentialNotesResponseNote {
+			return &ListCredentialNotesResponseNote{
+				ID:           item.ID.String(),
+				CredentialID: item.CredentialID.String(),
+
+				Author: item.Author,
+				Body:   item.Body,
+				Pinned: item.Pinned,
+
+				CreatedAt: item.CreatedAt,
+				UpdatedAt: item.UpdatedAt,
+			}
+		}),
+	}, nil
+}
+
+func NewListCredentialNotes(dao dao.ListCredentialNotes) ListCredentialNotes {
+	return &listCredentialNotesImpl{dao: dao}
+}
@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestListCredentialNotes(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListCredentialNotesRequest
+
+		shouldCallListCredentialNotesDAO bool
+		listCredentialNotesDAOResponse   []*entities.CredentialNote
+		listCredentialNotesDAOError      error
+
+		expect    *services.ListCredentialNotesResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListCredentialNotesRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallListCredentialNotesDAO: true,
+			listCredentialNotesDAOResponse: []*entities.CredentialNote{
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Author:       "agent@example.com",
+					Body:         "suspected bot",
+					Pinned:       true,
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Author:       "agent@example.com",
+					Body:         "refund issued",
+					CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			expect: &services.ListCredentialNotesResponse{
+				Notes: []*services.ListCredentialNotesResponseNote{
+					{
+						ID:           "10000000-0000-0000-0000-000000000001",
+						CredentialID: "00000000-0000-0000-0000-000000000001",
+						Author:       "agent@example.com",
+						Body:         "suspected bot",
+						Pinned:       true,
+						CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:           "10000000-0000-0000-0000-000000000002",
+						CredentialID: "00000000-0000-0000-0000-000000000001",
+						Author:       "agent@example.com",
+						Body:         "refund issued",
+						CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "NoNotes",
+
+			request: &services.ListCredentialNotesRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallListCredentialNotesDAO: true,
+			listCredentialNotesDAOResponse:   []*entities.CredentialNote{},
+
+			expect: &services.ListCredentialNotesResponse{
+				Notes: []*services.ListCredentialNotesResponseNote{},
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.ListCredentialNotesRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallListCredentialNotesDAO: true,
+			listCredentialNotesDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrListCredentialNotes,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.ListCredentialNotesRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidListCredentialNotesRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listCredentialNotesDAO := daomocks.NewMockListCredentialNotes(t)
+
+			if testCase.shouldCallListCredentialNotesDAO {
+				listCredentialNotesDAO.
+					On("Exec", context.Background(), uuid.MustParse(testCase.request.CredentialID)).
+					Return(testCase.listCredentialNotesDAOResponse, testCase.listCredentialNotesDAOError)
+			}
+
+			service := services.NewListCredentialNotes(listCredentialNotesDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			listCredentialNotesDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -29,12 +29,35 @@ type ListCredentialsResponseCredential struct {
 	ID    string
 	Email string
 	Role  entities.Role
+	// RoleExpiresAt is set when Role is only granted for a limited time. The credentials revert to FallbackRole
+	// once it expires.
+	RoleExpiresAt *time.Time
+	FallbackRole  *entities.Role
+
+	EmailVerifiedAt *time.Time
+
+	Labels      map[string]string
+	MFARequired bool
+	TokenEpoch  int64
 
 	EmailValidationTokenID        string
 	PendingEmailValidationTokenID string
 	PasswordTokenID               string
 	ResetPasswordTokenID          string
 
+	EmailValidationTokenExpiresAt        *time.Time
+	PendingEmailValidationTokenExpiresAt *time.Time
+	PasswordTokenExpiresAt               *time.Time
+	ResetPasswordTokenExpiresAt          *time.Time
+
+	DeletionScheduledAt *time.Time
+	ErasedAt            *time.Time
+
+	LegalHold       bool
+	LegalHoldReason string
+	LegalHoldSetBy  string
+	LegalHoldSetAt  *time.Time
+
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 }
@@ -76,18 +99,43 @@ func (service *listCredentialsImpl) Exec(
 		return nil, errors.Join(ErrListCredentials, err)
 	}
 
+	now := time.Now()
+
 	response := &ListCredentialsResponse{
 		Credentials: lo.Map(credentials, func(item *entities.Credential, _ int) *ListCredentialsResponseCredential {
+			item.ClearExpiredTokens(now)
+			item.ClearExpiredRoleGrant(now)
+
 			return &ListCredentialsResponseCredential{
 				ID:                            item.ID.String(),
 				Email:                         item.Email,
 				Role:                          item.Role,
+				RoleExpiresAt:                 item.RoleExpiresAt,
+				FallbackRole:                  item.FallbackRole,
+				EmailVerifiedAt:               item.EmailVerifiedAt,
+				Labels:                        item.Labels,
+				MFARequired:                   item.MFARequired,
+				TokenEpoch:                    item.TokenEpoch,
 				EmailValidationTokenID:        item.EmailValidationTokenID,
 				PendingEmailValidationTokenID: item.PendingEmailValidationTokenID,
 				PasswordTokenID:               item.PasswordTokenID,
 				ResetPasswordTokenID:          item.ResetPasswordTokenID,
-				CreatedAt:                     item.CreatedAt,
-				UpdatedAt:                     item.UpdatedAt,
+
+				EmailValidationTokenExpiresAt:        item.EmailValidationTokenExpiresAt,
+				PendingEmailValidationTokenExpiresAt: item.PendingEmailValidationTokenExpiresAt,
+				PasswordTokenExpiresAt:               item.PasswordTokenExpiresAt,
+				ResetPasswordTokenExpiresAt:          item.ResetPasswordTokenExpiresAt,
+
+				DeletionScheduledAt: item.DeletionScheduledAt,
+				ErasedAt:            item.ErasedAt,
+
+				LegalHold:       item.LegalHold,
+				LegalHoldReason: item.LegalHoldReason,
+				LegalHoldSetBy:  item.LegalHoldSetBy,
+				LegalHoldSetAt:  item.LegalHoldSetAt,
+
+				CreatedAt: item.CreatedAt,
+				UpdatedAt: item.UpdatedAt,
 			}
 		}),
 	}
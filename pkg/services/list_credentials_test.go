@@ -82,6 +82,97 @@ func TestListCredentials(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "OK/ExpiredTokens",
+
+			request: &services.ListCredentialsRequest{
+				IDs: []string{
+					"00000000-0000-0000-0000-000000000001",
+				},
+			},
+
+			shouldCallListCredentialsDAO: true,
+			listCredentialsDAOResponse: []*entities.Credential{
+				{
+					ID:                                   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Email:                                "email-1",
+					Role:                                 entities.RoleCore,
+					EmailValidationTokenID:               "email-validation-token-id-1",
+					PendingEmailValidationTokenID:        "pending-email-validation-token-id-1",
+					PasswordTokenID:                      "password-token-id-1",
+					ResetPasswordTokenID:                 "reset-password-token-id-1",
+					EmailValidationTokenExpiresAt:        lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+					PendingEmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+					PasswordTokenExpiresAt:               lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:                            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt:                            lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+
+			expect: &services.ListCredentialsResponse{
+				Credentials: []*services.ListCredentialsResponseCredential{
+					{
+						ID:                                   "00000000-0000-0000-0000-000000000001",
+						Email:                                "email-1",
+						Role:                                 entities.RoleCore,
+						PendingEmailValidationTokenID:        "pending-email-validation-token-id-1",
+						ResetPasswordTokenID:                 "reset-password-token-id-1",
+						PendingEmailValidationTokenExpiresAt: lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+						CreatedAt:                            time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						UpdatedAt:                            lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+		},
+		{
+			name: "OK/RoleGrants",
+
+			request: &services.ListCredentialsRequest{
+				IDs: []string{
+					"00000000-0000-0000-0000-000000000001",
+					"00000000-0000-0000-0000-000000000002",
+				},
+			},
+
+			shouldCallListCredentialsDAO: true,
+			listCredentialsDAOResponse: []*entities.Credential{
+				{
+					ID:            uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Email:         "email-1",
+					Role:          entities.RoleAdmin,
+					RoleExpiresAt: lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+					FallbackRole:  lo.ToPtr(entities.RoleCore),
+					CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:            uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Email:         "email-2",
+					Role:          entities.RoleEarlyAccessProgram,
+					RoleExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+					FallbackRole:  lo.ToPtr(entities.RoleNone),
+					CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			expect: &services.ListCredentialsResponse{
+				Credentials: []*services.ListCredentialsResponseCredential{
+					{
+						ID:            "00000000-0000-0000-0000-000000000001",
+						Email:         "email-1",
+						Role:          entities.RoleAdmin,
+						RoleExpiresAt: lo.ToPtr(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)),
+						FallbackRole:  lo.ToPtr(entities.RoleCore),
+						CreatedAt:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:        "00000000-0000-0000-0000-000000000002",
+						Email:     "email-2",
+						Role:      entities.RoleNone,
+						CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
 		{
 			name: "OK/NoReturn",
 
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidListEmailDomainRulesRequest = errors.New("invalid list email domain rules request")
+	ErrListEmailDomainRules               = errors.New("list email domain rules")
+)
+
+var listEmailDomainRulesValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterEmailDomainAction(listEmailDomainRulesValidate)
+}
+
+type ListEmailDomainRulesRequest struct {
+	// Action only returns the rules with the given action, when set.
+	Action entities.EmailDomainAction `validate:"omitempty,email_domain_action"`
+	Limit  int                        `validate:"required,min=1,max=128"`
+	Offset int                        `validate:"omitempty,min=0"`
+}
+
+type ListEmailDomainRulesResponseRule struct {
+	ID     string
+	Domain string
+	Action entities.EmailDomainAction
+	Reason string
+
+	SetBy     string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type ListEmailDomainRulesResponse struct {
+	Rules []*ListEmailDomainRulesResponseRule
+}
+
+type ListEmailDomainRules interface {
+	Exec(ctx context.Context, data *ListEmailDomainRulesRequest) (*ListEmailDomainRulesResponse, error)
+}
+
+type listEmailDomainRulesImpl struct {
+	dao dao.ListEmailDomainRules
+}
+
+func (service *listEmailDomainRulesImpl) Exec(
+	ctx context.Context, data *ListEmailDomainRulesRequest,
+) (*ListEmailDomainRulesResponse, error) {
+	if err := listEmailDomainRulesValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListEmailDomainRulesRequest, err)
+	}
+
+	rules, err := service.dao.Exec(ctx, &dao.ListEmailDomainRulesRequest{
+		Action: data.Action,
+		Limit:  data.Limit,
+		Offset: data.Offset,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListEmailDomainRules, err)
+	}
+
+	return &ListEmailDomainRulesResponse{
+		Rules: lo.Map(rules, func(item *entities.EmailDomainRule, _ int) *ListEmailDomainRulesResponseRule {
+			return &ListEmailDomainRulesResponseRule{
+				ID:     item.ID.String(),
+				Domain: item.Domain,
+				Action: item.Action,
+				Reason: item.Reason,
+
+				SetBy:     item.SetBy,
+				CreatedAt: item.CreatedAt,
+				UpdatedAt: item.UpdatedAt,
+			}
+		}),
+	}, nil
+}
+
+func NewListEmailDomainRules(dao dao.ListEmailDomainRules) ListEmailDomainRules {
+	return &listEmailDomainRulesImpl{dao: dao}
+}
@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestListEmailDomainRules(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListEmailDomainRulesRequest
+
+		shouldCallListEmailDomainRulesDAO bool
+		listEmailDomainRulesDAORequest    *dao.ListEmailDomainRulesRequest
+		listEmailDomainRulesDAOResponse   []*entities.EmailDomainRule
+		listEmailDomainRulesDAOError      error
+
+		expect    *services.ListEmailDomainRulesResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListEmailDomainRulesRequest{
+				Limit:  10,
+				Offset: 5,
+			},
+
+			shouldCallListEmailDomainRulesDAO: true,
+			listEmailDomainRulesDAORequest: &dao.ListEmailDomainRulesRequest{
+				Limit:  10,
+				Offset: 5,
+			},
+			listEmailDomainRulesDAOResponse: []*entities.EmailDomainRule{
+				{
+					ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TenantID:  "default",
+					Domain:    "example.com",
+					Action:    entities.EmailDomainActionAllow,
+					SetBy:     "admin@example.com",
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:        uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					TenantID:  "default",
+					Domain:    "spam.example.com",
+					Action:    entities.EmailDomainActionDeny,
+					Reason:    "abuse",
+					SetBy:     "admin@example.com",
+					CreatedAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+
+			expect: &services.ListEmailDomainRulesResponse{
+				Rules: []*services.ListEmailDomainRulesResponseRule{
+					{
+						ID:        "00000000-0000-0000-0000-000000000001",
+						Domain:    "example.com",
+						Action:    entities.EmailDomainActionAllow,
+						SetBy:     "admin@example.com",
+						CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:        "00000000-0000-0000-0000-000000000002",
+						Domain:    "spam.example.com",
+						Action:    entities.EmailDomainActionDeny,
+						Reason:    "abuse",
+						SetBy:     "admin@example.com",
+						CreatedAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+						UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+		},
+		{
+			name: "Action",
+
+			request: &services.ListEmailDomainRulesRequest{
+				Action: entities.EmailDomainActionDeny,
+				Limit:  10,
+			},
+
+			shouldCallListEmailDomainRulesDAO: true,
+			listEmailDomainRulesDAORequest: &dao.ListEmailDomainRulesRequest{
+				Action: entities.EmailDomainActionDeny,
+				Limit:  10,
+			},
+			listEmailDomainRulesDAOResponse: []*entities.EmailDomainRule{},
+
+			expect: &services.ListEmailDomainRulesResponse{
+				Rules: []*services.ListEmailDomainRulesResponseRule{},
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.ListEmailDomainRulesRequest{
+				Limit: 10,
+			},
+
+			shouldCallListEmailDomainRulesDAO: true,
+			listEmailDomainRulesDAORequest: &dao.ListEmailDomainRulesRequest{
+				Limit: 10,
+			},
+			listEmailDomainRulesDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrListEmailDomainRules,
+		},
+		{
+			name: "InvalidAction",
+
+			request: &services.ListEmailDomainRulesRequest{
+				Action: "block",
+				Limit:  10,
+			},
+
+			expectErr: services.ErrInvalidListEmailDomainRulesRequest,
+		},
+		{
+			name: "MissingLimit",
+
+			request: &services.ListEmailDomainRulesRequest{},
+
+			expectErr: services.ErrInvalidListEmailDomainRulesRequest,
+		},
+		{
+			name: "LimitTooHigh",
+
+			request: &services.ListEmailDomainRulesRequest{
+				Limit: 129,
+			},
+
+			expectErr: services.ErrInvalidListEmailDomainRulesRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listEmailDomainRulesDAO := daomocks.NewMockListEmailDomainRules(t)
+
+			if testCase.shouldCallListEmailDomainRulesDAO {
+				listEmailDomainRulesDAO.
+					On("Exec", context.Background(), testCase.listEmailDomainRulesDAORequest).
+					Return(testCase.listEmailDomainRulesDAOResponse, testCase.listEmailDomainRulesDAOError)
+			}
+
+			service := services.NewListEmailDomainRules(listEmailDomainRulesDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			listEmailDomainRulesDAO.AssertExpectations(t)
+		})
+	}
+}
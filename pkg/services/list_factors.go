@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidListFactorsRequest = errors.New("invalid list factors request")
+	ErrListFactors               = errors.New("list factors")
+)
+
+var listFactorsValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ListFactorsRequest struct {
+	CredentialID string `validate:"required,len=36"`
+}
+
+type ListFactorsResponseFactor struct {
+	ID           string
+	CredentialID string
+	Type         entities.FactorType
+	Label        string
+
+	SecretRef string
+
+	WebAuthnCredentialID string
+	WebAuthnPublicKey    []byte
+
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+type ListFactorsResponse struct {
+	Factors []*ListFactorsResponseFactor
+}
+
+type ListFactors interface {
+	Exec(ctx context.Context, data *ListFactorsRequest) (*ListFactorsResponse, error)
+}
+
+type listFactorsImpl struct {
+	dao dao.ListFactors
+}
+
+func (service *listFactorsImpl) Exec(ctx context.Context, data *ListFactorsRequest) (*ListFactorsResponse, error) {
+	if err := listFactorsValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListFactorsRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidListFactorsRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	factors, err := service.dao.Exec(ctx, credentialID)
+	if err != nil {
+		return nil, errors.Join(ErrListFactors, err)
+	}
+
+	return &ListFactorsResponse{
+		Factors: lo.Map(factors, func(item *entities.CredentialFactor, _ int) *ListFactorsResponseFactor {
+			return &ListFactorsResponseFactor{
+				ID:           item.ID.String(),
+				CredentialID: item.CredentialID.String(),
+				Type:         item.Type,
+				Label:        item.Label,
+
+				SecretRef: item.SecretRef,
+
+				WebAuthnCredentialID: item.WebAuthnCredentialID,
+				WebAuthnPublicKey:    item.WebAuthnPublicKey,
+
+				CreatedAt:  item.CreatedAt,
+				LastUsedAt: item.LastUsedAt,
+			}
+		}),
+	}, nil
+}
+
+func NewListFactors(dao dao.ListFactors) ListFactors {
+	return &listFactorsImpl{dao: dao}
+}
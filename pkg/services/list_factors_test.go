@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestListFactors(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListFactorsRequest
+
+		shouldCallListFactorsDAO bool
+		listFactorsDAOResponse   []*entities.CredentialFactor
+		listFactorsDAOError      error
+
+		expect    *services.ListFactorsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListFactorsRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallListFactorsDAO: true,
+			listFactorsDAOResponse: []*entities.CredentialFactor{
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Type:         entities.FactorTypeTOTP,
+					Label:        "phone",
+					SecretRef:    "totp-secret-ref",
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					LastUsedAt:   lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+
+			expect: &services.ListFactorsResponse{
+				Factors: []*services.ListFactorsResponseFactor{
+					{
+						ID:           "10000000-0000-0000-0000-000000000001",
+						CredentialID: "00000000-0000-0000-0000-000000000001",
+						Type:         entities.FactorTypeTOTP,
+						Label:        "phone",
+						SecretRef:    "totp-secret-ref",
+						CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						LastUsedAt:   lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+		},
+		{
+			name: "NoResults",
+
+			request: &services.ListFactorsRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallListFactorsDAO: true,
+			listFactorsDAOResponse:   []*entities.CredentialFactor{},
+
+			expect: &services.ListFactorsResponse{
+				Factors: []*services.ListFactorsResponseFactor{},
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.ListFactorsRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallListFactorsDAO: true,
+			listFactorsDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrListFactors,
+		},
+		{
+			name: "Invalid/CredentialID",
+
+			request: &services.ListFactorsRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidListFactorsRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listFactorsDAO := daomocks.NewMockListFactors(t)
+
+			if testCase.shouldCallListFactorsDAO {
+				listFactorsDAO.
+					On("Exec", context.Background(), uuid.MustParse(testCase.request.CredentialID)).
+					Return(testCase.listFactorsDAOResponse, testCase.listFactorsDAOError)
+			}
+
+			service := services.NewListFactors(listFactorsDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			listFactorsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidListInvitationsRequest = errors.New("invalid list invitations request")
+	ErrListInvitations               = errors.New("list invitations")
+)
+
+var listInvitationsValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ListInvitationsRequest struct {
+	// CreatedBy only returns the invitations issued by the given credentials, when set.
+	CreatedBy string `validate:"omitempty,len=36"`
+	Limit     int    `validate:"required,min=1,max=128"`
+	Offset    int    `validate:"omitempty,min=0"`
+}
+
+type ListInvitationsResponseRedemption struct {
+	CredentialID string
+	RedeemedAt   time.Time
+}
+
+type ListInvitationsResponseInvitation struct {
+	ID      string
+	Code    string
+	Role    entities.Role
+	MaxUses int
+	Uses    int
+
+	// CreatedBy is empty once the credentials that issued the invitation are deleted.
+	CreatedBy string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+
+	Redemptions []*ListInvitationsResponseRedemption
+}
+
+type ListInvitationsResponse struct {
+	Invitations []*ListInvitationsResponseInvitation
+}
+
+type ListInvitations interface {
+	Exec(ctx context.Context, data *ListInvitationsRequest) (*ListInvitationsResponse, error)
+}
+
+type listInvitationsImpl struct {
+	dao dao.ListInvitations
+}
+
+func (service *listInvitationsImpl) Exec(
+	ctx context.Context, data *ListInvitationsRequest,
+) (*ListInvitationsResponse, error) {
+	var err error
+
+	if err = listInvitationsValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListInvitationsRequest, err)
+	}
+
+	var createdBy uuid.UUID
+	if data.CreatedBy != "" {
+		createdBy, err = uuid.Parse(data.CreatedBy)
+		if err != nil {
+			return nil, errors.Join(
+				ErrInvalidListInvitationsRequest, fmt.Errorf("uuid value: '%s': %w", data.CreatedBy, err),
+			)
+		}
+	}
+
+	invitations, err := service.dao.Exec(ctx, &dao.ListInvitationsRequest{
+		CreatedBy: createdBy,
+		Limit:     data.Limit,
+		Offset:    data.Offset,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListInvitations, err)
+	}
+
+	return &ListInvitationsResponse{
+		Invitations: lo.Map(
+			invitations,
+			func(item *entities.Invitation, _ int) *ListInvitationsResponseInvitation {
+				return &ListInvitationsResponseInvitation{
+					ID:      item.ID.String(),
+					Code:    item.Code,
+					Role:    item.Role,
+					MaxUses: item.MaxUses,
+					Uses:    item.Uses,
+
+					CreatedBy: lo.Ternary(item.CreatedBy == uuid.Nil, "", item.CreatedBy.String()),
+					ExpiresAt: item.ExpiresAt,
+					CreatedAt: item.CreatedAt,
+
+					Redemptions: lo.Map(
+						item.Redemptions,
+						func(redemption *entities.InvitationRedemption, _ int) *ListInvitationsResponseRedemption {
+							return &ListInvitationsResponseRedemption{
+								CredentialID: redemption.CredentialID.String(),
+								RedeemedAt:   redemption.RedeemedAt,
+							}
+						},
+					),
+				}
+			},
+		),
+	}, nil
+}
+
+func NewListInvitations(dao dao.ListInvitations) ListInvitations {
+	return &listInvitationsImpl{dao: dao}
+}
@@ -0,0 +1,158 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestListInvitations(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListInvitationsRequest
+
+		shouldCallListInvitationsDAO bool
+		listInvitationsDAORequest    *dao.ListInvitationsRequest
+		listInvitationsDAOResponse   []*entities.Invitation
+		listInvitationsDAOError      error
+
+		expect    *services.ListInvitationsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListInvitationsRequest{
+				CreatedBy: "00000000-0000-0000-0000-000000000001",
+				Limit:     10,
+				Offset:    5,
+			},
+
+			shouldCallListInvitationsDAO: true,
+			listInvitationsDAORequest: &dao.ListInvitationsRequest{
+				CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Limit:     10,
+				Offset:    5,
+			},
+			listInvitationsDAOResponse: []*entities.Invitation{
+				{
+					ID:        uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+					TenantID:  "default",
+					Code:      "CODE",
+					Role:      entities.RoleEarlyAccessProgram,
+					MaxUses:   5,
+					Uses:      1,
+					CreatedBy: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					Redemptions: []*entities.InvitationRedemption{
+						{
+							InvitationID: uuid.MustParse("00000000-0000-0000-0005-000000000001"),
+							TenantID:     "default",
+							CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+							RedeemedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+			},
+
+			expect: &services.ListInvitationsResponse{
+				Invitations: []*services.ListInvitationsResponseInvitation{
+					{
+						ID:        "00000000-0000-0000-0005-000000000001",
+						Code:      "CODE",
+						Role:      entities.RoleEarlyAccessProgram,
+						MaxUses:   5,
+						Uses:      1,
+						CreatedBy: "00000000-0000-0000-0000-000000000001",
+						CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+						Redemptions: []*services.ListInvitationsResponseRedemption{
+							{
+								CredentialID: "00000000-0000-0000-0000-000000000002",
+								RedeemedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "OK/NoReturn",
+
+			request: &services.ListInvitationsRequest{
+				Limit: 10,
+			},
+
+			shouldCallListInvitationsDAO: true,
+			listInvitationsDAORequest: &dao.ListInvitationsRequest{
+				Limit: 10,
+			},
+			listInvitationsDAOResponse: []*entities.Invitation{},
+
+			expect: &services.ListInvitationsResponse{
+				Invitations: []*services.ListInvitationsResponseInvitation{},
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.ListInvitationsRequest{
+				Limit: 10,
+			},
+
+			shouldCallListInvitationsDAO: true,
+			listInvitationsDAORequest: &dao.ListInvitationsRequest{
+				Limit: 10,
+			},
+			listInvitationsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrListInvitations,
+		},
+		{
+			name: "InvalidRequest/NoLimit",
+
+			request: &services.ListInvitationsRequest{},
+
+			expectErr: services.ErrInvalidListInvitationsRequest,
+		},
+		{
+			name: "InvalidRequest/InvalidCreatedBy",
+
+			request: &services.ListInvitationsRequest{
+				CreatedBy: "00000000x0000x0000x0000x000000000001",
+				Limit:     10,
+			},
+
+			expectErr: services.ErrInvalidListInvitationsRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listInvitationsDAO := daomocks.NewMockListInvitations(t)
+
+			if testCase.shouldCallListInvitationsDAO {
+				listInvitationsDAO.
+					On("Exec", context.Background(), testCase.listInvitationsDAORequest).
+					Return(testCase.listInvitationsDAOResponse, testCase.listInvitationsDAOError)
+			}
+
+			service := services.NewListInvitations(listInvitationsDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			listInvitationsDAO.AssertExpectations(t)
+		})
+	}
+}
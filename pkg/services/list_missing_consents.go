@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidListMissingConsentsRequest = errors.New("invalid list missing consents request")
+	ErrListMissingConsents               = errors.New("list missing consents")
+)
+
+var listMissingConsentsValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterConsentDocument(listMissingConsentsValidate)
+}
+
+type ListMissingConsentsRequest struct {
+	Document entities.ConsentDocument `validate:"required,consent_document"`
+	// Version is the latest version of the document, that every user is expected to accept.
+	Version string `validate:"required,max=64"`
+	// After is the last ID of the previous page. Leave empty to get the first page.
+	After string `validate:"omitempty,len=36"`
+	Limit int    `validate:"required,min=1,max=1024"`
+}
+
+type ListMissingConsentsResponse struct {
+	IDs []string
+}
+
+type ListMissingConsents interface {
+	Exec(ctx context.Context, data *ListMissingConsentsRequest) (*ListMissingConsentsResponse, error)
+}
+
+type listMissingConsentsImpl struct {
+	dao dao.ListMissingConsents
+}
+
+func (service *listMissingConsentsImpl) Exec(
+	ctx context.Context, data *ListMissingConsentsRequest,
+) (*ListMissingConsentsResponse, error) {
+	if err := listMissingConsentsValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListMissingConsentsRequest, err)
+	}
+
+	var after uuid.UUID
+	if data.After != "" {
+		var err error
+		if after, err = uuid.Parse(data.After); err != nil {
+			return nil, errors.Join(
+				ErrInvalidListMissingConsentsRequest, fmt.Errorf("uuid value: '%s': %w", data.After, err),
+			)
+		}
+	}
+
+	ids, err := service.dao.Exec(ctx, &dao.ListMissingConsentsRequest{
+		Document: data.Document,
+		Version:  data.Version,
+		After:    after,
+		Limit:    data.Limit,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListMissingConsents, err)
+	}
+
+	return &ListMissingConsentsResponse{IDs: ids.Strings()}, nil
+}
+
+func NewListMissingConsents(dao dao.ListMissingConsents) ListMissingConsents {
+	return &listMissingConsentsImpl{dao: dao}
+}
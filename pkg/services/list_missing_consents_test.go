@@ -0,0 +1,154 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestListMissingConsents(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListMissingConsentsRequest
+
+		shouldCallListMissingConsentsDAO bool
+		listMissingConsentsDAORequest    *dao.ListMissingConsentsRequest
+		listMissingConsentsDAOResponse   uuid.UUIDs
+		listMissingConsentsDAOError      error
+
+		expect    *services.ListMissingConsentsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				Limit:    10,
+			},
+
+			shouldCallListMissingConsentsDAO: true,
+			listMissingConsentsDAORequest: &dao.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				Limit:    10,
+			},
+			listMissingConsentsDAOResponse: uuid.UUIDs{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+			},
+
+			expect: &services.ListMissingConsentsResponse{
+				IDs: []string{
+					"00000000-0000-0000-0000-000000000001",
+					"00000000-0000-0000-0000-000000000002",
+				},
+			},
+		},
+		{
+			name: "OK/After",
+
+			request: &services.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				After:    "00000000-0000-0000-0000-000000000002",
+				Limit:    10,
+			},
+
+			shouldCallListMissingConsentsDAO: true,
+			listMissingConsentsDAORequest: &dao.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				After:    uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Limit:    10,
+			},
+			listMissingConsentsDAOResponse: uuid.UUIDs{},
+
+			expect: &services.ListMissingConsentsResponse{
+				IDs: []string{},
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				Limit:    10,
+			},
+
+			shouldCallListMissingConsentsDAO: true,
+			listMissingConsentsDAORequest: &dao.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				Limit:    10,
+			},
+			listMissingConsentsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrListMissingConsents,
+		},
+		{
+			name: "InvalidAfter",
+
+			request: &services.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				After:    "00000000x0000x0000x0000x000000000001",
+				Limit:    10,
+			},
+
+			expectErr: services.ErrInvalidListMissingConsentsRequest,
+		},
+		{
+			name: "MissingLimit",
+
+			request: &services.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+			},
+
+			expectErr: services.ErrInvalidListMissingConsentsRequest,
+		},
+		{
+			name: "LimitTooHigh",
+
+			request: &services.ListMissingConsentsRequest{
+				Document: entities.ConsentDocumentTOS,
+				Version:  "v2",
+				Limit:    1025,
+			},
+
+			expectErr: services.ErrInvalidListMissingConsentsRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listMissingConsentsDAO := daomocks.NewMockListMissingConsents(t)
+
+			if testCase.shouldCallListMissingConsentsDAO {
+				listMissingConsentsDAO.
+					On("Exec", context.Background(), testCase.listMissingConsentsDAORequest).
+					Return(testCase.listMissingConsentsDAOResponse, testCase.listMissingConsentsDAOError)
+			}
+
+			service := services.NewListMissingConsents(listMissingConsentsDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			listMissingConsentsDAO.AssertExpectations(t)
+		})
+	}
+}
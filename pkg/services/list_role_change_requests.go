@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidListRoleChangeRequestsRequest = errors.New("invalid list role change requests request")
+	ErrListRoleChangeRequests               = errors.New("list role change requests")
+)
+
+var listRoleChangeRequestsValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type ListRoleChangeRequestsRequest struct {
+	// CredentialID only returns the requests concerning the given credentials, when set.
+	CredentialID string `validate:"omitempty,len=36"`
+	// Pending only returns the requests still awaiting a decision.
+	Pending bool
+	Limit   int `validate:"required,min=1,max=128"`
+	Offset  int `validate:"omitempty,min=0"`
+}
+
+type ListRoleChangeRequestsResponseRequest struct {
+	ID           string
+	CredentialID string
+	Role         entities.Role
+	Status       entities.RoleChangeRequestStatus
+
+	// ProposedBy and DecidedBy are empty once the credentials that proposed or decided the request are deleted.
+	ProposedBy string
+	// DecidedBy is also empty until the request is approved or rejected.
+	DecidedBy string
+	ExpiresAt time.Time
+	DecidedAt *time.Time
+	CreatedAt time.Time
+}
+
+type ListRoleChangeRequestsResponse struct {
+	Requests []*ListRoleChangeRequestsResponseRequest
+}
+
+type ListRoleChangeRequests interface {
+	Exec(ctx context.Context, data *ListRoleChangeRequestsRequest) (*ListRoleChangeRequestsResponse, error)
+}
+
+type listRoleChangeRequestsImpl struct {
+	dao dao.ListRoleChangeRequests
+}
+
+func (service *listRoleChangeRequestsImpl) Exec(
+	ctx context.Context, data *ListRoleChangeRequestsRequest,
+) (*ListRoleChangeRequestsResponse, error) {
+	var err error
+
+	if err = listRoleChangeRequestsValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidListRoleChangeRequestsRequest, err)
+	}
+
+	var credentialID uuid.UUID
+	if data.CredentialID != "" {
+		credentialID, err = uuid.Parse(data.CredentialID)
+		if err != nil {
+			return nil, errors.Join(
+				ErrInvalidListRoleChangeRequestsRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+			)
+		}
+	}
+
+	now := time.Now()
+
+	requests, err := service.dao.Exec(ctx, now, &dao.ListRoleChangeRequestsRequest{
+		CredentialID: credentialID,
+		Pending:      data.Pending,
+		Limit:        data.Limit,
+		Offset:       data.Offset,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrListRoleChangeRequests, err)
+	}
+
+	return &ListRoleChangeRequestsResponse{
+		Requests: lo.Map(
+			requests,
+			func(item *entities.RoleChangeRequest, _ int) *ListRoleChangeRequestsResponseRequest {
+				var decidedBy string
+				if item.DecidedBy != nil {
+					decidedBy = item.DecidedBy.String()
+				}
+
+				return &ListRoleChangeRequestsResponseRequest{
+					ID:           item.ID.String(),
+					CredentialID: item.CredentialID.String(),
+					Role:         item.Role,
+					// Expired requests may still be stored as pending.
+					Status: item.StatusAt(now),
+
+					ProposedBy: lo.Ternary(item.ProposedBy == uuid.Nil, "", item.ProposedBy.String()),
+					DecidedBy:  decidedBy,
+					ExpiresAt:  item.ExpiresAt,
+					DecidedAt:  item.DecidedAt,
+					CreatedAt:  item.CreatedAt,
+				}
+			},
+		),
+	}, nil
+}
+
+func NewListRoleChangeRequests(dao dao.ListRoleChangeRequests) ListRoleChangeRequests {
+	return &listRoleChangeRequestsImpl{dao: dao}
+}
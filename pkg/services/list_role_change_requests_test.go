@@ -0,0 +1,205 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestListRoleChangeRequests(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.ListRoleChangeRequestsRequest
+
+		shouldCallListRoleChangeRequestsDAO bool
+		listRoleChangeRequestsDAORequest    *dao.ListRoleChangeRequestsRequest
+		listRoleChangeRequestsDAOResponse   []*entities.RoleChangeRequest
+		listRoleChangeRequestsDAOError      error
+
+		expect    *services.ListRoleChangeRequestsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.ListRoleChangeRequestsRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Limit:        10,
+				Offset:       5,
+			},
+
+			shouldCallListRoleChangeRequestsDAO: true,
+			listRoleChangeRequestsDAORequest: &dao.ListRoleChangeRequestsRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Limit:        10,
+				Offset:       5,
+			},
+			listRoleChangeRequestsDAOResponse: []*entities.RoleChangeRequest{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Role:         entities.RoleAdmin,
+					Status:       entities.RoleChangeRequestStatusPending,
+					ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					ExpiresAt:    time.Date(2100, 1, 4, 0, 0, 0, 0, time.UTC),
+					CreatedAt:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0007-000000000002"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Role:         entities.RoleCore,
+					Status:       entities.RoleChangeRequestStatusRejected,
+					ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000003")),
+					ExpiresAt:    time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+					DecidedAt:    lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+					CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+				// Expired, but not closed yet.
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0007-000000000003"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Role:         entities.RoleCore,
+					Status:       entities.RoleChangeRequestStatusPending,
+					ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					ExpiresAt:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+					CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			expect: &services.ListRoleChangeRequestsResponse{
+				Requests: []*services.ListRoleChangeRequestsResponseRequest{
+					{
+						ID:           "00000000-0000-0000-0007-000000000001",
+						CredentialID: "00000000-0000-0000-0000-000000000002",
+						Role:         entities.RoleAdmin,
+						Status:       entities.RoleChangeRequestStatusPending,
+						ProposedBy:   "00000000-0000-0000-0000-000000000001",
+						ExpiresAt:    time.Date(2100, 1, 4, 0, 0, 0, 0, time.UTC),
+						CreatedAt:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:           "00000000-0000-0000-0007-000000000002",
+						CredentialID: "00000000-0000-0000-0000-000000000002",
+						Role:         entities.RoleCore,
+						Status:       entities.RoleChangeRequestStatusRejected,
+						ProposedBy:   "00000000-0000-0000-0000-000000000001",
+						DecidedBy:    "00000000-0000-0000-0000-000000000003",
+						ExpiresAt:    time.Date(2021, 1, 5, 0, 0, 0, 0, time.UTC),
+						DecidedAt:    lo.ToPtr(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)),
+						CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:           "00000000-0000-0000-0007-000000000003",
+						CredentialID: "00000000-0000-0000-0000-000000000002",
+						Role:         entities.RoleCore,
+						Status:       entities.RoleChangeRequestStatusExpired,
+						ProposedBy:   "00000000-0000-0000-0000-000000000001",
+						ExpiresAt:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+						CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "OK/Pending",
+
+			request: &services.ListRoleChangeRequestsRequest{
+				Pending: true,
+				Limit:   10,
+			},
+
+			shouldCallListRoleChangeRequestsDAO: true,
+			listRoleChangeRequestsDAORequest: &dao.ListRoleChangeRequestsRequest{
+				Pending: true,
+				Limit:   10,
+			},
+			listRoleChangeRequestsDAOResponse: []*entities.RoleChangeRequest{},
+
+			expect: &services.ListRoleChangeRequestsResponse{
+				Requests: []*services.ListRoleChangeRequestsResponseRequest{},
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.ListRoleChangeRequestsRequest{
+				Limit: 10,
+			},
+
+			shouldCallListRoleChangeRequestsDAO: true,
+			listRoleChangeRequestsDAORequest: &dao.ListRoleChangeRequestsRequest{
+				Limit: 10,
+			},
+			listRoleChangeRequestsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrListRoleChangeRequests,
+		},
+		{
+			name: "MissingLimit",
+
+			request: &services.ListRoleChangeRequestsRequest{},
+
+			expectErr: services.ErrInvalidListRoleChangeRequestsRequest,
+		},
+		{
+			name: "LimitTooHigh",
+
+			request: &services.ListRoleChangeRequestsRequest{
+				Limit: 129,
+			},
+
+			expectErr: services.ErrInvalidListRoleChangeRequestsRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.ListRoleChangeRequestsRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000002",
+				Limit:        10,
+			},
+
+			expectErr: services.ErrInvalidListRoleChangeRequestsRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			listRoleChangeRequestsDAO := daomocks.NewMockListRoleChangeRequests(t)
+
+			if testCase.shouldCallListRoleChangeRequestsDAO {
+				listRoleChangeRequestsDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.listRoleChangeRequestsDAORequest,
+					).
+					Return(testCase.listRoleChangeRequestsDAOResponse, testCase.listRoleChangeRequestsDAOError)
+			}
+
+			service := services.NewListRoleChangeRequests(listRoleChangeRequestsDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			listRoleChangeRequestsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidMarkEmailVerifiedRequest = errors.New("invalid mark email verified request")
+	ErrMarkEmailVerified               = errors.New("mark email verified")
+)
+
+var markEmailVerifiedValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type MarkEmailVerifiedRequest struct {
+	ID string `validate:"required,len=36"`
+}
+
+type MarkEmailVerifiedResponse struct {
+	ID              string
+	Email           string
+	EmailVerifiedAt *time.Time
+	UpdatedAt       *time.Time
+}
+
+type MarkEmailVerified interface {
+	Exec(ctx context.Context, data *MarkEmailVerifiedRequest) (*MarkEmailVerifiedResponse, error)
+}
+
+type markEmailVerifiedImpl struct {
+	dao dao.MarkEmailVerified
+}
+
+func (service *markEmailVerifiedImpl) Exec(
+	ctx context.Context, data *MarkEmailVerifiedRequest,
+) (*MarkEmailVerifiedResponse, error) {
+	if err := markEmailVerifiedValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidMarkEmailVerifiedRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidMarkEmailVerifiedRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentials, err := service.dao.Exec(ctx, credentialsID, time.Now())
+	if err != nil {
+		return nil, errors.Join(ErrMarkEmailVerified, err)
+	}
+
+	return &MarkEmailVerifiedResponse{
+		ID:              credentials.ID.String(),
+		Email:           credentials.Email,
+		EmailVerifiedAt: credentials.EmailVerifiedAt,
+		UpdatedAt:       credentials.UpdatedAt,
+	}, nil
+}
+
+func NewMarkEmailVerified(dao dao.MarkEmailVerified) MarkEmailVerified {
+	return &markEmailVerifiedImpl{dao: dao}
+}
@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestMarkEmailVerified(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.MarkEmailVerifiedRequest
+
+		shouldCallMarkEmailVerifiedDAO bool
+		markEmailVerifiedDAOResponse   *entities.Credential
+		markEmailVerifiedDAOError      error
+
+		expect    *services.MarkEmailVerifiedResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.MarkEmailVerifiedRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallMarkEmailVerifiedDAO: true,
+			markEmailVerifiedDAOResponse: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:           "user@gmail.com",
+				EmailVerifiedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.MarkEmailVerifiedResponse{
+				ID:              "00000000-0000-0000-0000-000000000001",
+				Email:           "user@gmail.com",
+				EmailVerifiedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.MarkEmailVerifiedRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallMarkEmailVerifiedDAO: true,
+			markEmailVerifiedDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrMarkEmailVerified,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.MarkEmailVerifiedRequest{
+				ID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidMarkEmailVerifiedRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			markEmailVerifiedDAO := daomocks.NewMockMarkEmailVerified(t)
+
+			if testCase.shouldCallMarkEmailVerifiedDAO {
+				markEmailVerifiedDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+					).
+					Return(testCase.markEmailVerifiedDAOResponse, testCase.markEmailVerifiedDAOError)
+			}
+
+			service := services.NewMarkEmailVerified(markEmailVerifiedDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			markEmailVerifiedDAO.AssertExpectations(t)
+		})
+	}
+}
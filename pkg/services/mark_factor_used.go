@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidMarkFactorUsedRequest = errors.New("invalid mark factor used request")
+	ErrMarkFactorUsed               = errors.New("mark factor used")
+)
+
+var markFactorUsedValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type MarkFactorUsedRequest struct {
+	ID           string `validate:"required,len=36"`
+	CredentialID string `validate:"required,len=36"`
+}
+
+type MarkFactorUsedResponse struct {
+	ID           string
+	CredentialID string
+	Type         entities.FactorType
+	Label        string
+
+	SecretRef string
+
+	WebAuthnCredentialID string
+	WebAuthnPublicKey    []byte
+
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+type MarkFactorUsed interface {
+	Exec(ctx context.Context, data *MarkFactorUsedRequest) (*MarkFactorUsedResponse, error)
+}
+
+type markFactorUsedImpl struct {
+	dao dao.MarkFactorUsed
+}
+
+func (service *markFactorUsedImpl) Exec(
+	ctx context.Context, data *MarkFactorUsedRequest,
+) (*MarkFactorUsedResponse, error) {
+	if err := markFactorUsedValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidMarkFactorUsedRequest, err)
+	}
+
+	factorID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidMarkFactorUsedRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidMarkFactorUsedRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	factor, err := service.dao.Exec(ctx, factorID, time.Now(), &dao.MarkFactorUsedRequest{
+		CredentialID: credentialID,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrMarkFactorUsed, err)
+	}
+
+	return &MarkFactorUsedResponse{
+		ID:           factor.ID.String(),
+		CredentialID: factor.CredentialID.String(),
+		Type:         factor.Type,
+		Label:        factor.Label,
+
+		SecretRef: factor.SecretRef,
+
+		WebAuthnCredentialID: factor.WebAuthnCredentialID,
+		WebAuthnPublicKey:    factor.WebAuthnPublicKey,
+
+		CreatedAt:  factor.CreatedAt,
+		LastUsedAt: factor.LastUsedAt,
+	}, nil
+}
+
+func NewMarkFactorUsed(dao dao.MarkFactorUsed) MarkFactorUsed {
+	return &markFactorUsedImpl{dao: dao}
+}
@@ -0,0 +1,128 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestMarkFactorUsed(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.MarkFactorUsedRequest
+
+		shouldCallMarkFactorUsedDAO bool
+		markFactorUsedDAOResponse   *entities.CredentialFactor
+		markFactorUsedDAOError      error
+
+		expect    *services.MarkFactorUsedResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.MarkFactorUsedRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallMarkFactorUsedDAO: true,
+			markFactorUsedDAOResponse: &entities.CredentialFactor{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Type:         entities.FactorTypeTOTP,
+				Label:        "phone",
+				SecretRef:    "totp-secret-ref",
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				LastUsedAt:   lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.MarkFactorUsedResponse{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorTypeTOTP,
+				Label:        "phone",
+				SecretRef:    "totp-secret-ref",
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				LastUsedAt:   lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAO/NotFound",
+
+			request: &services.MarkFactorUsedRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallMarkFactorUsedDAO: true,
+			markFactorUsedDAOError:      dao.ErrFactorNotFound,
+
+			expectErr: dao.ErrFactorNotFound,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.MarkFactorUsedRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallMarkFactorUsedDAO: true,
+			markFactorUsedDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrMarkFactorUsed,
+		},
+		{
+			name: "Invalid/CredentialID",
+
+			request: &services.MarkFactorUsedRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidMarkFactorUsedRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			markFactorUsedDAO := daomocks.NewMockMarkFactorUsed(t)
+
+			if testCase.shouldCallMarkFactorUsedDAO {
+				markFactorUsedDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.MarkFactorUsedRequest{
+							CredentialID: uuid.MustParse(testCase.request.CredentialID),
+						},
+					).
+					Return(testCase.markFactorUsedDAOResponse, testCase.markFactorUsedDAOError)
+			}
+
+			service := services.NewMarkFactorUsed(markFactorUsedDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			markFactorUsedDAO.AssertExpectations(t)
+		})
+	}
+}
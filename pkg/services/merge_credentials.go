@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidMergeCredentialsRequest = errors.New("invalid merge credentials request")
+	ErrMergeCredentials               = errors.New("merge credentials")
+)
+
+var mergeCredentialsValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type MergeCredentialsRequest struct {
+	// SurvivorID is the credentials that remain once the merge is complete.
+	SurvivorID string `validate:"required,len=36"`
+	// LoserID is the credentials merged into the survivor. They are deleted, and lookups by their ID or email resolve
+	// to the survivor.
+	LoserID string `validate:"required,len=36,nefield=SurvivorID"`
+}
+
+type MergeCredentialsResponse struct {
+	ID          string
+	Email       string
+	Role        entities.Role
+	Labels      map[string]string
+	MFARequired bool
+	UpdatedAt   *time.Time
+
+	MergedID string
+}
+
+type MergeCredentials interface {
+	Exec(ctx context.Context, data *MergeCredentialsRequest) (*MergeCredentialsResponse, error)
+}
+
+type mergeCredentialsImpl struct {
+	dao dao.MergeCredentials
+}
+
+func (service *mergeCredentialsImpl) Exec(
+	ctx context.Context, data *MergeCredentialsRequest,
+) (*MergeCredentialsResponse, error) {
+	if err := mergeCredentialsValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidMergeCredentialsRequest, err)
+	}
+
+	survivorID, err := uuid.Parse(data.SurvivorID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidMergeCredentialsRequest, fmt.Errorf("uuid value: '%s': %w", data.SurvivorID, err),
+		)
+	}
+
+	loserID, err := uuid.Parse(data.LoserID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidMergeCredentialsRequest, fmt.Errorf("uuid value: '%s': %w", data.LoserID, err),
+		)
+	}
+
+	credentials, err := service.dao.Exec(ctx, time.Now(), &dao.MergeCredentialsRequest{
+		SurvivorID: survivorID,
+		LoserID:    loserID,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrMergeCredentials, err)
+	}
+
+	return &MergeCredentialsResponse{
+		ID:          credentials.ID.String(),
+		Email:       credentials.Email,
+		Role:        credentials.Role,
+		Labels:      credentials.Labels,
+		MFARequired: credentials.MFARequired,
+		UpdatedAt:   credentials.UpdatedAt,
+
+		MergedID: loserID.String(),
+	}, nil
+}
+
+func NewMergeCredentials(dao dao.MergeCredentials) MergeCredentials {
+	return &mergeCredentialsImpl{dao: dao}
+}
@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestMergeCredentials(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.MergeCredentialsRequest
+
+		shouldCallMergeCredentialsDAO bool
+		mergeCredentialsDAOResponse   *entities.Credential
+		mergeCredentialsDAOError      error
+
+		expect    *services.MergeCredentialsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.MergeCredentialsRequest{
+				SurvivorID: "00000000-0000-0000-0000-000000000001",
+				LoserID:    "00000000-0000-0000-0000-000000000002",
+			},
+
+			shouldCallMergeCredentialsDAO: true,
+			mergeCredentialsDAOResponse: &entities.Credential{
+				ID:          uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:       "user@gmail.com",
+				Role:        entities.RoleCore,
+				Labels:      map[string]string{"team": "core", "source": "ads"},
+				MFARequired: true,
+				CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:   lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.MergeCredentialsResponse{
+				ID:          "00000000-0000-0000-0000-000000000001",
+				Email:       "user@gmail.com",
+				Role:        entities.RoleCore,
+				Labels:      map[string]string{"team": "core", "source": "ads"},
+				MFARequired: true,
+				UpdatedAt:   lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+
+				MergedID: "00000000-0000-0000-0000-000000000002",
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.MergeCredentialsRequest{
+				SurvivorID: "00000000-0000-0000-0000-000000000001",
+				LoserID:    "00000000-0000-0000-0000-000000000002",
+			},
+
+			shouldCallMergeCredentialsDAO: true,
+			mergeCredentialsDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrMergeCredentials,
+		},
+		{
+			name: "SameCredentials",
+
+			request: &services.MergeCredentialsRequest{
+				SurvivorID: "00000000-0000-0000-0000-000000000001",
+				LoserID:    "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidMergeCredentialsRequest,
+		},
+		{
+			name: "MissingLoserID",
+
+			request: &services.MergeCredentialsRequest{
+				SurvivorID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidMergeCredentialsRequest,
+		},
+		{
+			name: "InvalidSurvivorID",
+
+			request: &services.MergeCredentialsRequest{
+				SurvivorID: "00000000x0000x0000x0000x000000000001",
+				LoserID:    "00000000-0000-0000-0000-000000000002",
+			},
+
+			expectErr: services.ErrInvalidMergeCredentialsRequest,
+		},
+		{
+			name: "InvalidLoserID",
+
+			request: &services.MergeCredentialsRequest{
+				SurvivorID: "00000000-0000-0000-0000-000000000001",
+				LoserID:    "00000000x0000x0000x0000x000000000002",
+			},
+
+			expectErr: services.ErrInvalidMergeCredentialsRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mergeCredentialsDAO := daomocks.NewMockMergeCredentials(t)
+
+			if testCase.shouldCallMergeCredentialsDAO {
+				mergeCredentialsDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.MergeCredentialsRequest{
+							SurvivorID: uuid.MustParse(testCase.request.SurvivorID),
+							LoserID:    uuid.MustParse(testCase.request.LoserID),
+						},
+					).
+					Return(testCase.mergeCredentialsDAOResponse, testCase.mergeCredentialsDAOError)
+			}
+
+			service := services.NewMergeCredentials(mergeCredentialsDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			mergeCredentialsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockConsumeToken is an autogenerated mock type for the ConsumeToken type
+type MockConsumeToken struct {
+	mock.Mock
+}
+
+type MockConsumeToken_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockConsumeToken) EXPECT() *MockConsumeToken_Expecter {
+	return &MockConsumeToken_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockConsumeToken) Exec(ctx context.Context, data *services.ConsumeTokenRequest) (*services.ConsumeTokenResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.ConsumeTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ConsumeTokenRequest) (*services.ConsumeTokenResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ConsumeTokenRequest) *services.ConsumeTokenResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.ConsumeTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.ConsumeTokenRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConsumeToken_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockConsumeToken_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.ConsumeTokenRequest
+func (_e *MockConsumeToken_Expecter) Exec(ctx interface{}, data interface{}) *MockConsumeToken_Exec_Call {
+	return &MockConsumeToken_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockConsumeToken_Exec_Call) Run(run func(ctx context.Context, data *services.ConsumeTokenRequest)) *MockConsumeToken_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.ConsumeTokenRequest))
+	})
+	return _c
+}
+
+func (_c *MockConsumeToken_Exec_Call) Return(_a0 *services.ConsumeTokenResponse, _a1 error) *MockConsumeToken_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConsumeToken_Exec_Call) RunAndReturn(run func(context.Context, *services.ConsumeTokenRequest) (*services.ConsumeTokenResponse, error)) *MockConsumeToken_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockConsumeToken creates a new instance of MockConsumeToken. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockConsumeToken(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockConsumeToken {
+	mock := &MockConsumeToken{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCountRoleSeats is an autogenerated mock type for the CountRoleSeats type
+type MockCountRoleSeats struct {
+	mock.Mock
+}
+
+type MockCountRoleSeats_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCountRoleSeats) EXPECT() *MockCountRoleSeats_Expecter {
+	return &MockCountRoleSeats_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx
+func (_m *MockCountRoleSeats) Exec(ctx context.Context) (*services.CountRoleSeatsResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.CountRoleSeatsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*services.CountRoleSeatsResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *services.CountRoleSeatsResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.CountRoleSeatsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCountRoleSeats_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockCountRoleSeats_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCountRoleSeats_Expecter) Exec(ctx interface{}) *MockCountRoleSeats_Exec_Call {
+	return &MockCountRoleSeats_Exec_Call{Call: _e.mock.On("Exec", ctx)}
+}
+
+func (_c *MockCountRoleSeats_Exec_Call) Run(run func(ctx context.Context)) *MockCountRoleSeats_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCountRoleSeats_Exec_Call) Return(_a0 *services.CountRoleSeatsResponse, _a1 error) *MockCountRoleSeats_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCountRoleSeats_Exec_Call) RunAndReturn(run func(context.Context) (*services.CountRoleSeatsResponse, error)) *MockCountRoleSeats_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCountRoleSeats creates a new instance of MockCountRoleSeats. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCountRoleSeats(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCountRoleSeats {
+	mock := &MockCountRoleSeats{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCreateServiceAccount is an autogenerated mock type for the CreateServiceAccount type
+type MockCreateServiceAccount struct {
+	mock.Mock
+}
+
+type MockCreateServiceAccount_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCreateServiceAccount) EXPECT() *MockCreateServiceAccount_Expecter {
+	return &MockCreateServiceAccount_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockCreateServiceAccount) Exec(ctx context.Context, data *services.CreateServiceAccountRequest) (*services.CreateServiceAccountResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.CreateServiceAccountResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.CreateServiceAccountRequest) (*services.CreateServiceAccountResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.CreateServiceAccountRequest) *services.CreateServiceAccountResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.CreateServiceAccountResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.CreateServiceAccountRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCreateServiceAccount_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockCreateServiceAccount_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.CreateServiceAccountRequest
+func (_e *MockCreateServiceAccount_Expecter) Exec(ctx interface{}, data interface{}) *MockCreateServiceAccount_Exec_Call {
+	return &MockCreateServiceAccount_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockCreateServiceAccount_Exec_Call) Run(run func(ctx context.Context, data *services.CreateServiceAccountRequest)) *MockCreateServiceAccount_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.CreateServiceAccountRequest))
+	})
+	return _c
+}
+
+func (_c *MockCreateServiceAccount_Exec_Call) Return(_a0 *services.CreateServiceAccountResponse, _a1 error) *MockCreateServiceAccount_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCreateServiceAccount_Exec_Call) RunAndReturn(run func(context.Context, *services.CreateServiceAccountRequest) (*services.CreateServiceAccountResponse, error)) *MockCreateServiceAccount_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCreateServiceAccount creates a new instance of MockCreateServiceAccount. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCreateServiceAccount(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCreateServiceAccount {
+	mock := &MockCreateServiceAccount{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEditCredentialNote is an autogenerated mock type for the EditCredentialNote type
+type MockEditCredentialNote struct {
+	mock.Mock
+}
+
+type MockEditCredentialNote_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEditCredentialNote) EXPECT() *MockEditCredentialNote_Expecter {
+	return &MockEditCredentialNote_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockEditCredentialNote) Exec(ctx context.Context, data *services.EditCredentialNoteRequest) (*services.EditCredentialNoteResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.EditCredentialNoteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.EditCredentialNoteRequest) (*services.EditCredentialNoteResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.EditCredentialNoteRequest) *services.EditCredentialNoteResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.EditCredentialNoteResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.EditCredentialNoteRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEditCredentialNote_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockEditCredentialNote_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.EditCredentialNoteRequest
+func (_e *MockEditCredentialNote_Expecter) Exec(ctx interface{}, data interface{}) *MockEditCredentialNote_Exec_Call {
+	return &MockEditCredentialNote_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockEditCredentialNote_Exec_Call) Run(run func(ctx context.Context, data *services.EditCredentialNoteRequest)) *MockEditCredentialNote_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.EditCredentialNoteRequest))
+	})
+	return _c
+}
+
+func (_c *MockEditCredentialNote_Exec_Call) Return(_a0 *services.EditCredentialNoteResponse, _a1 error) *MockEditCredentialNote_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEditCredentialNote_Exec_Call) RunAndReturn(run func(context.Context, *services.EditCredentialNoteRequest) (*services.EditCredentialNoteResponse, error)) *MockEditCredentialNote_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEditCredentialNote creates a new instance of MockEditCredentialNote. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEditCredentialNote(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEditCredentialNote {
+	mock := &MockEditCredentialNote{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
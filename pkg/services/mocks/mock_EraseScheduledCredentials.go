@@ -0,0 +1,95 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEraseScheduledCredentials is an autogenerated mock type for the EraseScheduledCredentials type
+type MockEraseScheduledCredentials struct {
+	mock.Mock
+}
+
+type MockEraseScheduledCredentials_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEraseScheduledCredentials) EXPECT() *MockEraseScheduledCredentials_Expecter {
+	return &MockEraseScheduledCredentials_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx
+func (_m *MockEraseScheduledCredentials) Exec(ctx context.Context) (*services.EraseScheduledCredentialsResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.EraseScheduledCredentialsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*services.EraseScheduledCredentialsResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *services.EraseScheduledCredentialsResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.EraseScheduledCredentialsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEraseScheduledCredentials_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockEraseScheduledCredentials_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockEraseScheduledCredentials_Expecter) Exec(ctx interface{}) *MockEraseScheduledCredentials_Exec_Call {
+	return &MockEraseScheduledCredentials_Exec_Call{Call: _e.mock.On("Exec", ctx)}
+}
+
+func (_c *MockEraseScheduledCredentials_Exec_Call) Run(run func(ctx context.Context)) *MockEraseScheduledCredentials_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockEraseScheduledCredentials_Exec_Call) Return(_a0 *services.EraseScheduledCredentialsResponse, _a1 error) *MockEraseScheduledCredentials_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEraseScheduledCredentials_Exec_Call) RunAndReturn(run func(context.Context) (*services.EraseScheduledCredentialsResponse, error)) *MockEraseScheduledCredentials_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEraseScheduledCredentials creates a new instance of MockEraseScheduledCredentials. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEraseScheduledCredentials(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEraseScheduledCredentials {
+	mock := &MockEraseScheduledCredentials{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
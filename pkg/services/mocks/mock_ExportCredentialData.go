@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockExportCredentialData is an autogenerated mock type for the ExportCredentialData type
+type MockExportCredentialData struct {
+	mock.Mock
+}
+
+type MockExportCredentialData_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportCredentialData) EXPECT() *MockExportCredentialData_Expecter {
+	return &MockExportCredentialData_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockExportCredentialData) Exec(ctx context.Context, data *services.ExportCredentialDataRequest) (*services.ExportCredentialDataResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.ExportCredentialDataResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ExportCredentialDataRequest) (*services.ExportCredentialDataResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ExportCredentialDataRequest) *services.ExportCredentialDataResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.ExportCredentialDataResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.ExportCredentialDataRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExportCredentialData_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockExportCredentialData_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.ExportCredentialDataRequest
+func (_e *MockExportCredentialData_Expecter) Exec(ctx interface{}, data interface{}) *MockExportCredentialData_Exec_Call {
+	return &MockExportCredentialData_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockExportCredentialData_Exec_Call) Run(run func(ctx context.Context, data *services.ExportCredentialDataRequest)) *MockExportCredentialData_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.ExportCredentialDataRequest))
+	})
+	return _c
+}
+
+func (_c *MockExportCredentialData_Exec_Call) Return(_a0 *services.ExportCredentialDataResponse, _a1 error) *MockExportCredentialData_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExportCredentialData_Exec_Call) RunAndReturn(run func(context.Context, *services.ExportCredentialDataRequest) (*services.ExportCredentialDataResponse, error)) *MockExportCredentialData_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExportCredentialData creates a new instance of MockExportCredentialData. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportCredentialData(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportCredentialData {
+	mock := &MockExportCredentialData{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
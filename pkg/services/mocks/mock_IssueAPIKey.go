@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIssueAPIKey is an autogenerated mock type for the IssueAPIKey type
+type MockIssueAPIKey struct {
+	mock.Mock
+}
+
+type MockIssueAPIKey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIssueAPIKey) EXPECT() *MockIssueAPIKey_Expecter {
+	return &MockIssueAPIKey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockIssueAPIKey) Exec(ctx context.Context, data *services.IssueAPIKeyRequest) (*services.IssueAPIKeyResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.IssueAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.IssueAPIKeyRequest) (*services.IssueAPIKeyResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.IssueAPIKeyRequest) *services.IssueAPIKeyResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.IssueAPIKeyResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.IssueAPIKeyRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIssueAPIKey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockIssueAPIKey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.IssueAPIKeyRequest
+func (_e *MockIssueAPIKey_Expecter) Exec(ctx interface{}, data interface{}) *MockIssueAPIKey_Exec_Call {
+	return &MockIssueAPIKey_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockIssueAPIKey_Exec_Call) Run(run func(ctx context.Context, data *services.IssueAPIKeyRequest)) *MockIssueAPIKey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.IssueAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockIssueAPIKey_Exec_Call) Return(_a0 *services.IssueAPIKeyResponse, _a1 error) *MockIssueAPIKey_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIssueAPIKey_Exec_Call) RunAndReturn(run func(context.Context, *services.IssueAPIKeyRequest) (*services.IssueAPIKeyResponse, error)) *MockIssueAPIKey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIssueAPIKey creates a new instance of MockIssueAPIKey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIssueAPIKey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIssueAPIKey {
+	mock := &MockIssueAPIKey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
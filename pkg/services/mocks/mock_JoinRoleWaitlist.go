@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockJoinRoleWaitlist is an autogenerated mock type for the JoinRoleWaitlist type
+type MockJoinRoleWaitlist struct {
+	mock.Mock
+}
+
+type MockJoinRoleWaitlist_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJoinRoleWaitlist) EXPECT() *MockJoinRoleWaitlist_Expecter {
+	return &MockJoinRoleWaitlist_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockJoinRoleWaitlist) Exec(ctx context.Context, data *services.JoinRoleWaitlistRequest) (*services.JoinRoleWaitlistResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.JoinRoleWaitlistResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.JoinRoleWaitlistRequest) (*services.JoinRoleWaitlistResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.JoinRoleWaitlistRequest) *services.JoinRoleWaitlistResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.JoinRoleWaitlistResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.JoinRoleWaitlistRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJoinRoleWaitlist_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockJoinRoleWaitlist_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.JoinRoleWaitlistRequest
+func (_e *MockJoinRoleWaitlist_Expecter) Exec(ctx interface{}, data interface{}) *MockJoinRoleWaitlist_Exec_Call {
+	return &MockJoinRoleWaitlist_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockJoinRoleWaitlist_Exec_Call) Run(run func(ctx context.Context, data *services.JoinRoleWaitlistRequest)) *MockJoinRoleWaitlist_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.JoinRoleWaitlistRequest))
+	})
+	return _c
+}
+
+func (_c *MockJoinRoleWaitlist_Exec_Call) Return(_a0 *services.JoinRoleWaitlistResponse, _a1 error) *MockJoinRoleWaitlist_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJoinRoleWaitlist_Exec_Call) RunAndReturn(run func(context.Context, *services.JoinRoleWaitlistRequest) (*services.JoinRoleWaitlistResponse, error)) *MockJoinRoleWaitlist_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockJoinRoleWaitlist creates a new instance of MockJoinRoleWaitlist. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJoinRoleWaitlist(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJoinRoleWaitlist {
+	mock := &MockJoinRoleWaitlist{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListCanonicalEmailClusters is an autogenerated mock type for the ListCanonicalEmailClusters type
+type MockListCanonicalEmailClusters struct {
+	mock.Mock
+}
+
+type MockListCanonicalEmailClusters_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListCanonicalEmailClusters) EXPECT() *MockListCanonicalEmailClusters_Expecter {
+	return &MockListCanonicalEmailClusters_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockListCanonicalEmailClusters) Exec(ctx context.Context, data *services.ListCanonicalEmailClustersRequest) (*services.ListCanonicalEmailClustersResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.ListCanonicalEmailClustersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ListCanonicalEmailClustersRequest) (*services.ListCanonicalEmailClustersResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ListCanonicalEmailClustersRequest) *services.ListCanonicalEmailClustersResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.ListCanonicalEmailClustersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.ListCanonicalEmailClustersRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListCanonicalEmailClusters_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockListCanonicalEmailClusters_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.ListCanonicalEmailClustersRequest
+func (_e *MockListCanonicalEmailClusters_Expecter) Exec(ctx interface{}, data interface{}) *MockListCanonicalEmailClusters_Exec_Call {
+	return &MockListCanonicalEmailClusters_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockListCanonicalEmailClusters_Exec_Call) Run(run func(ctx context.Context, data *services.ListCanonicalEmailClustersRequest)) *MockListCanonicalEmailClusters_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.ListCanonicalEmailClustersRequest))
+	})
+	return _c
+}
+
+func (_c *MockListCanonicalEmailClusters_Exec_Call) Return(_a0 *services.ListCanonicalEmailClustersResponse, _a1 error) *MockListCanonicalEmailClusters_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListCanonicalEmailClusters_Exec_Call) RunAndReturn(run func(context.Context, *services.ListCanonicalEmailClustersRequest) (*services.ListCanonicalEmailClustersResponse, error)) *MockListCanonicalEmailClusters_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListCanonicalEmailClusters creates a new instance of MockListCanonicalEmailClusters. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListCanonicalEmailClusters(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListCanonicalEmailClusters {
+	mock := &MockListCanonicalEmailClusters{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockListFactors is an autogenerated mock type for the ListFactors type
+type MockListFactors struct {
+	mock.Mock
+}
+
+type MockListFactors_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListFactors) EXPECT() *MockListFactors_Expecter {
+	return &MockListFactors_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockListFactors) Exec(ctx context.Context, data *services.ListFactorsRequest) (*services.ListFactorsResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.ListFactorsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ListFactorsRequest) (*services.ListFactorsResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.ListFactorsRequest) *services.ListFactorsResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.ListFactorsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.ListFactorsRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListFactors_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockListFactors_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.ListFactorsRequest
+func (_e *MockListFactors_Expecter) Exec(ctx interface{}, data interface{}) *MockListFactors_Exec_Call {
+	return &MockListFactors_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockListFactors_Exec_Call) Run(run func(ctx context.Context, data *services.ListFactorsRequest)) *MockListFactors_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.ListFactorsRequest))
+	})
+	return _c
+}
+
+func (_c *MockListFactors_Exec_Call) Return(_a0 *services.ListFactorsResponse, _a1 error) *MockListFactors_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListFactors_Exec_Call) RunAndReturn(run func(context.Context, *services.ListFactorsRequest) (*services.ListFactorsResponse, error)) *MockListFactors_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListFactors creates a new instance of MockListFactors. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListFactors(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListFactors {
+	mock := &MockListFactors{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
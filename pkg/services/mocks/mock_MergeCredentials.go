@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockMergeCredentials is an autogenerated mock type for the MergeCredentials type
+type MockMergeCredentials struct {
+	mock.Mock
+}
+
+type MockMergeCredentials_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMergeCredentials) EXPECT() *MockMergeCredentials_Expecter {
+	return &MockMergeCredentials_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockMergeCredentials) Exec(ctx context.Context, data *services.MergeCredentialsRequest) (*services.MergeCredentialsResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.MergeCredentialsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.MergeCredentialsRequest) (*services.MergeCredentialsResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.MergeCredentialsRequest) *services.MergeCredentialsResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.MergeCredentialsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.MergeCredentialsRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockMergeCredentials_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockMergeCredentials_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.MergeCredentialsRequest
+func (_e *MockMergeCredentials_Expecter) Exec(ctx interface{}, data interface{}) *MockMergeCredentials_Exec_Call {
+	return &MockMergeCredentials_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockMergeCredentials_Exec_Call) Run(run func(ctx context.Context, data *services.MergeCredentialsRequest)) *MockMergeCredentials_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.MergeCredentialsRequest))
+	})
+	return _c
+}
+
+func (_c *MockMergeCredentials_Exec_Call) Return(_a0 *services.MergeCredentialsResponse, _a1 error) *MockMergeCredentials_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockMergeCredentials_Exec_Call) RunAndReturn(run func(context.Context, *services.MergeCredentialsRequest) (*services.MergeCredentialsResponse, error)) *MockMergeCredentials_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMergeCredentials creates a new instance of MockMergeCredentials. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMergeCredentials(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMergeCredentials {
+	mock := &MockMergeCredentials{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
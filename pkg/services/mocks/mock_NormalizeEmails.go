@@ -0,0 +1,95 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockNormalizeEmails is an autogenerated mock type for the NormalizeEmails type
+type MockNormalizeEmails struct {
+	mock.Mock
+}
+
+type MockNormalizeEmails_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNormalizeEmails) EXPECT() *MockNormalizeEmails_Expecter {
+	return &MockNormalizeEmails_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx
+func (_m *MockNormalizeEmails) Exec(ctx context.Context) (*services.NormalizeEmailsResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.NormalizeEmailsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*services.NormalizeEmailsResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *services.NormalizeEmailsResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.NormalizeEmailsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNormalizeEmails_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockNormalizeEmails_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockNormalizeEmails_Expecter) Exec(ctx interface{}) *MockNormalizeEmails_Exec_Call {
+	return &MockNormalizeEmails_Exec_Call{Call: _e.mock.On("Exec", ctx)}
+}
+
+func (_c *MockNormalizeEmails_Exec_Call) Run(run func(ctx context.Context)) *MockNormalizeEmails_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockNormalizeEmails_Exec_Call) Return(_a0 *services.NormalizeEmailsResponse, _a1 error) *MockNormalizeEmails_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNormalizeEmails_Exec_Call) RunAndReturn(run func(context.Context) (*services.NormalizeEmailsResponse, error)) *MockNormalizeEmails_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNormalizeEmails creates a new instance of MockNormalizeEmails. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNormalizeEmails(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNormalizeEmails {
+	mock := &MockNormalizeEmails{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
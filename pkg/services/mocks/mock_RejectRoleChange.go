@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRejectRoleChange is an autogenerated mock type for the RejectRoleChange type
+type MockRejectRoleChange struct {
+	mock.Mock
+}
+
+type MockRejectRoleChange_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRejectRoleChange) EXPECT() *MockRejectRoleChange_Expecter {
+	return &MockRejectRoleChange_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockRejectRoleChange) Exec(ctx context.Context, data *services.RejectRoleChangeRequest) (*services.RejectRoleChangeResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.RejectRoleChangeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RejectRoleChangeRequest) (*services.RejectRoleChangeResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RejectRoleChangeRequest) *services.RejectRoleChangeResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.RejectRoleChangeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.RejectRoleChangeRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRejectRoleChange_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRejectRoleChange_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.RejectRoleChangeRequest
+func (_e *MockRejectRoleChange_Expecter) Exec(ctx interface{}, data interface{}) *MockRejectRoleChange_Exec_Call {
+	return &MockRejectRoleChange_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockRejectRoleChange_Exec_Call) Run(run func(ctx context.Context, data *services.RejectRoleChangeRequest)) *MockRejectRoleChange_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.RejectRoleChangeRequest))
+	})
+	return _c
+}
+
+func (_c *MockRejectRoleChange_Exec_Call) Return(_a0 *services.RejectRoleChangeResponse, _a1 error) *MockRejectRoleChange_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRejectRoleChange_Exec_Call) RunAndReturn(run func(context.Context, *services.RejectRoleChangeRequest) (*services.RejectRoleChangeResponse, error)) *MockRejectRoleChange_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRejectRoleChange creates a new instance of MockRejectRoleChange. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRejectRoleChange(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRejectRoleChange {
+	mock := &MockRejectRoleChange{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
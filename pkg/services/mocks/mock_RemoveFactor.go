@@ -0,0 +1,84 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRemoveFactor is an autogenerated mock type for the RemoveFactor type
+type MockRemoveFactor struct {
+	mock.Mock
+}
+
+type MockRemoveFactor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRemoveFactor) EXPECT() *MockRemoveFactor_Expecter {
+	return &MockRemoveFactor_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockRemoveFactor) Exec(ctx context.Context, data *services.RemoveFactorRequest) error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RemoveFactorRequest) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRemoveFactor_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRemoveFactor_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.RemoveFactorRequest
+func (_e *MockRemoveFactor_Expecter) Exec(ctx interface{}, data interface{}) *MockRemoveFactor_Exec_Call {
+	return &MockRemoveFactor_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockRemoveFactor_Exec_Call) Run(run func(ctx context.Context, data *services.RemoveFactorRequest)) *MockRemoveFactor_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.RemoveFactorRequest))
+	})
+	return _c
+}
+
+func (_c *MockRemoveFactor_Exec_Call) Return(_a0 error) *MockRemoveFactor_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRemoveFactor_Exec_Call) RunAndReturn(run func(context.Context, *services.RemoveFactorRequest) error) *MockRemoveFactor_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRemoveFactor creates a new instance of MockRemoveFactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRemoveFactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRemoveFactor {
+	mock := &MockRemoveFactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
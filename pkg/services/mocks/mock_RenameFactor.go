@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRenameFactor is an autogenerated mock type for the RenameFactor type
+type MockRenameFactor struct {
+	mock.Mock
+}
+
+type MockRenameFactor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRenameFactor) EXPECT() *MockRenameFactor_Expecter {
+	return &MockRenameFactor_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockRenameFactor) Exec(ctx context.Context, data *services.RenameFactorRequest) (*services.RenameFactorResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.RenameFactorResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RenameFactorRequest) (*services.RenameFactorResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RenameFactorRequest) *services.RenameFactorResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.RenameFactorResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.RenameFactorRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRenameFactor_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRenameFactor_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.RenameFactorRequest
+func (_e *MockRenameFactor_Expecter) Exec(ctx interface{}, data interface{}) *MockRenameFactor_Exec_Call {
+	return &MockRenameFactor_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockRenameFactor_Exec_Call) Run(run func(ctx context.Context, data *services.RenameFactorRequest)) *MockRenameFactor_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.RenameFactorRequest))
+	})
+	return _c
+}
+
+func (_c *MockRenameFactor_Exec_Call) Return(_a0 *services.RenameFactorResponse, _a1 error) *MockRenameFactor_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRenameFactor_Exec_Call) RunAndReturn(run func(context.Context, *services.RenameFactorRequest) (*services.RenameFactorResponse, error)) *MockRenameFactor_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRenameFactor creates a new instance of MockRenameFactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRenameFactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRenameFactor {
+	mock := &MockRenameFactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
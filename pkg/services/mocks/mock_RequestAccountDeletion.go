@@ -0,0 +1,96 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRequestAccountDeletion is an autogenerated mock type for the RequestAccountDeletion type
+type MockRequestAccountDeletion struct {
+	mock.Mock
+}
+
+type MockRequestAccountDeletion_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRequestAccountDeletion) EXPECT() *MockRequestAccountDeletion_Expecter {
+	return &MockRequestAccountDeletion_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockRequestAccountDeletion) Exec(ctx context.Context, data *services.RequestAccountDeletionRequest) (*services.RequestAccountDeletionResponse, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.RequestAccountDeletionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RequestAccountDeletionRequest) (*services.RequestAccountDeletionResponse, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RequestAccountDeletionRequest) *services.RequestAccountDeletionResponse); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.RequestAccountDeletionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *services.RequestAccountDeletionRequest) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRequestAccountDeletion_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRequestAccountDeletion_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.RequestAccountDeletionRequest
+func (_e *MockRequestAccountDeletion_Expecter) Exec(ctx interface{}, data interface{}) *MockRequestAccountDeletion_Exec_Call {
+	return &MockRequestAccountDeletion_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockRequestAccountDeletion_Exec_Call) Run(run func(ctx context.Context, data *services.RequestAccountDeletionRequest)) *MockRequestAccountDeletion_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.RequestAccountDeletionRequest))
+	})
+	return _c
+}
+
+func (_c *MockRequestAccountDeletion_Exec_Call) Return(_a0 *services.RequestAccountDeletionResponse, _a1 error) *MockRequestAccountDeletion_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRequestAccountDeletion_Exec_Call) RunAndReturn(run func(context.Context, *services.RequestAccountDeletionRequest) (*services.RequestAccountDeletionResponse, error)) *MockRequestAccountDeletion_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRequestAccountDeletion creates a new instance of MockRequestAccountDeletion. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRequestAccountDeletion(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRequestAccountDeletion {
+	mock := &MockRequestAccountDeletion{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRevokeAPIKey is an autogenerated mock type for the RevokeAPIKey type
+type MockRevokeAPIKey struct {
+	mock.Mock
+}
+
+type MockRevokeAPIKey_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRevokeAPIKey) EXPECT() *MockRevokeAPIKey_Expecter {
+	return &MockRevokeAPIKey_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockRevokeAPIKey) Exec(ctx context.Context, data *services.RevokeAPIKeyRequest) error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.RevokeAPIKeyRequest) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRevokeAPIKey_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockRevokeAPIKey_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.RevokeAPIKeyRequest
+func (_e *MockRevokeAPIKey_Expecter) Exec(ctx interface{}, data interface{}) *MockRevokeAPIKey_Exec_Call {
+	return &MockRevokeAPIKey_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockRevokeAPIKey_Exec_Call) Run(run func(ctx context.Context, data *services.RevokeAPIKeyRequest)) *MockRevokeAPIKey_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.RevokeAPIKeyRequest))
+	})
+	return _c
+}
+
+func (_c *MockRevokeAPIKey_Exec_Call) Return(_a0 error) *MockRevokeAPIKey_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRevokeAPIKey_Exec_Call) RunAndReturn(run func(context.Context, *services.RevokeAPIKeyRequest) error) *MockRevokeAPIKey_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRevokeAPIKey creates a new instance of MockRevokeAPIKey. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRevokeAPIKey(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRevokeAPIKey {
+	mock := &MockRevokeAPIKey{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockSweepExpiredTokens is an autogenerated mock type for the SweepExpiredTokens type
+type MockSweepExpiredTokens struct {
+	mock.Mock
+}
+
+type MockSweepExpiredTokens_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSweepExpiredTokens) EXPECT() *MockSweepExpiredTokens_Expecter {
+	return &MockSweepExpiredTokens_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx
+func (_m *MockSweepExpiredTokens) Exec(ctx context.Context) (*services.SweepExpiredTokensResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 *services.SweepExpiredTokensResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*services.SweepExpiredTokensResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *services.SweepExpiredTokensResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.SweepExpiredTokensResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSweepExpiredTokens_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockSweepExpiredTokens_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSweepExpiredTokens_Expecter) Exec(ctx interface{}) *MockSweepExpiredTokens_Exec_Call {
+	return &MockSweepExpiredTokens_Exec_Call{Call: _e.mock.On("Exec", ctx)}
+}
+
+func (_c *MockSweepExpiredTokens_Exec_Call) Run(run func(ctx context.Context)) *MockSweepExpiredTokens_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockSweepExpiredTokens_Exec_Call) Return(_a0 *services.SweepExpiredTokensResponse, _a1 error) *MockSweepExpiredTokens_Exec_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSweepExpiredTokens_Exec_Call) RunAndReturn(run func(context.Context) (*services.SweepExpiredTokensResponse, error)) *MockSweepExpiredTokens_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSweepExpiredTokens creates a new instance of MockSweepExpiredTokens. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSweepExpiredTokens(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSweepExpiredTokens {
+	mock := &MockSweepExpiredTokens{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
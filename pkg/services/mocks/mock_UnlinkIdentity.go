@@ -0,0 +1,84 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package servicesmocks
+
+import (
+	context "context"
+
+	services "github.com/a-novel/uservice-credentials/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockUnlinkIdentity is an autogenerated mock type for the UnlinkIdentity type
+type MockUnlinkIdentity struct {
+	mock.Mock
+}
+
+type MockUnlinkIdentity_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUnlinkIdentity) EXPECT() *MockUnlinkIdentity_Expecter {
+	return &MockUnlinkIdentity_Expecter{mock: &_m.Mock}
+}
+
+// Exec provides a mock function with given fields: ctx, data
+func (_m *MockUnlinkIdentity) Exec(ctx context.Context, data *services.UnlinkIdentityRequest) error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.UnlinkIdentityRequest) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUnlinkIdentity_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockUnlinkIdentity_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data *services.UnlinkIdentityRequest
+func (_e *MockUnlinkIdentity_Expecter) Exec(ctx interface{}, data interface{}) *MockUnlinkIdentity_Exec_Call {
+	return &MockUnlinkIdentity_Exec_Call{Call: _e.mock.On("Exec", ctx, data)}
+}
+
+func (_c *MockUnlinkIdentity_Exec_Call) Run(run func(ctx context.Context, data *services.UnlinkIdentityRequest)) *MockUnlinkIdentity_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*services.UnlinkIdentityRequest))
+	})
+	return _c
+}
+
+func (_c *MockUnlinkIdentity_Exec_Call) Return(_a0 error) *MockUnlinkIdentity_Exec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUnlinkIdentity_Exec_Call) RunAndReturn(run func(context.Context, *services.UnlinkIdentityRequest) error) *MockUnlinkIdentity_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUnlinkIdentity creates a new instance of MockUnlinkIdentity. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUnlinkIdentity(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUnlinkIdentity {
+	mock := &MockUnlinkIdentity{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var ErrNormalizeEmails = errors.New("normalize emails")
+
+type NormalizeEmailsResponseConflict struct {
+	TenantID     string
+	CredentialID string
+	Email        string
+}
+
+type NormalizeEmailsResponse struct {
+	Normalized int
+	// Conflicts are credentials whose email normalizes to the email of other credentials. They keep their stored
+	// email, and cannot be found by email until merged.
+	Conflicts []*NormalizeEmailsResponseConflict
+}
+
+type NormalizeEmails interface {
+	Exec(ctx context.Context) (*NormalizeEmailsResponse, error)
+}
+
+type normalizeEmailsImpl struct {
+	dao dao.NormalizeEmails
+	// batchSize is the number of credentials normalized per transaction.
+	batchSize int
+}
+
+// Exec normalizes the emails of every credential that still needs it, one batch at a time. Lookups normalize the
+// emails they are given, so it must run before the server starts serving.
+func (service *normalizeEmailsImpl) Exec(ctx context.Context) (*NormalizeEmailsResponse, error) {
+	response := &NormalizeEmailsResponse{Conflicts: make([]*NormalizeEmailsResponseConflict, 0)}
+
+	request := &dao.NormalizeEmailsRequest{Limit: service.batchSize}
+
+	for {
+		res, err := service.dao.Exec(ctx, request)
+		if err != nil {
+			return nil, errors.Join(ErrNormalizeEmails, err)
+		}
+
+		if res.Last == uuid.Nil {
+			return response, nil
+		}
+
+		response.Normalized += len(res.Normalized)
+		response.Conflicts = append(response.Conflicts, lo.Map(
+			res.Conflicts,
+			func(item *entities.Credential, _ int) *NormalizeEmailsResponseConflict {
+				return &NormalizeEmailsResponseConflict{
+					TenantID:     item.TenantID,
+					CredentialID: item.ID.String(),
+					Email:        item.Email,
+				}
+			},
+		)...)
+
+		request = &dao.NormalizeEmailsRequest{After: res.Last, Limit: service.batchSize}
+	}
+}
+
+func NewNormalizeEmails(dao dao.NormalizeEmails, batchSize int) NormalizeEmails {
+	return &normalizeEmailsImpl{dao: dao, batchSize: batchSize}
+}
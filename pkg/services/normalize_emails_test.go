@@ -0,0 +1,139 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestNormalizeEmails(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		batchSize int
+
+		// normalizeEmailsDAOResults are returned by the successive calls to the DAO. Each call resumes after the last
+		// credentials of the previous result.
+		normalizeEmailsDAOResults []*dao.NormalizeEmailsResult
+		normalizeEmailsDAOError   error
+
+		expect    *services.NormalizeEmailsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			batchSize: 2,
+
+			normalizeEmailsDAOResults: []*dao.NormalizeEmailsResult{
+				{
+					Last: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Normalized: []*entities.Credential{
+						{
+							ID:       uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+							TenantID: "default",
+							Email:    "jane@xn--bcher-kva.de",
+						},
+					},
+					Conflicts: []*entities.Credential{},
+				},
+				{
+					Last: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+					Normalized: []*entities.Credential{
+						{
+							ID:       uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+							TenantID: "default",
+							Email:    "john@example.com",
+						},
+					},
+					Conflicts: []*entities.Credential{
+						{
+							ID:       uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+							TenantID: "default",
+							Email:    "john@Example.com",
+						},
+					},
+				},
+				{
+					Normalized: []*entities.Credential{},
+					Conflicts:  []*entities.Credential{},
+				},
+			},
+
+			expect: &services.NormalizeEmailsResponse{
+				Normalized: 2,
+				Conflicts: []*services.NormalizeEmailsResponseConflict{
+					{
+						TenantID:     "default",
+						CredentialID: "00000000-0000-0000-0000-000000000004",
+						Email:        "john@Example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "OK/Nothing",
+
+			batchSize: 2,
+
+			normalizeEmailsDAOResults: []*dao.NormalizeEmailsResult{
+				{
+					Normalized: []*entities.Credential{},
+					Conflicts:  []*entities.Credential{},
+				},
+			},
+
+			expect: &services.NormalizeEmailsResponse{
+				Conflicts: []*services.NormalizeEmailsResponseConflict{},
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			batchSize: 2,
+
+			normalizeEmailsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrNormalizeEmails,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			normalizeEmailsDAO := daomocks.NewMockNormalizeEmails(t)
+
+			after := uuid.Nil
+			for _, result := range testCase.normalizeEmailsDAOResults {
+				normalizeEmailsDAO.
+					On("Exec", context.Background(), &dao.NormalizeEmailsRequest{After: after, Limit: testCase.batchSize}).
+					Return(result, nil).
+					Once()
+
+				after = result.Last
+			}
+
+			if testCase.normalizeEmailsDAOError != nil {
+				normalizeEmailsDAO.
+					On("Exec", context.Background(), &dao.NormalizeEmailsRequest{After: after, Limit: testCase.batchSize}).
+					Return(nil, testCase.normalizeEmailsDAOError).
+					Once()
+			}
+
+			service := services.NewNormalizeEmails(normalizeEmailsDAO, testCase.batchSize)
+			response, err := service.Exec(context.Background())
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			normalizeEmailsDAO.AssertExpectations(t)
+		})
+	}
+}
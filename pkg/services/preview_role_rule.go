@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidPreviewRoleRuleRequest = errors.New("invalid preview role rule request")
+	ErrPreviewRoleRule               = errors.New("preview role rule")
+)
+
+var previewRoleRuleValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterRole(previewRoleRuleValidate)
+	entities.RegisterRoleRulePattern(previewRoleRuleValidate)
+}
+
+// PreviewRoleRuleRequest describes a rule, that matches either a domain or a pattern.
+type PreviewRoleRuleRequest struct {
+	Domain  string        `validate:"required_without=Pattern,excluded_with=Pattern,max=253"`
+	Pattern string        `validate:"required_without=Domain,max=256,role_rule_pattern"`
+	Role    entities.Role `validate:"omitempty,role"`
+	Limit   int           `validate:"required,min=1,max=128"`
+	Offset  int           `validate:"omitempty,min=0"`
+}
+
+type PreviewRoleRuleResponseCredential struct {
+	ID    string
+	Email string
+	// Role is the current role of the credentials, that the rule would replace.
+	Role entities.Role
+}
+
+type PreviewRoleRuleResponse struct {
+	Credentials []*PreviewRoleRuleResponseCredential
+}
+
+type PreviewRoleRule interface {
+	Exec(ctx context.Context, data *PreviewRoleRuleRequest) (*PreviewRoleRuleResponse, error)
+}
+
+type previewRoleRuleImpl struct {
+	dao dao.PreviewRoleRule
+}
+
+func (service *previewRoleRuleImpl) Exec(
+	ctx context.Context, data *PreviewRoleRuleRequest,
+) (*PreviewRoleRuleResponse, error) {
+	if err := previewRoleRuleValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidPreviewRoleRuleRequest, err)
+	}
+
+	rule, err := entities.NewRoleRule(entities.NormalizeEmailDomain(data.Domain), data.Pattern, data.Role)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidPreviewRoleRuleRequest, err)
+	}
+
+	credentials, err := service.dao.Exec(ctx, &dao.PreviewRoleRuleRequest{
+		Rule:   rule,
+		Limit:  data.Limit,
+		Offset: data.Offset,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrPreviewRoleRule, err)
+	}
+
+	return &PreviewRoleRuleResponse{
+		Credentials: lo.Map(
+			credentials,
+			func(item *entities.Credential, _ int) *PreviewRoleRuleResponseCredential {
+				return &PreviewRoleRuleResponseCredential{
+					ID:    item.ID.String(),
+					Email: item.Email,
+					Role:  item.Role,
+				}
+			},
+		),
+	}, nil
+}
+
+func NewPreviewRoleRule(dao dao.PreviewRoleRule) PreviewRoleRule {
+	return &previewRoleRuleImpl{dao: dao}
+}
@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestPreviewRoleRule(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.PreviewRoleRuleRequest
+
+		shouldCallPreviewRoleRuleDAO bool
+		previewRoleRuleDAORequest    *dao.PreviewRoleRuleRequest
+		previewRoleRuleDAOResponse   []*entities.Credential
+		previewRoleRuleDAOError      error
+
+		expect    *services.PreviewRoleRuleResponse
+		expectErr error
+	}{
+		{
+			name: "OK/Domain",
+
+			request: &services.PreviewRoleRuleRequest{
+				Domain: "staff.example.com",
+				Role:   entities.RoleCore,
+				Limit:  10,
+				Offset: 5,
+			},
+
+			shouldCallPreviewRoleRuleDAO: true,
+			previewRoleRuleDAORequest: &dao.PreviewRoleRuleRequest{
+				Rule:   entities.RoleRule{Domain: "staff.example.com", Role: entities.RoleCore},
+				Limit:  10,
+				Offset: 5,
+			},
+			previewRoleRuleDAOResponse: []*entities.Credential{
+				{
+					ID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Email: "alice@staff.example.com",
+				},
+				{
+					ID:    uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+					Email: "bob@staff.example.com",
+					Role:  entities.RoleEarlyAccessProgram,
+				},
+			},
+
+			expect: &services.PreviewRoleRuleResponse{
+				Credentials: []*services.PreviewRoleRuleResponseCredential{
+					{
+						ID:    "00000000-0000-0000-0000-000000000001",
+						Email: "alice@staff.example.com",
+					},
+					{
+						ID:    "00000000-0000-0000-0000-000000000002",
+						Email: "bob@staff.example.com",
+						Role:  entities.RoleEarlyAccessProgram,
+					},
+				},
+			},
+		},
+		{
+			name: "OK/Pattern",
+
+			request: &services.PreviewRoleRuleRequest{
+				Pattern: `@(eu|us)\.staff\.example\.com$`,
+				Role:    entities.RoleEarlyAccessProgram,
+				Limit:   10,
+			},
+
+			shouldCallPreviewRoleRuleDAO: true,
+			previewRoleRuleDAORequest: &dao.PreviewRoleRuleRequest{
+				Rule: lo.Must(entities.NewRoleRule(
+					"", `@(eu|us)\.staff\.example\.com$`, entities.RoleEarlyAccessProgram,
+				)),
+				Limit: 10,
+			},
+			previewRoleRuleDAOResponse: []*entities.Credential{},
+
+			expect: &services.PreviewRoleRuleResponse{
+				Credentials: []*services.PreviewRoleRuleResponseCredential{},
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.PreviewRoleRuleRequest{
+				Domain: "staff.example.com",
+				Role:   entities.RoleCore,
+				Limit:  10,
+			},
+
+			shouldCallPreviewRoleRuleDAO: true,
+			previewRoleRuleDAORequest: &dao.PreviewRoleRuleRequest{
+				Rule:  entities.RoleRule{Domain: "staff.example.com", Role: entities.RoleCore},
+				Limit: 10,
+			},
+			previewRoleRuleDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrPreviewRoleRule,
+		},
+		{
+			name: "MissingRule",
+
+			request: &services.PreviewRoleRuleRequest{
+				Role:  entities.RoleCore,
+				Limit: 10,
+			},
+
+			expectErr: services.ErrInvalidPreviewRoleRuleRequest,
+		},
+		{
+			name: "DomainAndPattern",
+
+			request: &services.PreviewRoleRuleRequest{
+				Domain:  "staff.example.com",
+				Pattern: `@staff\.example\.com$`,
+				Role:    entities.RoleCore,
+				Limit:   10,
+			},
+
+			expectErr: services.ErrInvalidPreviewRoleRuleRequest,
+		},
+		{
+			name: "InvalidPattern",
+
+			request: &services.PreviewRoleRuleRequest{
+				Pattern: `@staff\.(example`,
+				Role:    entities.RoleCore,
+				Limit:   10,
+			},
+
+			expectErr: services.ErrInvalidPreviewRoleRuleRequest,
+		},
+		{
+			name: "InvalidRole",
+
+			request: &services.PreviewRoleRuleRequest{
+				Domain: "staff.example.com",
+				Role:   "super-admin",
+				Limit:  10,
+			},
+
+			expectErr: services.ErrInvalidPreviewRoleRuleRequest,
+		},
+		{
+			name: "MissingLimit",
+
+			request: &services.PreviewRoleRuleRequest{
+				Domain: "staff.example.com",
+				Role:   entities.RoleCore,
+			},
+
+			expectErr: services.ErrInvalidPreviewRoleRuleRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			previewRoleRuleDAO := daomocks.NewMockPreviewRoleRule(t)
+
+			if testCase.shouldCallPreviewRoleRuleDAO {
+				previewRoleRuleDAO.
+					On("Exec", context.Background(), testCase.previewRoleRuleDAORequest).
+					Return(testCase.previewRoleRuleDAOResponse, testCase.previewRoleRuleDAOError)
+			}
+
+			service := services.NewPreviewRoleRule(previewRoleRuleDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			previewRoleRuleDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var ErrPromoteWaitlistedCredentials = errors.New("promote waitlisted credentials")
+
+type PromoteWaitlistedCredentialsResponsePromotion struct {
+	TenantID     string
+	CredentialID string
+	FromRole     entities.Role
+	ToRole       entities.Role
+	ChangedAt    time.Time
+}
+
+type PromoteWaitlistedCredentialsResponse struct {
+	Promotions []*PromoteWaitlistedCredentialsResponsePromotion
+}
+
+type PromoteWaitlistedCredentials interface {
+	Exec(ctx context.Context) (*PromoteWaitlistedCredentialsResponse, error)
+}
+
+type promoteWaitlistedCredentialsImpl struct {
+	dao dao.PromoteWaitlistedCredentials
+}
+
+func (service *promoteWaitlistedCredentialsImpl) Exec(
+	ctx context.Context,
+) (*PromoteWaitlistedCredentialsResponse, error) {
+	changes, err := service.dao.Exec(ctx, time.Now())
+	if err != nil {
+		return nil, errors.Join(ErrPromoteWaitlistedCredentials, err)
+	}
+
+	return &PromoteWaitlistedCredentialsResponse{
+		Promotions: lo.Map(
+			changes,
+			func(item *entities.CredentialRoleChange, _ int) *PromoteWaitlistedCredentialsResponsePromotion {
+				return &PromoteWaitlistedCredentialsResponsePromotion{
+					TenantID:     item.TenantID,
+					CredentialID: item.CredentialID.String(),
+					FromRole:     item.FromRole,
+					ToRole:       item.ToRole,
+					ChangedAt:    item.ChangedAt,
+				}
+			},
+		),
+	}, nil
+}
+
+func NewPromoteWaitlistedCredentials(dao dao.PromoteWaitlistedCredentials) PromoteWaitlistedCredentials {
+	return &promoteWaitlistedCredentialsImpl{dao: dao}
+}
@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestPromoteWaitlistedCredentials(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		promoteWaitlistedCredentialsDAOResponse []*entities.CredentialRoleChange
+		promoteWaitlistedCredentialsDAOError    error
+
+		expect    *services.PromoteWaitlistedCredentialsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			promoteWaitlistedCredentialsDAOResponse: []*entities.CredentialRoleChange{
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					FromRole:     entities.RoleNone,
+					ToRole:       entities.RoleEarlyAccessProgram,
+					Reason:       entities.RoleChangeReasonWaitlistPromoted,
+					ChangedAt:    time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			expect: &services.PromoteWaitlistedCredentialsResponse{
+				Promotions: []*services.PromoteWaitlistedCredentialsResponsePromotion{
+					{
+						TenantID:     "default",
+						CredentialID: "00000000-0000-0000-0000-000000000001",
+						FromRole:     entities.RoleNone,
+						ToRole:       entities.RoleEarlyAccessProgram,
+						ChangedAt:    time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "OK/Nothing",
+
+			expect: &services.PromoteWaitlistedCredentialsResponse{
+				Promotions: []*services.PromoteWaitlistedCredentialsResponsePromotion{},
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			promoteWaitlistedCredentialsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrPromoteWaitlistedCredentials,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			promoteWaitlistedCredentialsDAO := daomocks.NewMockPromoteWaitlistedCredentials(t)
+
+			promoteWaitlistedCredentialsDAO.
+				On(
+					"Exec",
+					context.Background(),
+					mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+				).
+				Return(testCase.promoteWaitlistedCredentialsDAOResponse, testCase.promoteWaitlistedCredentialsDAOError)
+
+			service := services.NewPromoteWaitlistedCredentials(promoteWaitlistedCredentialsDAO)
+			response, err := service.Exec(context.Background())
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			promoteWaitlistedCredentialsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidProposeRoleChangeRequest = errors.New("invalid propose role change request")
+	ErrProposeRoleChange               = errors.New("propose role change")
+)
+
+var proposeRoleChangeValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterRole(proposeRoleChangeValidate)
+}
+
+type ProposeRoleChangeRequest struct {
+	CredentialID string        `validate:"required,len=36"`
+	Role         entities.Role `validate:"role"`
+	// ProposedBy is the user credential proposing the change. Another user must approve it.
+	ProposedBy string `validate:"required,len=36"`
+}
+
+type ProposeRoleChangeResponse struct {
+	ID           string
+	CredentialID string
+	Role         entities.Role
+	Status       entities.RoleChangeRequestStatus
+
+	ProposedBy string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+}
+
+type ProposeRoleChange interface {
+	Exec(ctx context.Context, data *ProposeRoleChangeRequest) (*ProposeRoleChangeResponse, error)
+}
+
+type proposeRoleChangeImpl struct {
+	dao dao.ProposeRoleChange
+	ttl time.Duration
+}
+
+func (service *proposeRoleChangeImpl) Exec(
+	ctx context.Context, data *ProposeRoleChangeRequest,
+) (*ProposeRoleChangeResponse, error) {
+	if err := proposeRoleChangeValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidProposeRoleChangeRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidProposeRoleChangeRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	proposedBy, err := uuid.Parse(data.ProposedBy)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidProposeRoleChangeRequest, fmt.Errorf("uuid value: '%s': %w", data.ProposedBy, err),
+		)
+	}
+
+	now := time.Now()
+
+	request, err := service.dao.Exec(ctx, uuid.New(), now, &dao.ProposeRoleChangeRequest{
+		CredentialID: credentialID,
+		Role:         data.Role,
+		ProposedBy:   proposedBy,
+		ExpiresAt:    now.Add(service.ttl),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrProposeRoleChange, err)
+	}
+
+	return &ProposeRoleChangeResponse{
+		ID:           request.ID.String(),
+		CredentialID: request.CredentialID.String(),
+		Role:         request.Role,
+		Status:       request.Status,
+
+		ProposedBy: request.ProposedBy.String(),
+		ExpiresAt:  request.ExpiresAt,
+		CreatedAt:  request.CreatedAt,
+	}, nil
+}
+
+// NewProposeRoleChange creates a service that opens role change requests, that expire once the ttl is over.
+func NewProposeRoleChange(dao dao.ProposeRoleChange, ttl time.Duration) ProposeRoleChange {
+	return &proposeRoleChangeImpl{dao: dao, ttl: ttl}
+}
@@ -0,0 +1,170 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestProposeRoleChange(t *testing.T) {
+	ttl := 72 * time.Hour
+
+	testCases := []struct {
+		name string
+
+		request *services.ProposeRoleChangeRequest
+
+		shouldCallProposeRoleChangeDAO bool
+		proposeRoleChangeDAORequest    *dao.ProposeRoleChangeRequest
+		proposeRoleChangeDAOResponse   *entities.RoleChangeRequest
+		proposeRoleChangeDAOError      error
+
+		expect    *services.ProposeRoleChangeResponse
+		expectErr error
+	}{
+		{
+			name: "Propose",
+
+			request: &services.ProposeRoleChangeRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Role:         entities.RoleAdmin,
+				ProposedBy:   "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallProposeRoleChangeDAO: true,
+			proposeRoleChangeDAORequest: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleAdmin,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+			proposeRoleChangeDAOResponse: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusPending,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				ExpiresAt:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.ProposeRoleChangeResponse{
+				ID:           "00000000-0000-0000-0007-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusPending,
+				ProposedBy:   "00000000-0000-0000-0000-000000000001",
+				ExpiresAt:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.ProposeRoleChangeRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Role:         entities.RoleAdmin,
+				ProposedBy:   "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallProposeRoleChangeDAO: true,
+			proposeRoleChangeDAORequest: &dao.ProposeRoleChangeRequest{
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleAdmin,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+			proposeRoleChangeDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrProposeRoleChange,
+		},
+		{
+			name: "InvalidRole",
+
+			request: &services.ProposeRoleChangeRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Role:         "fake-role",
+				ProposedBy:   "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidProposeRoleChangeRequest,
+		},
+		{
+			name: "MissingProposedBy",
+
+			request: &services.ProposeRoleChangeRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Role:         entities.RoleAdmin,
+			},
+
+			expectErr: services.ErrInvalidProposeRoleChangeRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.ProposeRoleChangeRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000002",
+				Role:         entities.RoleAdmin,
+				ProposedBy:   "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidProposeRoleChangeRequest,
+		},
+		{
+			name: "InvalidProposedBy",
+
+			request: &services.ProposeRoleChangeRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Role:         entities.RoleAdmin,
+				ProposedBy:   "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidProposeRoleChangeRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			proposeRoleChangeDAO := daomocks.NewMockProposeRoleChange(t)
+
+			if testCase.shouldCallProposeRoleChangeDAO {
+				var proposedAt time.Time
+
+				proposeRoleChangeDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.Anything,
+						mock.MatchedBy(func(at time.Time) bool {
+							proposedAt = at
+							return at.Unix() > 0
+						}),
+						mock.MatchedBy(func(request *dao.ProposeRoleChangeRequest) bool {
+							return request.CredentialID == testCase.proposeRoleChangeDAORequest.CredentialID &&
+								request.Role == testCase.proposeRoleChangeDAORequest.Role &&
+								request.ProposedBy == testCase.proposeRoleChangeDAORequest.ProposedBy &&
+								request.ExpiresAt.Equal(proposedAt.Add(ttl))
+						}),
+					).
+					Return(testCase.proposeRoleChangeDAOResponse, testCase.proposeRoleChangeDAOError)
+			}
+
+			service := services.NewProposeRoleChange(proposeRoleChangeDAO, ttl)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			proposeRoleChangeDAO.AssertExpectations(t)
+		})
+	}
+}
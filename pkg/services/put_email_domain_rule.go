@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidPutEmailDomainRuleRequest = errors.New("invalid put email domain rule request")
+	ErrPutEmailDomainRule               = errors.New("put email domain rule")
+)
+
+var putEmailDomainRuleValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterEmailDomainAction(putEmailDomainRuleValidate)
+	entities.RegisterEmailDomain(putEmailDomainRuleValidate)
+}
+
+type PutEmailDomainRuleRequest struct {
+	// Domain is case-insensitive, and may be internationalized. The rule also applies to its subdomains.
+	Domain string                     `validate:"required,email_domain,max=253"`
+	Action entities.EmailDomainAction `validate:"required,email_domain_action"`
+	Reason string                     `validate:"omitempty,max=512"`
+	// SetBy identifies the administrator who sets the rule.
+	SetBy string `validate:"required,max=256"`
+}
+
+type PutEmailDomainRuleResponse struct {
+	ID     string
+	Domain string
+	Action entities.EmailDomainAction
+	Reason string
+
+	SetBy     string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type PutEmailDomainRule interface {
+	Exec(ctx context.Context, data *PutEmailDomainRuleRequest) (*PutEmailDomainRuleResponse, error)
+}
+
+type putEmailDomainRuleImpl struct {
+	dao dao.PutEmailDomainRule
+}
+
+func (service *putEmailDomainRuleImpl) Exec(
+	ctx context.Context, data *PutEmailDomainRuleRequest,
+) (*PutEmailDomainRuleResponse, error) {
+	if err := putEmailDomainRuleValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidPutEmailDomainRuleRequest, err)
+	}
+
+	rule, err := service.dao.Exec(ctx, time.Now(), &dao.PutEmailDomainRuleRequest{
+		ID:     uuid.New(),
+		Domain: entities.NormalizeEmailDomain(data.Domain),
+		Action: data.Action,
+		Reason: data.Reason,
+		SetBy:  data.SetBy,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrPutEmailDomainRule, err)
+	}
+
+	return &PutEmailDomainRuleResponse{
+		ID:     rule.ID.String(),
+		Domain: rule.Domain,
+		Action: rule.Action,
+		Reason: rule.Reason,
+
+		SetBy:     rule.SetBy,
+		CreatedAt: rule.CreatedAt,
+		UpdatedAt: rule.UpdatedAt,
+	}, nil
+}
+
+func NewPutEmailDomainRule(dao dao.PutEmailDomainRule) PutEmailDomainRule {
+	return &putEmailDomainRuleImpl{dao: dao}
+}
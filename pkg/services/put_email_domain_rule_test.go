@@ -0,0 +1,216 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestPutEmailDomainRule(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.PutEmailDomainRuleRequest
+
+		shouldCallPutEmailDomainRuleDAO bool
+		putEmailDomainRuleDAORequest    *dao.PutEmailDomainRuleRequest
+		putEmailDomainRuleDAOResponse   *entities.EmailDomainRule
+		putEmailDomainRuleDAOError      error
+
+		expect    *services.PutEmailDomainRuleResponse
+		expectErr error
+	}{
+		{
+			name: "Deny",
+
+			request: &services.PutEmailDomainRuleRequest{
+				Domain: "Spam.Example.com",
+				Action: entities.EmailDomainActionDeny,
+				Reason: "abuse",
+				SetBy:  "admin@example.com",
+			},
+
+			shouldCallPutEmailDomainRuleDAO: true,
+			putEmailDomainRuleDAORequest: &dao.PutEmailDomainRuleRequest{
+				Domain: "spam.example.com",
+				Action: entities.EmailDomainActionDeny,
+				Reason: "abuse",
+				SetBy:  "admin@example.com",
+			},
+			putEmailDomainRuleDAOResponse: &entities.EmailDomainRule{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Domain:    "spam.example.com",
+				Action:    entities.EmailDomainActionDeny,
+				Reason:    "abuse",
+				SetBy:     "admin@example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.PutEmailDomainRuleResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Domain:    "spam.example.com",
+				Action:    entities.EmailDomainActionDeny,
+				Reason:    "abuse",
+				SetBy:     "admin@example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Allow",
+
+			request: &services.PutEmailDomainRuleRequest{
+				Domain: "example.com",
+				Action: entities.EmailDomainActionAllow,
+				SetBy:  "admin@example.com",
+			},
+
+			shouldCallPutEmailDomainRuleDAO: true,
+			putEmailDomainRuleDAORequest: &dao.PutEmailDomainRuleRequest{
+				Domain: "example.com",
+				Action: entities.EmailDomainActionAllow,
+				SetBy:  "admin@example.com",
+			},
+			putEmailDomainRuleDAOResponse: &entities.EmailDomainRule{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Domain:    "example.com",
+				Action:    entities.EmailDomainActionAllow,
+				SetBy:     "admin@example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.PutEmailDomainRuleResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Domain:    "example.com",
+				Action:    entities.EmailDomainActionAllow,
+				SetBy:     "admin@example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "IDN",
+
+			request: &services.PutEmailDomainRuleRequest{
+				Domain: "Bücher.de",
+				Action: entities.EmailDomainActionAllow,
+				SetBy:  "admin@example.com",
+			},
+
+			shouldCallPutEmailDomainRuleDAO: true,
+			putEmailDomainRuleDAORequest: &dao.PutEmailDomainRuleRequest{
+				Domain: "xn--bcher-kva.de",
+				Action: entities.EmailDomainActionAllow,
+				SetBy:  "admin@example.com",
+			},
+			putEmailDomainRuleDAOResponse: &entities.EmailDomainRule{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Domain:    "xn--bcher-kva.de",
+				Action:    entities.EmailDomainActionAllow,
+				SetBy:     "admin@example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.PutEmailDomainRuleResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Domain:    "xn--bcher-kva.de",
+				Action:    entities.EmailDomainActionAllow,
+				SetBy:     "admin@example.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.PutEmailDomainRuleRequest{
+				Domain: "example.com",
+				Action: entities.EmailDomainActionAllow,
+				SetBy:  "admin@example.com",
+			},
+
+			shouldCallPutEmailDomainRuleDAO: true,
+			putEmailDomainRuleDAORequest: &dao.PutEmailDomainRuleRequest{
+				Domain: "example.com",
+				Action: entities.EmailDomainActionAllow,
+				SetBy:  "admin@example.com",
+			},
+			putEmailDomainRuleDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrPutEmailDomainRule,
+		},
+		{
+			name: "InvalidDomain",
+
+			request: &services.PutEmailDomainRuleRequest{
+				Domain: "not a domain",
+				Action: entities.EmailDomainActionAllow,
+				SetBy:  "admin@example.com",
+			},
+
+			expectErr: services.ErrInvalidPutEmailDomainRuleRequest,
+		},
+		{
+			name: "InvalidAction",
+
+			request: &services.PutEmailDomainRuleRequest{
+				Domain: "example.com",
+				Action: "block",
+				SetBy:  "admin@example.com",
+			},
+
+			expectErr: services.ErrInvalidPutEmailDomainRuleRequest,
+		},
+		{
+			name: "MissingSetBy",
+
+			request: &services.PutEmailDomainRuleRequest{
+				Domain: "example.com",
+				Action: entities.EmailDomainActionAllow,
+			},
+
+			expectErr: services.ErrInvalidPutEmailDomainRuleRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			putEmailDomainRuleDAO := daomocks.NewMockPutEmailDomainRule(t)
+
+			if testCase.shouldCallPutEmailDomainRuleDAO {
+				putEmailDomainRuleDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						mock.MatchedBy(func(request *dao.PutEmailDomainRuleRequest) bool {
+							return request.ID != uuid.Nil &&
+								request.Domain == testCase.putEmailDomainRuleDAORequest.Domain &&
+								request.Action == testCase.putEmailDomainRuleDAORequest.Action &&
+								request.Reason == testCase.putEmailDomainRuleDAORequest.Reason &&
+								request.SetBy == testCase.putEmailDomainRuleDAORequest.SetBy
+						}),
+					).
+					Return(testCase.putEmailDomainRuleDAOResponse, testCase.putEmailDomainRuleDAOError)
+			}
+
+			service := services.NewPutEmailDomainRule(putEmailDomainRuleDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			putEmailDomainRuleDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidRecordConsentRequest = errors.New("invalid record consent request")
+	ErrRecordConsent               = errors.New("record consent")
+)
+
+var recordConsentValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterConsentDocument(recordConsentValidate)
+}
+
+type RecordConsentRequest struct {
+	CredentialID string                   `validate:"required,len=36"`
+	Document     entities.ConsentDocument `validate:"required,consent_document"`
+	Version      string                   `validate:"required,max=64"`
+}
+
+type RecordConsentResponse struct {
+	ID           string
+	CredentialID string
+	Document     entities.ConsentDocument
+	Version      string
+	AcceptedAt   time.Time
+}
+
+type RecordConsent interface {
+	Exec(ctx context.Context, data *RecordConsentRequest) (*RecordConsentResponse, error)
+}
+
+type recordConsentImpl struct {
+	dao dao.RecordConsent
+}
+
+func (service *recordConsentImpl) Exec(
+	ctx context.Context, data *RecordConsentRequest,
+) (*RecordConsentResponse, error) {
+	if err := recordConsentValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRecordConsentRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidRecordConsentRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	consent, err := service.dao.Exec(ctx, credentialID, time.Now(), &dao.RecordConsentRequest{
+		ID:       uuid.New(),
+		Document: data.Document,
+		Version:  data.Version,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrRecordConsent, err)
+	}
+
+	return &RecordConsentResponse{
+		ID:           consent.ID.String(),
+		CredentialID: consent.CredentialID.String(),
+		Document:     consent.Document,
+		Version:      consent.Version,
+		AcceptedAt:   consent.AcceptedAt,
+	}, nil
+}
+
+func NewRecordConsent(dao dao.RecordConsent) RecordConsent {
+	return &recordConsentImpl{dao: dao}
+}
@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRecordConsent(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RecordConsentRequest
+
+		shouldCallRecordConsentDAO bool
+		recordConsentDAOResponse   *entities.CredentialConsent
+		recordConsentDAOError      error
+
+		expect    *services.RecordConsentResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RecordConsentRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Document:     entities.ConsentDocumentTOS,
+				Version:      "v2",
+			},
+
+			shouldCallRecordConsentDAO: true,
+			recordConsentDAOResponse: &entities.CredentialConsent{
+				ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Document:     entities.ConsentDocumentTOS,
+				Version:      "v2",
+				AcceptedAt:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.RecordConsentResponse{
+				ID:           "00000000-0000-0000-0001-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Document:     entities.ConsentDocumentTOS,
+				Version:      "v2",
+				AcceptedAt:   time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.RecordConsentRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Document:     entities.ConsentDocumentTOS,
+				Version:      "v2",
+			},
+
+			shouldCallRecordConsentDAO: true,
+			recordConsentDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrRecordConsent,
+		},
+		{
+			name: "UnknownDocument",
+
+			request: &services.RecordConsentRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Document:     "cookies",
+				Version:      "v2",
+			},
+
+			expectErr: services.ErrInvalidRecordConsentRequest,
+		},
+		{
+			name: "MissingVersion",
+
+			request: &services.RecordConsentRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Document:     entities.ConsentDocumentTOS,
+			},
+
+			expectErr: services.ErrInvalidRecordConsentRequest,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.RecordConsentRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Document:     entities.ConsentDocumentTOS,
+				Version:      "v2",
+			},
+
+			expectErr: services.ErrInvalidRecordConsentRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recordConsentDAO := daomocks.NewMockRecordConsent(t)
+
+			if testCase.shouldCallRecordConsentDAO {
+				recordConsentDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.CredentialID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						mock.MatchedBy(func(request *dao.RecordConsentRequest) bool {
+							return request.ID != uuid.Nil &&
+								request.Document == testCase.request.Document &&
+								request.Version == testCase.request.Version
+						}),
+					).
+					Return(testCase.recordConsentDAOResponse, testCase.recordConsentDAOError)
+			}
+
+			service := services.NewRecordConsent(recordConsentDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			recordConsentDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidRedeemInvitationRequest = errors.New("invalid redeem invitation request")
+	ErrRedeemInvitation               = errors.New("redeem invitation")
+)
+
+var redeemInvitationValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RedeemInvitationRequest struct {
+	// Code is case-insensitive, so it can be typed by hand.
+	Code         string `validate:"required,max=64"`
+	CredentialID string `validate:"required,len=36"`
+}
+
+type RedeemInvitationResponse struct {
+	ID string
+
+	Role          entities.Role
+	RoleExpiresAt *time.Time
+	FallbackRole  *entities.Role
+
+	UpdatedAt *time.Time
+}
+
+type RedeemInvitation interface {
+	Exec(ctx context.Context, data *RedeemInvitationRequest) (*RedeemInvitationResponse, error)
+}
+
+type redeemInvitationImpl struct {
+	dao dao.RedeemInvitation
+}
+
+func (service *redeemInvitationImpl) Exec(
+	ctx context.Context, data *RedeemInvitationRequest,
+) (*RedeemInvitationResponse, error) {
+	if err := redeemInvitationValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRedeemInvitationRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidRedeemInvitationRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	credentials, err := service.dao.Exec(ctx, time.Now(), &dao.RedeemInvitationRequest{
+		Code:         strings.ToUpper(strings.TrimSpace(data.Code)),
+		CredentialID: credentialID,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrRedeemInvitation, err)
+	}
+
+	return &RedeemInvitationResponse{
+		ID: credentials.ID.String(),
+
+		Role:          credentials.Role,
+		RoleExpiresAt: credentials.RoleExpiresAt,
+		FallbackRole:  credentials.FallbackRole,
+
+		UpdatedAt: credentials.UpdatedAt,
+	}, nil
+}
+
+func NewRedeemInvitation(dao dao.RedeemInvitation) RedeemInvitation {
+	return &redeemInvitationImpl{dao: dao}
+}
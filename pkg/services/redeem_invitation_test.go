@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRedeemInvitation(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RedeemInvitationRequest
+
+		shouldCallRedeemInvitationDAO bool
+		redeemInvitationDAORequest    *dao.RedeemInvitationRequest
+		redeemInvitationDAOResponse   *entities.Credential
+		redeemInvitationDAOError      error
+
+		expect    *services.RedeemInvitationResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RedeemInvitationRequest{
+				Code:         " abcd-efgh ",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRedeemInvitationDAO: true,
+			redeemInvitationDAORequest: &dao.RedeemInvitationRequest{
+				Code:         "ABCD-EFGH",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+			redeemInvitationDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:     "user@gmail.com",
+				Role:      entities.RoleEarlyAccessProgram,
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.RedeemInvitationResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				Role:      entities.RoleEarlyAccessProgram,
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.RedeemInvitationRequest{
+				Code:         "ABCD",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRedeemInvitationDAO: true,
+			redeemInvitationDAORequest: &dao.RedeemInvitationRequest{
+				Code:         "ABCD",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+			redeemInvitationDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrRedeemInvitation,
+		},
+		{
+			name: "NoCode",
+
+			request: &services.RedeemInvitationRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidRedeemInvitationRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.RedeemInvitationRequest{
+				Code:         "ABCD",
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidRedeemInvitationRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			redeemInvitationDAO := daomocks.NewMockRedeemInvitation(t)
+
+			if testCase.shouldCallRedeemInvitationDAO {
+				redeemInvitationDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.redeemInvitationDAORequest,
+					).
+					Return(testCase.redeemInvitationDAOResponse, testCase.redeemInvitationDAOError)
+			}
+
+			service := services.NewRedeemInvitation(redeemInvitationDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			redeemInvitationDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidRejectRoleChangeRequest = errors.New("invalid reject role change request")
+	ErrRejectRoleChange               = errors.New("reject role change")
+)
+
+var rejectRoleChangeValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RejectRoleChangeRequest struct {
+	ID string `validate:"required,len=36"`
+	// RejectedBy is the user credential rejecting the change. The proposer may reject their own request, to withdraw it.
+	RejectedBy string `validate:"required,len=36"`
+}
+
+type RejectRoleChangeResponse struct {
+	ID           string
+	CredentialID string
+	Role         entities.Role
+	Status       entities.RoleChangeRequestStatus
+
+	ProposedBy string
+	DecidedBy  string
+	ExpiresAt  time.Time
+	DecidedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type RejectRoleChange interface {
+	Exec(ctx context.Context, data *RejectRoleChangeRequest) (*RejectRoleChangeResponse, error)
+}
+
+type rejectRoleChangeImpl struct {
+	dao dao.RejectRoleChange
+}
+
+func (service *rejectRoleChangeImpl) Exec(
+	ctx context.Context, data *RejectRoleChangeRequest,
+) (*RejectRoleChangeResponse, error) {
+	if err := rejectRoleChangeValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRejectRoleChangeRequest, err)
+	}
+
+	requestID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidRejectRoleChangeRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err),
+		)
+	}
+
+	actorID, err := uuid.Parse(data.RejectedBy)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidRejectRoleChangeRequest, fmt.Errorf("uuid value: '%s': %w", data.RejectedBy, err),
+		)
+	}
+
+	request, err := service.dao.Exec(ctx, requestID, time.Now(), &dao.RejectRoleChangeRequest{
+		RejectedBy: actorID,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrRejectRoleChange, err)
+	}
+
+	return &RejectRoleChangeResponse{
+		ID:           request.ID.String(),
+		CredentialID: request.CredentialID.String(),
+		Role:         request.Role,
+		Status:       request.Status,
+
+		ProposedBy: lo.Ternary(request.ProposedBy == uuid.Nil, "", request.ProposedBy.String()),
+		DecidedBy:  lo.FromPtr(request.DecidedBy).String(),
+		ExpiresAt:  request.ExpiresAt,
+		DecidedAt:  request.DecidedAt,
+		CreatedAt:  request.CreatedAt,
+	}, nil
+}
+
+func NewRejectRoleChange(dao dao.RejectRoleChange) RejectRoleChange {
+	return &rejectRoleChangeImpl{dao: dao}
+}
@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRejectRoleChange(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RejectRoleChangeRequest
+
+		shouldCallRejectRoleChangeDAO bool
+		rejectRoleChangeDAORequest    *dao.RejectRoleChangeRequest
+		rejectRoleChangeDAOResponse   *entities.RoleChangeRequest
+		rejectRoleChangeDAOError      error
+
+		expect    *services.RejectRoleChangeResponse
+		expectErr error
+	}{
+		{
+			name: "Reject",
+
+			request: &services.RejectRoleChangeRequest{
+				ID:         "00000000-0000-0000-0007-000000000001",
+				RejectedBy: "00000000-0000-0000-0000-000000000003",
+			},
+
+			shouldCallRejectRoleChangeDAO: true,
+			rejectRoleChangeDAORequest: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+			rejectRoleChangeDAOResponse: &entities.RoleChangeRequest{
+				ID:           uuid.MustParse("00000000-0000-0000-0007-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000002"),
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusRejected,
+				ProposedBy:   uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				DecidedBy:    lo.ToPtr(uuid.MustParse("00000000-0000-0000-0000-000000000003")),
+				ExpiresAt:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+				DecidedAt:    lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.RejectRoleChangeResponse{
+				ID:           "00000000-0000-0000-0007-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000002",
+				Role:         entities.RoleAdmin,
+				Status:       entities.RoleChangeRequestStatusRejected,
+				ProposedBy:   "00000000-0000-0000-0000-000000000001",
+				DecidedBy:    "00000000-0000-0000-0000-000000000003",
+				ExpiresAt:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+				DecidedAt:    lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.RejectRoleChangeRequest{
+				ID:         "00000000-0000-0000-0007-000000000001",
+				RejectedBy: "00000000-0000-0000-0000-000000000003",
+			},
+
+			shouldCallRejectRoleChangeDAO: true,
+			rejectRoleChangeDAORequest: &dao.RejectRoleChangeRequest{
+				RejectedBy: uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			},
+			rejectRoleChangeDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrRejectRoleChange,
+		},
+		{
+			name: "MissingRejectedBy",
+
+			request: &services.RejectRoleChangeRequest{
+				ID: "00000000-0000-0000-0007-000000000001",
+			},
+
+			expectErr: services.ErrInvalidRejectRoleChangeRequest,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.RejectRoleChangeRequest{
+				ID:         "00000000x0000x0000x0007x000000000001",
+				RejectedBy: "00000000-0000-0000-0000-000000000003",
+			},
+
+			expectErr: services.ErrInvalidRejectRoleChangeRequest,
+		},
+		{
+			name: "InvalidRejectedBy",
+
+			request: &services.RejectRoleChangeRequest{
+				ID:         "00000000-0000-0000-0007-000000000001",
+				RejectedBy: "00000000x0000x0000x0000x000000000003",
+			},
+
+			expectErr: services.ErrInvalidRejectRoleChangeRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rejectRoleChangeDAO := daomocks.NewMockRejectRoleChange(t)
+
+			if testCase.shouldCallRejectRoleChangeDAO {
+				rejectRoleChangeDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.rejectRoleChangeDAORequest,
+					).
+					Return(testCase.rejectRoleChangeDAOResponse, testCase.rejectRoleChangeDAOError)
+			}
+
+			service := services.NewRejectRoleChange(rejectRoleChangeDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			rejectRoleChangeDAO.AssertExpectations(t)
+		})
+	}
+}
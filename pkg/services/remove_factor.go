@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidRemoveFactorRequest = errors.New("invalid remove factor request")
+	ErrRemoveFactor               = errors.New("remove factor")
+)
+
+var removeFactorValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RemoveFactorRequest struct {
+	ID           string `validate:"required,len=36"`
+	CredentialID string `validate:"required,len=36"`
+}
+
+type RemoveFactor interface {
+	Exec(ctx context.Context, data *RemoveFactorRequest) error
+}
+
+type removeFactorImpl struct {
+	dao dao.RemoveFactor
+}
+
+func (service *removeFactorImpl) Exec(ctx context.Context, data *RemoveFactorRequest) error {
+	if err := removeFactorValidate.Struct(data); err != nil {
+		return errors.Join(ErrInvalidRemoveFactorRequest, err)
+	}
+
+	factorID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return errors.Join(ErrInvalidRemoveFactorRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return errors.Join(
+			ErrInvalidRemoveFactorRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	if err = service.dao.Exec(ctx, factorID, &dao.RemoveFactorRequest{CredentialID: credentialID}); err != nil {
+		return errors.Join(ErrRemoveFactor, err)
+	}
+
+	return nil
+}
+
+func NewRemoveFactor(dao dao.RemoveFactor) RemoveFactor {
+	return &removeFactorImpl{dao: dao}
+}
@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRemoveFactor(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RemoveFactorRequest
+
+		shouldCallRemoveFactorDAO bool
+		removeFactorDAOError      error
+
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RemoveFactorRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRemoveFactorDAO: true,
+		},
+		{
+			name: "DAO/LastFactor",
+
+			request: &services.RemoveFactorRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRemoveFactorDAO: true,
+			removeFactorDAOError:      dao.ErrLastFactor,
+
+			expectErr: dao.ErrLastFactor,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.RemoveFactorRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRemoveFactorDAO: true,
+			removeFactorDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrRemoveFactor,
+		},
+		{
+			name: "Invalid/ID",
+
+			request: &services.RemoveFactorRequest{
+				ID:           "10000000x0000x0000x0000x000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidRemoveFactorRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			removeFactorDAO := daomocks.NewMockRemoveFactor(t)
+
+			if testCase.shouldCallRemoveFactorDAO {
+				removeFactorDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						&dao.RemoveFactorRequest{CredentialID: uuid.MustParse(testCase.request.CredentialID)},
+					).
+					Return(testCase.removeFactorDAOError)
+			}
+
+			service := services.NewRemoveFactor(removeFactorDAO)
+			err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			removeFactorDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidRenameFactorRequest = errors.New("invalid rename factor request")
+	ErrRenameFactor               = errors.New("rename factor")
+)
+
+var renameFactorValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RenameFactorRequest struct {
+	ID           string `validate:"required,len=36"`
+	CredentialID string `validate:"required,len=36"`
+	Label        string `validate:"required,max=64"`
+}
+
+type RenameFactorResponse struct {
+	ID           string
+	CredentialID string
+	Type         entities.FactorType
+	Label        string
+
+	SecretRef string
+
+	WebAuthnCredentialID string
+	WebAuthnPublicKey    []byte
+
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+type RenameFactor interface {
+	Exec(ctx context.Context, data *RenameFactorRequest) (*RenameFactorResponse, error)
+}
+
+type renameFactorImpl struct {
+	dao dao.RenameFactor
+}
+
+func (service *renameFactorImpl) Exec(ctx context.Context, data *RenameFactorRequest) (*RenameFactorResponse, error) {
+	if err := renameFactorValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRenameFactorRequest, err)
+	}
+
+	factorID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidRenameFactorRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidRenameFactorRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	factor, err := service.dao.Exec(ctx, factorID, &dao.RenameFactorRequest{
+		CredentialID: credentialID,
+		Label:        data.Label,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrRenameFactor, err)
+	}
+
+	return &RenameFactorResponse{
+		ID:           factor.ID.String(),
+		CredentialID: factor.CredentialID.String(),
+		Type:         factor.Type,
+		Label:        factor.Label,
+
+		SecretRef: factor.SecretRef,
+
+		WebAuthnCredentialID: factor.WebAuthnCredentialID,
+		WebAuthnPublicKey:    factor.WebAuthnPublicKey,
+
+		CreatedAt:  factor.CreatedAt,
+		LastUsedAt: factor.LastUsedAt,
+	}, nil
+}
+
+func NewRenameFactor(dao dao.RenameFactor) RenameFactor {
+	return &renameFactorImpl{dao: dao}
+}
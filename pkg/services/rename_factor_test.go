@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRenameFactor(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RenameFactorRequest
+
+		shouldCallRenameFactorDAO bool
+		renameFactorDAOResponse   *entities.CredentialFactor
+		renameFactorDAOError      error
+
+		expect    *services.RenameFactorResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RenameFactorRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Label:        "old phone",
+			},
+
+			shouldCallRenameFactorDAO: true,
+			renameFactorDAOResponse: &entities.CredentialFactor{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Type:         entities.FactorTypeTOTP,
+				Label:        "old phone",
+				SecretRef:    "totp-secret-ref",
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.RenameFactorResponse{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Type:         entities.FactorTypeTOTP,
+				Label:        "old phone",
+				SecretRef:    "totp-secret-ref",
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAO/NotFound",
+
+			request: &services.RenameFactorRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Label:        "old phone",
+			},
+
+			shouldCallRenameFactorDAO: true,
+			renameFactorDAOError:      dao.ErrFactorNotFound,
+
+			expectErr: dao.ErrFactorNotFound,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.RenameFactorRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Label:        "old phone",
+			},
+
+			shouldCallRenameFactorDAO: true,
+			renameFactorDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrRenameFactor,
+		},
+		{
+			name: "Invalid/NoLabel",
+
+			request: &services.RenameFactorRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidRenameFactorRequest,
+		},
+		{
+			name: "Invalid/ID",
+
+			request: &services.RenameFactorRequest{
+				ID:           "10000000x0000x0000x0000x000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Label:        "old phone",
+			},
+
+			expectErr: services.ErrInvalidRenameFactorRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			renameFactorDAO := daomocks.NewMockRenameFactor(t)
+
+			if testCase.shouldCallRenameFactorDAO {
+				renameFactorDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						&dao.RenameFactorRequest{
+							CredentialID: uuid.MustParse(testCase.request.CredentialID),
+							Label:        testCase.request.Label,
+						},
+					).
+					Return(testCase.renameFactorDAOResponse, testCase.renameFactorDAOError)
+			}
+
+			service := services.NewRenameFactor(renameFactorDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			renameFactorDAO.AssertExpectations(t)
+		})
+	}
+}
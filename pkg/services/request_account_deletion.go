@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidRequestAccountDeletionRequest = errors.New("invalid request account deletion request")
+	ErrRequestAccountDeletion               = errors.New("request account deletion")
+)
+
+var requestAccountDeletionValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RequestAccountDeletionRequest struct {
+	ID string `validate:"required,len=36"`
+}
+
+type RequestAccountDeletionResponse struct {
+	ID string
+	// DeletionScheduledAt is the end of the grace period. The deletion can be canceled until then.
+	DeletionScheduledAt *time.Time
+	UpdatedAt           *time.Time
+}
+
+type RequestAccountDeletion interface {
+	Exec(ctx context.Context, data *RequestAccountDeletionRequest) (*RequestAccountDeletionResponse, error)
+}
+
+type requestAccountDeletionImpl struct {
+	dao         dao.RequestAccountDeletion
+	gracePeriod time.Duration
+}
+
+func (service *requestAccountDeletionImpl) Exec(
+	ctx context.Context, data *RequestAccountDeletionRequest,
+) (*RequestAccountDeletionResponse, error) {
+	if err := requestAccountDeletionValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRequestAccountDeletionRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidRequestAccountDeletionRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err),
+		)
+	}
+
+	now := time.Now()
+
+	credentials, err := service.dao.Exec(ctx, credentialsID, now, &dao.RequestAccountDeletionRequest{
+		ScheduledAt: now.Add(service.gracePeriod),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrRequestAccountDeletion, err)
+	}
+
+	return &RequestAccountDeletionResponse{
+		ID:                  credentials.ID.String(),
+		DeletionScheduledAt: credentials.DeletionScheduledAt,
+		UpdatedAt:           credentials.UpdatedAt,
+	}, nil
+}
+
+// NewRequestAccountDeletion creates a service that schedules the deletion of credentials once the grace period is
+// over.
+func NewRequestAccountDeletion(dao dao.RequestAccountDeletion, gracePeriod time.Duration) RequestAccountDeletion {
+	return &requestAccountDeletionImpl{dao: dao, gracePeriod: gracePeriod}
+}
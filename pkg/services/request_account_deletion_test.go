@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRequestAccountDeletion(t *testing.T) {
+	gracePeriod := 30 * 24 * time.Hour
+
+	testCases := []struct {
+		name string
+
+		request *services.RequestAccountDeletionRequest
+
+		shouldCallRequestAccountDeletionDAO bool
+		requestAccountDeletionDAOResponse   *entities.Credential
+		requestAccountDeletionDAOError      error
+
+		expect    *services.RequestAccountDeletionResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RequestAccountDeletionRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRequestAccountDeletionDAO: true,
+			requestAccountDeletionDAOResponse: &entities.Credential{
+				ID:                  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:               "user@gmail.com",
+				DeletionScheduledAt: lo.ToPtr(time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:           time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:           lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.RequestAccountDeletionResponse{
+				ID:                  "00000000-0000-0000-0000-000000000001",
+				DeletionScheduledAt: lo.ToPtr(time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC)),
+				UpdatedAt:           lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.RequestAccountDeletionRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRequestAccountDeletionDAO: true,
+			requestAccountDeletionDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrRequestAccountDeletion,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.RequestAccountDeletionRequest{
+				ID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidRequestAccountDeletionRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requestAccountDeletionDAO := daomocks.NewMockRequestAccountDeletion(t)
+
+			if testCase.shouldCallRequestAccountDeletionDAO {
+				var requestedAt time.Time
+
+				requestAccountDeletionDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool {
+							requestedAt = at
+							return at.Unix() > 0
+						}),
+						mock.MatchedBy(func(request *dao.RequestAccountDeletionRequest) bool {
+							return request.ScheduledAt.Equal(requestedAt.Add(gracePeriod))
+						}),
+					).
+					Return(testCase.requestAccountDeletionDAOResponse, testCase.requestAccountDeletionDAOError)
+			}
+
+			service := services.NewRequestAccountDeletion(requestAccountDeletionDAO, gracePeriod)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			requestAccountDeletionDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var ErrRevertExpiredRoleGrants = errors.New("revert expired role grants")
+
+type RevertExpiredRoleGrantsResponseChange struct {
+	TenantID     string
+	CredentialID string
+	FromRole     entities.Role
+	ToRole       entities.Role
+	ChangedAt    time.Time
+}
+
+type RevertExpiredRoleGrantsResponse struct {
+	Changes []*RevertExpiredRoleGrantsResponseChange
+}
+
+type RevertExpiredRoleGrants interface {
+	Exec(ctx context.Context) (*RevertExpiredRoleGrantsResponse, error)
+}
+
+type revertExpiredRoleGrantsImpl struct {
+	dao dao.RevertExpiredRoleGrants
+}
+
+func (service *revertExpiredRoleGrantsImpl) Exec(ctx context.Context) (*RevertExpiredRoleGrantsResponse, error) {
+	changes, err := service.dao.Exec(ctx, time.Now())
+	if err != nil {
+		return nil, errors.Join(ErrRevertExpiredRoleGrants, err)
+	}
+
+	return &RevertExpiredRoleGrantsResponse{
+		Changes: lo.Map(
+			changes,
+			func(item *entities.CredentialRoleChange, _ int) *RevertExpiredRoleGrantsResponseChange {
+				return &RevertExpiredRoleGrantsResponseChange{
+					TenantID:     item.TenantID,
+					CredentialID: item.CredentialID.String(),
+					FromRole:     item.FromRole,
+					ToRole:       item.ToRole,
+					ChangedAt:    item.ChangedAt,
+				}
+			},
+		),
+	}, nil
+}
+
+func NewRevertExpiredRoleGrants(dao dao.RevertExpiredRoleGrants) RevertExpiredRoleGrants {
+	return &revertExpiredRoleGrantsImpl{dao: dao}
+}
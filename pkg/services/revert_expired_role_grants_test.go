@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRevertExpiredRoleGrants(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		revertExpiredRoleGrantsDAOResponse []*entities.CredentialRoleChange
+		revertExpiredRoleGrantsDAOError    error
+
+		expect    *services.RevertExpiredRoleGrantsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			revertExpiredRoleGrantsDAOResponse: []*entities.CredentialRoleChange{
+				{
+					ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+					TenantID:     "default",
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					FromRole:     entities.RoleAdmin,
+					ToRole:       entities.RoleEarlyAccessProgram,
+					Reason:       entities.RoleChangeReasonGrantExpired,
+					ChangedAt:    time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+				},
+			},
+
+			expect: &services.RevertExpiredRoleGrantsResponse{
+				Changes: []*services.RevertExpiredRoleGrantsResponseChange{
+					{
+						TenantID:     "default",
+						CredentialID: "00000000-0000-0000-0000-000000000001",
+						FromRole:     entities.RoleAdmin,
+						ToRole:       entities.RoleEarlyAccessProgram,
+						ChangedAt:    time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name: "OK/Nothing",
+
+			expect: &services.RevertExpiredRoleGrantsResponse{
+				Changes: []*services.RevertExpiredRoleGrantsResponseChange{},
+			},
+		},
+		{
+			name: "DAO/Error",
+
+			revertExpiredRoleGrantsDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrRevertExpiredRoleGrants,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			revertExpiredRoleGrantsDAO := daomocks.NewMockRevertExpiredRoleGrants(t)
+
+			revertExpiredRoleGrantsDAO.
+				On(
+					"Exec",
+					context.Background(),
+					mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+				).
+				Return(testCase.revertExpiredRoleGrantsDAOResponse, testCase.revertExpiredRoleGrantsDAOError)
+
+			service := services.NewRevertExpiredRoleGrants(revertExpiredRoleGrantsDAO)
+			response, err := service.Exec(context.Background())
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			revertExpiredRoleGrantsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidRevokeAllSessionsRequest = errors.New("invalid revoke all sessions request")
+	ErrRevokeAllSessions               = errors.New("revoke all sessions")
+)
+
+var revokeAllSessionsValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RevokeAllSessionsRequest struct {
+	ID string `validate:"required,len=36"`
+}
+
+type RevokeAllSessionsResponse struct {
+	ID string
+	// TokenEpoch is the new epoch of the credentials. Sessions and tokens issued with a lower epoch must be rejected.
+	TokenEpoch int64
+	UpdatedAt  *time.Time
+}
+
+type RevokeAllSessions interface {
+	Exec(ctx context.Context, data *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error)
+}
+
+type revokeAllSessionsImpl struct {
+	dao dao.RevokeAllSessions
+}
+
+func (service *revokeAllSessionsImpl) Exec(
+	ctx context.Context, data *RevokeAllSessionsRequest,
+) (*RevokeAllSessionsResponse, error) {
+	if err := revokeAllSessionsValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRevokeAllSessionsRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidRevokeAllSessionsRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentials, err := service.dao.Exec(ctx, credentialsID, time.Now())
+	if err != nil {
+		return nil, errors.Join(ErrRevokeAllSessions, err)
+	}
+
+	return &RevokeAllSessionsResponse{
+		ID:         credentials.ID.String(),
+		TokenEpoch: credentials.TokenEpoch,
+		UpdatedAt:  credentials.UpdatedAt,
+	}, nil
+}
+
+func NewRevokeAllSessions(dao dao.RevokeAllSessions) RevokeAllSessions {
+	return &revokeAllSessionsImpl{dao: dao}
+}
@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRevokeAllSessions(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RevokeAllSessionsRequest
+
+		shouldCallRevokeAllSessionsDAO bool
+		revokeAllSessionsDAOResponse   *entities.Credential
+		revokeAllSessionsDAOError      error
+
+		expect    *services.RevokeAllSessionsResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RevokeAllSessionsRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRevokeAllSessionsDAO: true,
+			revokeAllSessionsDAOResponse: &entities.Credential{
+				ID:         uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				TokenEpoch: 3,
+				Email:      "user@gmail.com",
+				CreatedAt:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.RevokeAllSessionsResponse{
+				ID:         "00000000-0000-0000-0000-000000000001",
+				TokenEpoch: 3,
+				UpdatedAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.RevokeAllSessionsRequest{
+				ID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRevokeAllSessionsDAO: true,
+			revokeAllSessionsDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrRevokeAllSessions,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.RevokeAllSessionsRequest{
+				ID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidRevokeAllSessionsRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			revokeAllSessionsDAO := daomocks.NewMockRevokeAllSessions(t)
+
+			if testCase.shouldCallRevokeAllSessionsDAO {
+				revokeAllSessionsDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+					).
+					Return(testCase.revokeAllSessionsDAOResponse, testCase.revokeAllSessionsDAOError)
+			}
+
+			service := services.NewRevokeAllSessions(revokeAllSessionsDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			revokeAllSessionsDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidRevokeAPIKeyRequest = errors.New("invalid revoke api key request")
+	ErrRevokeAPIKey               = errors.New("revoke api key")
+)
+
+var revokeAPIKeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RevokeAPIKeyRequest struct {
+	ID           string `validate:"required,len=36"`
+	CredentialID string `validate:"required,len=36"`
+}
+
+type RevokeAPIKey interface {
+	Exec(ctx context.Context, data *RevokeAPIKeyRequest) error
+}
+
+type revokeAPIKeyImpl struct {
+	dao dao.RevokeAPIKey
+}
+
+func (service *revokeAPIKeyImpl) Exec(ctx context.Context, data *RevokeAPIKeyRequest) error {
+	if err := revokeAPIKeyValidate.Struct(data); err != nil {
+		return errors.Join(ErrInvalidRevokeAPIKeyRequest, err)
+	}
+
+	keyID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return errors.Join(ErrInvalidRevokeAPIKeyRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return errors.Join(
+			ErrInvalidRevokeAPIKeyRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	err = service.dao.Exec(ctx, keyID, time.Now(), &dao.RevokeAPIKeyRequest{CredentialID: credentialID})
+	if err != nil {
+		return errors.Join(ErrRevokeAPIKey, err)
+	}
+
+	return nil
+}
+
+func NewRevokeAPIKey(dao dao.RevokeAPIKey) RevokeAPIKey {
+	return &revokeAPIKeyImpl{dao: dao}
+}
@@ -0,0 +1,98 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRevokeAPIKey(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RevokeAPIKeyRequest
+
+		shouldCallRevokeAPIKeyDAO bool
+		revokeAPIKeyDAOError      error
+
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RevokeAPIKeyRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRevokeAPIKeyDAO: true,
+		},
+		{
+			name: "DAOError",
+
+			request: &services.RevokeAPIKeyRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRevokeAPIKeyDAO: true,
+			revokeAPIKeyDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrRevokeAPIKey,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.RevokeAPIKeyRequest{
+				ID:           "10000000x0000x0000x0000x000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidRevokeAPIKeyRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.RevokeAPIKeyRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidRevokeAPIKeyRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			revokeAPIKeyDAO := daomocks.NewMockRevokeAPIKey(t)
+
+			if testCase.shouldCallRevokeAPIKeyDAO {
+				revokeAPIKeyDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.RevokeAPIKeyRequest{CredentialID: uuid.MustParse(testCase.request.CredentialID)},
+					).
+					Return(testCase.revokeAPIKeyDAOError)
+			}
+
+			service := services.NewRevokeAPIKey(revokeAPIKeyDAO)
+			err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			revokeAPIKeyDAO.AssertExpectations(t)
+		})
+	}
+}
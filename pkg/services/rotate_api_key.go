@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidRotateAPIKeyRequest = errors.New("invalid rotate api key request")
+	ErrRotateAPIKey               = errors.New("rotate api key")
+)
+
+var rotateAPIKeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type RotateAPIKeyRequest struct {
+	ID           string `validate:"required,len=36"`
+	CredentialID string `validate:"required,len=36"`
+	// ExpiresAt sets the expiration of the new key. It must be in the future, or nil for a key that never expires.
+	ExpiresAt *time.Time `validate:"omitempty,gt"`
+}
+
+type RotateAPIKeyResponse struct {
+	ID           string
+	CredentialID string
+
+	// Key is only returned once, when the key is issued. It cannot be retrieved afterward.
+	Key    string
+	Prefix string
+	Scopes []string
+
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+type RotateAPIKey interface {
+	Exec(ctx context.Context, data *RotateAPIKeyRequest) (*RotateAPIKeyResponse, error)
+}
+
+type rotateAPIKeyImpl struct {
+	dao dao.RotateAPIKey
+}
+
+func (service *rotateAPIKeyImpl) Exec(ctx context.Context, data *RotateAPIKeyRequest) (*RotateAPIKeyResponse, error) {
+	if err := rotateAPIKeyValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidRotateAPIKeyRequest, err)
+	}
+
+	keyID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidRotateAPIKeyRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidRotateAPIKeyRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	key, prefix, hash, err := entities.GenerateAPIKey()
+	if err != nil {
+		return nil, errors.Join(ErrRotateAPIKey, err)
+	}
+
+	apiKey, err := service.dao.Exec(ctx, keyID, time.Now(), &dao.RotateAPIKeyRequest{
+		CredentialID: credentialID,
+		NewID:        uuid.New(),
+		Prefix:       prefix,
+		SecretHash:   hash,
+		ExpiresAt:    data.ExpiresAt,
+	})
+	if err != nil {
+		return nil, errors.Join(ErrRotateAPIKey, err)
+	}
+
+	return &RotateAPIKeyResponse{
+		ID:           apiKey.ID.String(),
+		CredentialID: apiKey.CredentialID.String(),
+		Key:          key,
+		Prefix:       apiKey.Prefix,
+		Scopes:       apiKey.Scopes,
+		ExpiresAt:    apiKey.ExpiresAt,
+		CreatedAt:    apiKey.CreatedAt,
+	}, nil
+}
+
+func NewRotateAPIKey(dao dao.RotateAPIKey) RotateAPIKey {
+	return &rotateAPIKeyImpl{dao: dao}
+}
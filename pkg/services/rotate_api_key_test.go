@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestRotateAPIKey(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.RotateAPIKeyRequest
+
+		shouldCallRotateAPIKeyDAO bool
+		rotateAPIKeyDAOResponse   *entities.APIKey
+		rotateAPIKeyDAOError      error
+
+		expect    *services.RotateAPIKeyResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.RotateAPIKeyRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRotateAPIKeyDAO: true,
+			rotateAPIKeyDAOResponse: &entities.APIKey{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000002"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Prefix:       "prefix-2",
+				SecretHash:   []byte("hash-2"),
+				Scopes:       []string{"books:read"},
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.RotateAPIKeyResponse{
+				ID:           "10000000-0000-0000-0000-000000000002",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Prefix:       "prefix-2",
+				Scopes:       []string{"books:read"},
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.RotateAPIKeyRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			shouldCallRotateAPIKeyDAO: true,
+			rotateAPIKeyDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrRotateAPIKey,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.RotateAPIKeyRequest{
+				ID:           "10000000x0000x0000x0000x000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+			},
+
+			expectErr: services.ErrInvalidRotateAPIKeyRequest,
+		},
+		{
+			name: "InvalidCredentialID",
+
+			request: &services.RotateAPIKeyRequest{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidRotateAPIKeyRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rotateAPIKeyDAO := daomocks.NewMockRotateAPIKey(t)
+
+			var rotated *dao.RotateAPIKeyRequest
+
+			if testCase.shouldCallRotateAPIKeyDAO {
+				rotateAPIKeyDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						mock.MatchedBy(func(request *dao.RotateAPIKeyRequest) bool {
+							rotated = request
+
+							return request.CredentialID == uuid.MustParse(testCase.request.CredentialID) &&
+								request.NewID != uuid.Nil &&
+								request.Prefix != "" &&
+								len(request.SecretHash) > 0
+						}),
+					).
+					Return(testCase.rotateAPIKeyDAOResponse, testCase.rotateAPIKeyDAOError)
+			}
+
+			service := services.NewRotateAPIKey(rotateAPIKeyDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if response != nil {
+				require.Equal(t, rotated.SecretHash, entities.HashAPIKey(response.Key))
+
+				response.Key = ""
+			}
+
+			require.Equal(t, testCase.expect, response)
+
+			rotateAPIKeyDAO.AssertExpectations(t)
+		})
+	}
+}
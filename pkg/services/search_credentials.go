@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/go-playground/validator/v10"
 
@@ -23,6 +24,7 @@ func init() {
 	database.RegisterSortDirection(searchCredentialsValidate)
 	entities.RegisterRole(searchCredentialsValidate)
 	entities.RegisterSortCredentials(searchCredentialsValidate)
+	entities.RegisterEmail(searchCredentialsValidate)
 }
 
 type SearchCredentialsRequest struct {
@@ -30,8 +32,16 @@ type SearchCredentialsRequest struct {
 	Offset        int                      `validate:"omitempty,min=0"`
 	Sort          entities.SortCredentials `validate:"omitempty,sort_credentials"`
 	SortDirection database.SortDirection   `validate:"omitempty,sort_direction"`
-	Emails        []string                 `validate:"omitempty,max=128,dive,email"`
-	Roles         []entities.Role          `validate:"omitempty,max=128,dive,role"`
+	Emails        []string                 `validate:"omitempty,max=128,dive,email_address"`
+	// CanonicalEmails matches credentials registered with any variant of the given emails.
+	CanonicalEmails []string        `validate:"omitempty,max=128,dive,email_address"`
+	Roles           []entities.Role `validate:"omitempty,max=128,dive,role"`
+	// Labels selectors, in one of the following formats: "key=value", "key in (value1,value2)", "!key".
+	Labels []string `validate:"omitempty,max=32,dive,required,max=512"`
+	// Verified filters credentials on the verification of their email. Leave nil to ignore it.
+	Verified *bool
+	// LegalHold filters credentials on legal hold when true, or not on legal hold when false. Leave nil to ignore it.
+	LegalHold *bool
 }
 
 type SearchCredentialsResponse struct {
@@ -53,13 +63,37 @@ func (service *searchCredentialsImpl) Exec(
 		return nil, errors.Join(ErrInvalidSearchCredentialsRequest, err)
 	}
 
+	var labels []*entities.LabelSelector
+	for i, raw := range data.Labels {
+		selector, err := entities.ParseLabelSelector(raw)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidSearchCredentialsRequest, fmt.Errorf("at position %v: %w", i, err))
+		}
+
+		labels = append(labels, selector)
+	}
+
+	var emails []string
+	for _, email := range data.Emails {
+		emails = append(emails, entities.NormalizeEmail(email))
+	}
+
+	var canonicalEmails []string
+	for _, email := range data.CanonicalEmails {
+		canonicalEmails = append(canonicalEmails, entities.CanonicalEmail(email))
+	}
+
 	ids, err := service.dao.Exec(ctx, &dao.SearchCredentialsRequest{
-		Limit:         data.Limit,
-		Offset:        data.Offset,
-		Sort:          data.Sort,
-		SortDirection: data.SortDirection,
-		Emails:        data.Emails,
-		Roles:         data.Roles,
+		Limit:           data.Limit,
+		Offset:          data.Offset,
+		Sort:            data.Sort,
+		SortDirection:   data.SortDirection,
+		Emails:          emails,
+		CanonicalEmails: canonicalEmails,
+		Roles:           data.Roles,
+		Labels:          labels,
+		Verified:        data.Verified,
+		LegalHold:       data.LegalHold,
 	})
 	if err != nil {
 		return nil, errors.Join(ErrSearchCredentials, err)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
 	"github.com/a-novel/golib/database"
@@ -23,8 +24,13 @@ func TestSearchCredentials(t *testing.T) {
 		request *services.SearchCredentialsRequest
 
 		shouldCallSearchCredentialsDAO bool
-		searchCredentialsDAOResponse   uuid.UUIDs
-		searchCredentialsDAOError      error
+		searchCredentialsDAOLabels     []*entities.LabelSelector
+		// searchCredentialsDAOEmails are the normalized forms of the requested emails.
+		searchCredentialsDAOEmails []string
+		// searchCredentialsDAOCanonicalEmails are the canonical forms of the requested emails.
+		searchCredentialsDAOCanonicalEmails []string
+		searchCredentialsDAOResponse        uuid.UUIDs
+		searchCredentialsDAOError           error
 
 		expect    *services.SearchCredentialsResponse
 		expectErr error
@@ -54,6 +60,98 @@ func TestSearchCredentials(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "OK/Labels",
+
+			request: &services.SearchCredentialsRequest{
+				Limit:  10,
+				Labels: []string{"source=ads", " cohort in (beta-1, beta-2) ", "!campaign"},
+			},
+
+			shouldCallSearchCredentialsDAO: true,
+			searchCredentialsDAOLabels: []*entities.LabelSelector{
+				{Key: "source", Operator: entities.LabelSelectorOperatorEquals, Values: []string{"ads"}},
+				{Key: "cohort", Operator: entities.LabelSelectorOperatorIn, Values: []string{"beta-1", "beta-2"}},
+				{Key: "campaign", Operator: entities.LabelSelectorOperatorNotExists},
+			},
+			searchCredentialsDAOResponse: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expect: &services.SearchCredentialsResponse{
+				IDs: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+		},
+		{
+			name: "OK/InternationalEmails",
+
+			request: &services.SearchCredentialsRequest{
+				Limit:  10,
+				Emails: []string{"user@Bücher.de", "用户@例子.广告"},
+			},
+
+			shouldCallSearchCredentialsDAO: true,
+			searchCredentialsDAOEmails:     []string{"user@xn--bcher-kva.de", "用户@xn--fsqu00a.xn--4rr70v"},
+			searchCredentialsDAOResponse: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expect: &services.SearchCredentialsResponse{
+				IDs: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+		},
+		{
+			name: "OK/CanonicalEmails",
+
+			request: &services.SearchCredentialsRequest{
+				Limit:           10,
+				CanonicalEmails: []string{"John.Doe+promo@googlemail.com", "jane@Bücher.de"},
+			},
+
+			shouldCallSearchCredentialsDAO:      true,
+			searchCredentialsDAOCanonicalEmails: []string{"johndoe@gmail.com", "jane@xn--bcher-kva.de"},
+			searchCredentialsDAOResponse: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expect: &services.SearchCredentialsResponse{
+				IDs: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+		},
+		{
+			name: "OK/Verified",
+
+			request: &services.SearchCredentialsRequest{
+				Limit:    10,
+				Verified: lo.ToPtr(false),
+			},
+
+			shouldCallSearchCredentialsDAO: true,
+			searchCredentialsDAOResponse: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expect: &services.SearchCredentialsResponse{
+				IDs: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+		},
+		{
+			name: "OK/LegalHold",
+
+			request: &services.SearchCredentialsRequest{
+				Limit:     10,
+				LegalHold: lo.ToPtr(true),
+			},
+
+			shouldCallSearchCredentialsDAO: true,
+			searchCredentialsDAOResponse: []uuid.UUID{
+				uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			},
+
+			expect: &services.SearchCredentialsResponse{
+				IDs: []string{"00000000-0000-0000-0000-000000000001"},
+			},
+		},
 		{
 			name: "OK/Minimal",
 
@@ -107,6 +205,36 @@ func TestSearchCredentials(t *testing.T) {
 
 			expectErr: services.ErrInvalidSearchCredentialsRequest,
 		},
+		{
+			name: "InvalidRequest/LabelSelectorOperator",
+
+			request: &services.SearchCredentialsRequest{
+				Limit:  10,
+				Labels: []string{"source"},
+			},
+
+			expectErr: services.ErrInvalidSearchCredentialsRequest,
+		},
+		{
+			name: "InvalidRequest/LabelSelectorKey",
+
+			request: &services.SearchCredentialsRequest{
+				Limit:  10,
+				Labels: []string{"Source Key=ads"},
+			},
+
+			expectErr: services.ErrInvalidSearchCredentialsRequest,
+		},
+		{
+			name: "InvalidRequest/CanonicalEmail",
+
+			request: &services.SearchCredentialsRequest{
+				Limit:           10,
+				CanonicalEmails: []string{"not-an-email"},
+			},
+
+			expectErr: services.ErrInvalidSearchCredentialsRequest,
+		},
 		{
 			name: "InvalidRequest/LimitTooLow",
 
@@ -142,14 +270,23 @@ func TestSearchCredentials(t *testing.T) {
 			searchCredentialsDAO := daomocks.NewMockSearchCredentials(t)
 
 			if testCase.shouldCallSearchCredentialsDAO {
+				emails := testCase.request.Emails
+				if testCase.searchCredentialsDAOEmails != nil {
+					emails = testCase.searchCredentialsDAOEmails
+				}
+
 				searchCredentialsDAO.
 					On("Exec", context.Background(), &dao.SearchCredentialsRequest{
-						Limit:         testCase.request.Limit,
-						Offset:        testCase.request.Offset,
-						Sort:          testCase.request.Sort,
-						SortDirection: testCase.request.SortDirection,
-						Emails:        testCase.request.Emails,
-						Roles:         testCase.request.Roles,
+						Limit:           testCase.request.Limit,
+						Offset:          testCase.request.Offset,
+						Sort:            testCase.request.Sort,
+						SortDirection:   testCase.request.SortDirection,
+						Emails:          emails,
+						CanonicalEmails: testCase.searchCredentialsDAOCanonicalEmails,
+						Roles:           testCase.request.Roles,
+						Labels:          testCase.searchCredentialsDAOLabels,
+						Verified:        testCase.request.Verified,
+						LegalHold:       testCase.request.LegalHold,
 					}).
 					Return(testCase.searchCredentialsDAOResponse, testCase.searchCredentialsDAOError)
 			}
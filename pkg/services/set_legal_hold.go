@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var (
+	ErrInvalidSetLegalHoldRequest = errors.New("invalid set legal hold request")
+	ErrSetLegalHold               = errors.New("set legal hold")
+)
+
+var setLegalHoldValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type SetLegalHoldRequest struct {
+	ID string `validate:"required,len=36"`
+	// Hold places the credentials on legal hold when true, and releases them when false.
+	Hold bool
+	// Reason and SetBy are required to place a hold, and ignored when releasing it. SetBy identifies the person who
+	// requested the hold.
+	Reason string `validate:"required_if=Hold true,max=512"`
+	SetBy  string `validate:"required_if=Hold true,max=256"`
+}
+
+type SetLegalHoldResponse struct {
+	ID string
+
+	LegalHold       bool
+	LegalHoldReason string
+	LegalHoldSetBy  string
+	LegalHoldSetAt  *time.Time
+
+	UpdatedAt *time.Time
+}
+
+type SetLegalHold interface {
+	Exec(ctx context.Context, data *SetLegalHoldRequest) (*SetLegalHoldResponse, error)
+}
+
+type setLegalHoldImpl struct {
+	dao dao.SetLegalHold
+}
+
+func (service *setLegalHoldImpl) Exec(ctx context.Context, data *SetLegalHoldRequest) (*SetLegalHoldResponse, error) {
+	if err := setLegalHoldValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidSetLegalHoldRequest, err)
+	}
+
+	credentialsID, err := uuid.Parse(data.ID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidSetLegalHoldRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err),
+		)
+	}
+
+	request := &dao.SetLegalHoldRequest{Hold: data.Hold}
+	if data.Hold {
+		request.Reason = data.Reason
+		request.SetBy = data.SetBy
+	}
+
+	credentials, err := service.dao.Exec(ctx, credentialsID, time.Now(), request)
+	if err != nil {
+		return nil, errors.Join(ErrSetLegalHold, err)
+	}
+
+	return &SetLegalHoldResponse{
+		ID: credentials.ID.String(),
+
+		LegalHold:       credentials.LegalHold,
+		LegalHoldReason: credentials.LegalHoldReason,
+		LegalHoldSetBy:  credentials.LegalHoldSetBy,
+		LegalHoldSetAt:  credentials.LegalHoldSetAt,
+
+		UpdatedAt: credentials.UpdatedAt,
+	}, nil
+}
+
+func NewSetLegalHold(dao dao.SetLegalHold) SetLegalHold {
+	return &setLegalHoldImpl{dao: dao}
+}
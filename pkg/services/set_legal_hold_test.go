@@ -0,0 +1,171 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestSetLegalHold(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.SetLegalHoldRequest
+
+		shouldCallSetLegalHoldDAO bool
+		setLegalHoldDAORequest    *dao.SetLegalHoldRequest
+		setLegalHoldDAOResponse   *entities.Credential
+		setLegalHoldDAOError      error
+
+		expect    *services.SetLegalHoldResponse
+		expectErr error
+	}{
+		{
+			name: "Hold",
+
+			request: &services.SetLegalHoldRequest{
+				ID:     "00000000-0000-0000-0000-000000000001",
+				Hold:   true,
+				Reason: "case 42",
+				SetBy:  "legal@example.com",
+			},
+
+			shouldCallSetLegalHoldDAO: true,
+			setLegalHoldDAORequest: &dao.SetLegalHoldRequest{
+				Hold:   true,
+				Reason: "case 42",
+				SetBy:  "legal@example.com",
+			},
+			setLegalHoldDAOResponse: &entities.Credential{
+				ID:              uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:           "user@gmail.com",
+				LegalHold:       true,
+				LegalHoldReason: "case 42",
+				LegalHoldSetBy:  "legal@example.com",
+				LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.SetLegalHoldResponse{
+				ID:              "00000000-0000-0000-0000-000000000001",
+				LegalHold:       true,
+				LegalHoldReason: "case 42",
+				LegalHoldSetBy:  "legal@example.com",
+				LegalHoldSetAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				UpdatedAt:       lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "Release",
+
+			request: &services.SetLegalHoldRequest{
+				ID:     "00000000-0000-0000-0000-000000000001",
+				Reason: "ignored",
+				SetBy:  "legal@example.com",
+			},
+
+			shouldCallSetLegalHoldDAO: true,
+			setLegalHoldDAORequest:    &dao.SetLegalHoldRequest{},
+			setLegalHoldDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Email:     "user@gmail.com",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.SetLegalHoldResponse{
+				ID:        "00000000-0000-0000-0000-000000000001",
+				UpdatedAt: lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.SetLegalHoldRequest{
+				ID:     "00000000-0000-0000-0000-000000000001",
+				Hold:   true,
+				Reason: "case 42",
+				SetBy:  "legal@example.com",
+			},
+
+			shouldCallSetLegalHoldDAO: true,
+			setLegalHoldDAORequest: &dao.SetLegalHoldRequest{
+				Hold:   true,
+				Reason: "case 42",
+				SetBy:  "legal@example.com",
+			},
+			setLegalHoldDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrSetLegalHold,
+		},
+		{
+			name: "MissingReason",
+
+			request: &services.SetLegalHoldRequest{
+				ID:    "00000000-0000-0000-0000-000000000001",
+				Hold:  true,
+				SetBy: "legal@example.com",
+			},
+
+			expectErr: services.ErrInvalidSetLegalHoldRequest,
+		},
+		{
+			name: "MissingSetBy",
+
+			request: &services.SetLegalHoldRequest{
+				ID:     "00000000-0000-0000-0000-000000000001",
+				Hold:   true,
+				Reason: "case 42",
+			},
+
+			expectErr: services.ErrInvalidSetLegalHoldRequest,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.SetLegalHoldRequest{
+				ID: "00000000x0000x0000x0000x000000000001",
+			},
+
+			expectErr: services.ErrInvalidSetLegalHoldRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			setLegalHoldDAO := daomocks.NewMockSetLegalHold(t)
+
+			if testCase.shouldCallSetLegalHoldDAO {
+				setLegalHoldDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.ID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.setLegalHoldDAORequest,
+					).
+					Return(testCase.setLegalHoldDAOResponse, testCase.setLegalHoldDAOError)
+			}
+
+			service := services.NewSetLegalHold(setLegalHoldDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			setLegalHoldDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+)
+
+var ErrSweepExpiredTokens = errors.New("sweep expired tokens")
+
+type SweepExpiredTokensResponse struct {
+	Cleared int64
+}
+
+type SweepExpiredTokens interface {
+	Exec(ctx context.Context) (*SweepExpiredTokensResponse, error)
+}
+
+type sweepExpiredTokensImpl struct {
+	dao dao.SweepExpiredTokens
+}
+
+func (service *sweepExpiredTokensImpl) Exec(ctx context.Context) (*SweepExpiredTokensResponse, error) {
+	cleared, err := service.dao.Exec(ctx, time.Now())
+	if err != nil {
+		return nil, errors.Join(ErrSweepExpiredTokens, err)
+	}
+
+	return &SweepExpiredTokensResponse{Cleared: cleared}, nil
+}
+
+func NewSweepExpiredTokens(dao dao.SweepExpiredTokens) SweepExpiredTokens {
+	return &sweepExpiredTokensImpl{dao: dao}
+}
@@ -0,0 +1,68 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestSweepExpiredTokens(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		sweepExpiredTokensDAOResponse int64
+		sweepExpiredTokensDAOError    error
+
+		expect    *services.SweepExpiredTokensResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			sweepExpiredTokensDAOResponse: 3,
+
+			expect: &services.SweepExpiredTokensResponse{Cleared: 3},
+		},
+		{
+			name: "OK/Nothing",
+
+			expect: &services.SweepExpiredTokensResponse{Cleared: 0},
+		},
+		{
+			name: "DAO/Error",
+
+			sweepExpiredTokensDAOError: errors.New("uwups"),
+
+			expectErr: services.ErrSweepExpiredTokens,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			sweepExpiredTokensDAO := daomocks.NewMockSweepExpiredTokens(t)
+
+			sweepExpiredTokensDAO.
+				On(
+					"Exec",
+					context.Background(),
+					mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+				).
+				Return(testCase.sweepExpiredTokensDAOResponse, testCase.sweepExpiredTokensDAOError)
+
+			service := services.NewSweepExpiredTokens(sweepExpiredTokensDAO)
+			response, err := service.Exec(context.Background())
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			sweepExpiredTokensDAO.AssertExpectations(t)
+		})
+	}
+}
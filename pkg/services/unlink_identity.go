@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidUnlinkIdentityRequest = errors.New("invalid unlink identity request")
+	ErrUnlinkIdentity               = errors.New("unlink identity")
+)
+
+var unlinkIdentityValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterIdentityProvider(unlinkIdentityValidate)
+}
+
+type UnlinkIdentityRequest struct {
+	CredentialID string `validate:"required,len=36"`
+	Provider     string `validate:"required,max=64,identity_provider"`
+	Subject      string `validate:"required,max=256"`
+}
+
+type UnlinkIdentity interface {
+	Exec(ctx context.Context, data *UnlinkIdentityRequest) error
+}
+
+type unlinkIdentityImpl struct {
+	dao dao.UnlinkIdentity
+}
+
+func (service *unlinkIdentityImpl) Exec(ctx context.Context, data *UnlinkIdentityRequest) error {
+	if err := unlinkIdentityValidate.Struct(data); err != nil {
+		return errors.Join(ErrInvalidUnlinkIdentityRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return errors.Join(
+			ErrInvalidUnlinkIdentityRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	err = service.dao.Exec(ctx, credentialID, time.Now(), &dao.UnlinkIdentityRequest{
+		Provider: data.Provider,
+		Subject:  data.Subject,
+	})
+	if err != nil {
+		return errors.Join(ErrUnlinkIdentity, err)
+	}
+
+	return nil
+}
+
+func NewUnlinkIdentity(dao dao.UnlinkIdentity) UnlinkIdentity {
+	return &unlinkIdentityImpl{dao: dao}
+}
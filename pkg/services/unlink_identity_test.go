@@ -0,0 +1,119 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestUnlinkIdentity(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.UnlinkIdentityRequest
+
+		shouldCallUnlinkIdentityDAO bool
+		unlinkIdentityDAOError      error
+
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.UnlinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "google",
+				Subject:      "subject-1",
+			},
+
+			shouldCallUnlinkIdentityDAO: true,
+		},
+		{
+			name: "DAO/LastLoginMethod",
+
+			request: &services.UnlinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "google",
+				Subject:      "subject-1",
+			},
+
+			shouldCallUnlinkIdentityDAO: true,
+			unlinkIdentityDAOError:      dao.ErrLastLoginMethod,
+
+			expectErr: dao.ErrLastLoginMethod,
+		},
+		{
+			name: "DAO/Error",
+
+			request: &services.UnlinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "google",
+				Subject:      "subject-1",
+			},
+
+			shouldCallUnlinkIdentityDAO: true,
+			unlinkIdentityDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrUnlinkIdentity,
+		},
+		{
+			name: "Invalid/Provider",
+
+			request: &services.UnlinkIdentityRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Provider:     "-google",
+				Subject:      "subject-1",
+			},
+
+			expectErr: services.ErrInvalidUnlinkIdentityRequest,
+		},
+		{
+			name: "Invalid/CredentialID",
+
+			request: &services.UnlinkIdentityRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Provider:     "google",
+				Subject:      "subject-1",
+			},
+
+			expectErr: services.ErrInvalidUnlinkIdentityRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			unlinkIdentityDAO := daomocks.NewMockUnlinkIdentity(t)
+
+			if testCase.shouldCallUnlinkIdentityDAO {
+				unlinkIdentityDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.CredentialID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.UnlinkIdentityRequest{
+							Provider: testCase.request.Provider,
+							Subject:  testCase.request.Subject,
+						},
+					).
+					Return(testCase.unlinkIdentityDAOError)
+			}
+
+			service := services.NewUnlinkIdentity(unlinkIdentityDAO)
+			err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			unlinkIdentityDAO.AssertExpectations(t)
+		})
+	}
+}
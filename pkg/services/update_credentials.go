@@ -22,16 +22,28 @@ var updateCredentialsValidate = validator.New(validator.WithRequiredStructEnable
 
 func init() {
 	entities.RegisterRole(updateCredentialsValidate)
+	entities.RegisterLabelKey(updateCredentialsValidate)
+	entities.RegisterEmail(updateCredentialsValidate)
 }
 
 type UpdateCredentialsRequest struct {
 	ID                            string        `validate:"required,len=36"`
-	Email                         string        `validate:"required,email,max=256"`
+	Email                         string        `validate:"required,email_address,max=256"`
 	Role                          entities.Role `validate:"omitempty,role"`
 	EmailValidationTokenID        string        `validate:"omitempty,min=1,max=128"`
 	PendingEmailValidationTokenID string        `validate:"omitempty,min=1,max=128"`
 	PasswordTokenID               string        `validate:"omitempty,min=1,max=128"`
 	ResetPasswordTokenID          string        `validate:"omitempty,min=1,max=128"`
+
+	// Labels replace the current labels of the credentials. Leave nil to keep them unchanged.
+	Labels map[string]string `validate:"omitempty,max=64,dive,keys,label_key,endkeys,max=256"`
+	// MFARequired replaces the MFA requirement of the credentials. Leave nil to keep it unchanged.
+	MFARequired *bool
+
+	EmailValidationTokenExpiresAt        *time.Time `validate:"excluded_without=EmailValidationTokenID"`
+	PendingEmailValidationTokenExpiresAt *time.Time `validate:"excluded_without=PendingEmailValidationTokenID"`
+	PasswordTokenExpiresAt               *time.Time `validate:"excluded_without=PasswordTokenID"`
+	ResetPasswordTokenExpiresAt          *time.Time `validate:"excluded_without=ResetPasswordTokenID"`
 }
 
 type UpdateCredentialsResponse struct {
@@ -39,11 +51,22 @@ type UpdateCredentialsResponse struct {
 	Email string
 	Role  entities.Role
 
+	EmailVerifiedAt *time.Time
+
+	Labels      map[string]string
+	MFARequired bool
+	TokenEpoch  int64
+
 	EmailValidationTokenID        string
 	PendingEmailValidationTokenID string
 	PasswordTokenID               string
 	ResetPasswordTokenID          string
 
+	EmailValidationTokenExpiresAt        *time.Time
+	PendingEmailValidationTokenExpiresAt *time.Time
+	PasswordTokenExpiresAt               *time.Time
+	ResetPasswordTokenExpiresAt          *time.Time
+
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 }
@@ -68,13 +91,22 @@ func (service *updateCredentialsImpl) Exec(
 		return nil, errors.Join(ErrInvalidUpdateCredentialsRequest, fmt.Errorf("uuid value: '%s': %w", data.ID, err))
 	}
 
+	email := entities.NormalizeEmail(data.Email)
+
 	credentials, err := service.dao.Exec(ctx, credentialsID, time.Now(), &dao.UpdateCredentialsRequest{
-		Email:                         data.Email,
+		Email:                         email,
 		Role:                          data.Role,
+		Labels:                        data.Labels,
+		MFARequired:                   data.MFARequired,
 		EmailValidationTokenID:        data.EmailValidationTokenID,
 		PendingEmailValidationTokenID: data.PendingEmailValidationTokenID,
 		PasswordTokenID:               data.PasswordTokenID,
 		ResetPasswordTokenID:          data.ResetPasswordTokenID,
+
+		EmailValidationTokenExpiresAt:        data.EmailValidationTokenExpiresAt,
+		PendingEmailValidationTokenExpiresAt: data.PendingEmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:               data.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:          data.ResetPasswordTokenExpiresAt,
 	})
 	if err != nil {
 		return nil, errors.Join(ErrUpdateCredentials, err)
@@ -84,12 +116,22 @@ func (service *updateCredentialsImpl) Exec(
 		ID:                            credentials.ID.String(),
 		Email:                         credentials.Email,
 		Role:                          credentials.Role,
+		EmailVerifiedAt:               credentials.EmailVerifiedAt,
+		Labels:                        credentials.Labels,
+		MFARequired:                   credentials.MFARequired,
+		TokenEpoch:                    credentials.TokenEpoch,
 		EmailValidationTokenID:        credentials.EmailValidationTokenID,
 		PendingEmailValidationTokenID: credentials.PendingEmailValidationTokenID,
 		PasswordTokenID:               credentials.PasswordTokenID,
 		ResetPasswordTokenID:          credentials.ResetPasswordTokenID,
-		CreatedAt:                     credentials.CreatedAt,
-		UpdatedAt:                     credentials.UpdatedAt,
+
+		EmailValidationTokenExpiresAt:        credentials.EmailValidationTokenExpiresAt,
+		PendingEmailValidationTokenExpiresAt: credentials.PendingEmailValidationTokenExpiresAt,
+		PasswordTokenExpiresAt:               credentials.PasswordTokenExpiresAt,
+		ResetPasswordTokenExpiresAt:          credentials.ResetPasswordTokenExpiresAt,
+
+		CreatedAt: credentials.CreatedAt,
+		UpdatedAt: credentials.UpdatedAt,
 	}, nil
 }
 
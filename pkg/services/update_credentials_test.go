@@ -25,8 +25,10 @@ func TestUpdateCredentials(t *testing.T) {
 		request *services.UpdateCredentialsRequest
 
 		shouldCallUpdateCredentialsDAO bool
-		updateCredentialsDAOResponse   *entities.Credential
-		updateCredentialsDAOError      error
+		// updateCredentialsDAOEmail is the email expected by the DAO, when it differs from the requested one.
+		updateCredentialsDAOEmail    string
+		updateCredentialsDAOResponse *entities.Credential
+		updateCredentialsDAOError    error
 
 		expect    *services.UpdateCredentialsResponse
 		expectErr error
@@ -95,6 +97,120 @@ func TestUpdateCredentials(t *testing.T) {
 				UpdatedAt: lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
 			},
 		},
+		{
+			name: "OK/InternationalEmail",
+
+			request: &services.UpdateCredentialsRequest{
+				ID:    "00000000-0000-0000-0000-000000000004",
+				Email: "用户@例子.广告",
+			},
+
+			shouldCallUpdateCredentialsDAO: true,
+			updateCredentialsDAOEmail:      "用户@xn--fsqu00a.xn--4rr70v",
+			updateCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:     "用户@xn--fsqu00a.xn--4rr70v",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdateCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "用户@xn--fsqu00a.xn--4rr70v",
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "OK/TokensExpiration",
+
+			request: &services.UpdateCredentialsRequest{
+				ID:                          "00000000-0000-0000-0000-000000000004",
+				Email:                       "user@gmail.com",
+				Role:                        entities.RoleNone,
+				ResetPasswordTokenID:        "00000000-0000-0000-0000-000000000003",
+				ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			shouldCallUpdateCredentialsDAO: true,
+			updateCredentialsDAOResponse: &entities.Credential{
+				ID:                          uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:                       "user@gmail.com",
+				Role:                        entities.RoleNone,
+				ResetPasswordTokenID:        "00000000-0000-0000-0000-000000000003",
+				ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                   lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdateCredentialsResponse{
+				ID:                          "00000000-0000-0000-0000-000000000004",
+				Email:                       "user@gmail.com",
+				Role:                        entities.RoleNone,
+				ResetPasswordTokenID:        "00000000-0000-0000-0000-000000000003",
+				ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+				CreatedAt:                   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:                   lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "OK/Labels",
+
+			request: &services.UpdateCredentialsRequest{
+				ID:     "00000000-0000-0000-0000-000000000004",
+				Email:  "user@gmail.com",
+				Role:   entities.RoleNone,
+				Labels: map[string]string{"campaign": "spring"},
+			},
+
+			shouldCallUpdateCredentialsDAO: true,
+			updateCredentialsDAOResponse: &entities.Credential{
+				ID:        uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:     "user@gmail.com",
+				Role:      entities.RoleNone,
+				Labels:    map[string]string{"campaign": "spring"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdateCredentialsResponse{
+				ID:        "00000000-0000-0000-0000-000000000004",
+				Email:     "user@gmail.com",
+				Role:      entities.RoleNone,
+				Labels:    map[string]string{"campaign": "spring"},
+				CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "OK/MFARequired",
+
+			request: &services.UpdateCredentialsRequest{
+				ID:          "00000000-0000-0000-0000-000000000004",
+				Email:       "user@gmail.com",
+				Role:        entities.RoleNone,
+				MFARequired: lo.ToPtr(true),
+			},
+
+			shouldCallUpdateCredentialsDAO: true,
+			updateCredentialsDAOResponse: &entities.Credential{
+				ID:          uuid.MustParse("00000000-0000-0000-0000-000000000004"),
+				Email:       "user@gmail.com",
+				Role:        entities.RoleNone,
+				MFARequired: true,
+				CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:   lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expect: &services.UpdateCredentialsResponse{
+				ID:          "00000000-0000-0000-0000-000000000004",
+				Email:       "user@gmail.com",
+				Role:        entities.RoleNone,
+				MFARequired: true,
+				CreatedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt:   lo.ToPtr(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
 		{
 			name: "DAO/Error",
 
@@ -112,6 +228,30 @@ func TestUpdateCredentials(t *testing.T) {
 
 			expectErr: services.ErrUpdateCredentials,
 		},
+		{
+			name: "Invalid/ExpirationWithoutToken",
+
+			request: &services.UpdateCredentialsRequest{
+				ID:                          "00000000-0000-0000-0000-000000000004",
+				Email:                       "user@gmail.com",
+				Role:                        entities.RoleNone,
+				ResetPasswordTokenExpiresAt: lo.ToPtr(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+
+			expectErr: services.ErrInvalidUpdateCredentialsRequest,
+		},
+		{
+			name: "Invalid/LabelKey",
+
+			request: &services.UpdateCredentialsRequest{
+				ID:     "00000000-0000-0000-0000-000000000004",
+				Email:  "user@gmail.com",
+				Role:   entities.RoleNone,
+				Labels: map[string]string{"-campaign": "spring"},
+			},
+
+			expectErr: services.ErrInvalidUpdateCredentialsRequest,
+		},
 		{
 			name: "Invalid/EmailMissing",
 
@@ -202,12 +342,19 @@ func TestUpdateCredentials(t *testing.T) {
 						uuid.MustParse(testCase.request.ID),
 						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
 						&dao.UpdateCredentialsRequest{
-							Email:                         testCase.request.Email,
+							Email:                         lo.CoalesceOrEmpty(testCase.updateCredentialsDAOEmail, testCase.request.Email),
 							Role:                          testCase.request.Role,
+							Labels:                        testCase.request.Labels,
+							MFARequired:                   testCase.request.MFARequired,
 							EmailValidationTokenID:        testCase.request.EmailValidationTokenID,
 							PendingEmailValidationTokenID: testCase.request.PendingEmailValidationTokenID,
 							PasswordTokenID:               testCase.request.PasswordTokenID,
 							ResetPasswordTokenID:          testCase.request.ResetPasswordTokenID,
+
+							EmailValidationTokenExpiresAt:        testCase.request.EmailValidationTokenExpiresAt,
+							PendingEmailValidationTokenExpiresAt: testCase.request.PendingEmailValidationTokenExpiresAt,
+							PasswordTokenExpiresAt:               testCase.request.PasswordTokenExpiresAt,
+							ResetPasswordTokenExpiresAt:          testCase.request.ResetPasswordTokenExpiresAt,
 						},
 					).
 					Return(testCase.updateCredentialsDAOResponse, testCase.updateCredentialsDAOError)
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidVerifyAPIKeyRequest = errors.New("invalid verify api key request")
+	ErrVerifyAPIKey               = errors.New("verify api key")
+)
+
+var verifyAPIKeyValidate = validator.New(validator.WithRequiredStructEnabled())
+
+type VerifyAPIKeyRequest struct {
+	Key string `validate:"required,max=256"`
+}
+
+type VerifyAPIKeyResponse struct {
+	ID           string
+	CredentialID string
+	Scopes       []string
+	ExpiresAt    *time.Time
+}
+
+type VerifyAPIKey interface {
+	Exec(ctx context.Context, data *VerifyAPIKeyRequest) (*VerifyAPIKeyResponse, error)
+}
+
+type verifyAPIKeyImpl struct {
+	dao dao.VerifyAPIKey
+}
+
+func (service *verifyAPIKeyImpl) Exec(ctx context.Context, data *VerifyAPIKeyRequest) (*VerifyAPIKeyResponse, error) {
+	if err := verifyAPIKeyValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidVerifyAPIKeyRequest, err)
+	}
+
+	// Malformed keys are rejected like any other invalid key.
+	prefix, err := entities.ParseAPIKey(data.Key)
+	if err != nil {
+		return nil, errors.Join(ErrVerifyAPIKey, err)
+	}
+
+	apiKey, err := service.dao.Exec(ctx, time.Now(), &dao.VerifyAPIKeyRequest{
+		Prefix:     prefix,
+		SecretHash: entities.HashAPIKey(data.Key),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrVerifyAPIKey, err)
+	}
+
+	return &VerifyAPIKeyResponse{
+		ID:           apiKey.ID.String(),
+		CredentialID: apiKey.CredentialID.String(),
+		Scopes:       apiKey.Scopes,
+		ExpiresAt:    apiKey.ExpiresAt,
+	}, nil
+}
+
+func NewVerifyAPIKey(dao dao.VerifyAPIKey) VerifyAPIKey {
+	return &verifyAPIKeyImpl{dao: dao}
+}
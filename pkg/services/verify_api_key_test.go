@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestVerifyAPIKey(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.VerifyAPIKeyRequest
+
+		shouldCallVerifyAPIKeyDAO bool
+		verifyAPIKeyDAOResponse   *entities.APIKey
+		verifyAPIKeyDAOError      error
+
+		expect    *services.VerifyAPIKeyResponse
+		expectErr error
+	}{
+		{
+			name: "OK",
+
+			request: &services.VerifyAPIKeyRequest{
+				Key: "ak_prefix1_c2VjcmV0_c2VjcmV0",
+			},
+
+			shouldCallVerifyAPIKeyDAO: true,
+			verifyAPIKeyDAOResponse: &entities.APIKey{
+				ID:           uuid.MustParse("10000000-0000-0000-0000-000000000001"),
+				TenantID:     "default",
+				CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+				Prefix:       "prefix1",
+				SecretHash:   entities.HashAPIKey("ak_prefix1_c2VjcmV0_c2VjcmV0"),
+				Scopes:       []string{"books:read"},
+				CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+
+			expect: &services.VerifyAPIKeyResponse{
+				ID:           "10000000-0000-0000-0000-000000000001",
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Scopes:       []string{"books:read"},
+			},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.VerifyAPIKeyRequest{
+				Key: "ak_prefix1_c2VjcmV0_c2VjcmV0",
+			},
+
+			shouldCallVerifyAPIKeyDAO: true,
+			verifyAPIKeyDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrVerifyAPIKey,
+		},
+		{
+			name: "MalformedKey",
+
+			request: &services.VerifyAPIKeyRequest{
+				Key: "prefix1.secret",
+			},
+
+			expectErr: entities.ErrInvalidAPIKey,
+		},
+		{
+			name: "NoKey",
+
+			request: &services.VerifyAPIKeyRequest{},
+
+			expectErr: services.ErrInvalidVerifyAPIKeyRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			verifyAPIKeyDAO := daomocks.NewMockVerifyAPIKey(t)
+
+			if testCase.shouldCallVerifyAPIKeyDAO {
+				verifyAPIKeyDAO.
+					On(
+						"Exec",
+						context.Background(),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						&dao.VerifyAPIKeyRequest{
+							Prefix:     "prefix1",
+							SecretHash: entities.HashAPIKey(testCase.request.Key),
+						},
+					).
+					Return(testCase.verifyAPIKeyDAOResponse, testCase.verifyAPIKeyDAOError)
+			}
+
+			service := services.NewVerifyAPIKey(verifyAPIKeyDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+			require.Equal(t, testCase.expect, response)
+
+			verifyAPIKeyDAO.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/a-novel/uservice-credentials/pkg/dao"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+)
+
+var (
+	ErrInvalidWithdrawConsentRequest = errors.New("invalid withdraw consent request")
+	ErrWithdrawConsent               = errors.New("withdraw consent")
+)
+
+var withdrawConsentValidate = validator.New(validator.WithRequiredStructEnabled())
+
+func init() {
+	entities.RegisterConsentDocument(withdrawConsentValidate)
+}
+
+type WithdrawConsentRequest struct {
+	CredentialID string                   `validate:"required,len=36"`
+	Document     entities.ConsentDocument `validate:"required,consent_document"`
+}
+
+type WithdrawConsentResponse struct {
+	// Versions lists the versions of the document whose consent was withdrawn.
+	Versions    []string
+	WithdrawnAt time.Time
+}
+
+type WithdrawConsent interface {
+	Exec(ctx context.Context, data *WithdrawConsentRequest) (*WithdrawConsentResponse, error)
+}
+
+type withdrawConsentImpl struct {
+	dao dao.WithdrawConsent
+}
+
+func (service *withdrawConsentImpl) Exec(
+	ctx context.Context, data *WithdrawConsentRequest,
+) (*WithdrawConsentResponse, error) {
+	if err := withdrawConsentValidate.Struct(data); err != nil {
+		return nil, errors.Join(ErrInvalidWithdrawConsentRequest, err)
+	}
+
+	credentialID, err := uuid.Parse(data.CredentialID)
+	if err != nil {
+		return nil, errors.Join(
+			ErrInvalidWithdrawConsentRequest, fmt.Errorf("uuid value: '%s': %w", data.CredentialID, err),
+		)
+	}
+
+	now := time.Now()
+
+	consents, err := service.dao.Exec(ctx, credentialID, now, data.Document)
+	if err != nil {
+		return nil, errors.Join(ErrWithdrawConsent, err)
+	}
+
+	return &WithdrawConsentResponse{
+		Versions: lo.Map(consents, func(item *entities.CredentialConsent, _ int) string {
+			return item.Version
+		}),
+		WithdrawnAt: now,
+	}, nil
+}
+
+func NewWithdrawConsent(dao dao.WithdrawConsent) WithdrawConsent {
+	return &withdrawConsentImpl{dao: dao}
+}
@@ -0,0 +1,128 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	daomocks "github.com/a-novel/uservice-credentials/pkg/dao/mocks"
+	"github.com/a-novel/uservice-credentials/pkg/entities"
+	"github.com/a-novel/uservice-credentials/pkg/services"
+)
+
+func TestWithdrawConsent(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		request *services.WithdrawConsentRequest
+
+		shouldCallWithdrawConsentDAO bool
+		withdrawConsentDAOResponse   []*entities.CredentialConsent
+		withdrawConsentDAOError      error
+
+		expectVersions []string
+		expectErr      error
+	}{
+		{
+			name: "OK",
+
+			request: &services.WithdrawConsentRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Document:     entities.ConsentDocumentMarketing,
+			},
+
+			shouldCallWithdrawConsentDAO: true,
+			withdrawConsentDAOResponse: []*entities.CredentialConsent{
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0001-000000000001"),
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Document:     entities.ConsentDocumentMarketing,
+					Version:      "v1",
+					AcceptedAt:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+					WithdrawnAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				},
+				{
+					ID:           uuid.MustParse("00000000-0000-0000-0001-000000000002"),
+					CredentialID: uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					Document:     entities.ConsentDocumentMarketing,
+					Version:      "v2",
+					AcceptedAt:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+					WithdrawnAt:  lo.ToPtr(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+
+			expectVersions: []string{"v1", "v2"},
+		},
+		{
+			name: "DAOError",
+
+			request: &services.WithdrawConsentRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Document:     entities.ConsentDocumentMarketing,
+			},
+
+			shouldCallWithdrawConsentDAO: true,
+			withdrawConsentDAOError:      errors.New("uwups"),
+
+			expectErr: services.ErrWithdrawConsent,
+		},
+		{
+			name: "UnknownDocument",
+
+			request: &services.WithdrawConsentRequest{
+				CredentialID: "00000000-0000-0000-0000-000000000001",
+				Document:     "cookies",
+			},
+
+			expectErr: services.ErrInvalidWithdrawConsentRequest,
+		},
+		{
+			name: "InvalidID",
+
+			request: &services.WithdrawConsentRequest{
+				CredentialID: "00000000x0000x0000x0000x000000000001",
+				Document:     entities.ConsentDocumentMarketing,
+			},
+
+			expectErr: services.ErrInvalidWithdrawConsentRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			withdrawConsentDAO := daomocks.NewMockWithdrawConsent(t)
+
+			if testCase.shouldCallWithdrawConsentDAO {
+				withdrawConsentDAO.
+					On(
+						"Exec",
+						context.Background(),
+						uuid.MustParse(testCase.request.CredentialID),
+						mock.MatchedBy(func(at time.Time) bool { return at.Unix() > 0 }),
+						testCase.request.Document,
+					).
+					Return(testCase.withdrawConsentDAOResponse, testCase.withdrawConsentDAOError)
+			}
+
+			service := services.NewWithdrawConsent(withdrawConsentDAO)
+			response, err := service.Exec(context.Background(), testCase.request)
+
+			require.ErrorIs(t, err, testCase.expectErr)
+
+			if testCase.expectErr != nil {
+				require.Nil(t, response)
+			} else {
+				require.Equal(t, testCase.expectVersions, response.Versions)
+				require.False(t, response.WithdrawnAt.IsZero())
+			}
+
+			withdrawConsentDAO.AssertExpectations(t)
+		})
+	}
+}
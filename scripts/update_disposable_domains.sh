@@ -0,0 +1,18 @@
+#!/bin/bash
+
+# Refreshes the embedded list of disposable email domains from the disposable-email-domains project.
+set -euo pipefail
+
+SOURCE="https://raw.githubusercontent.com/disposable-email-domains/disposable-email-domains/main/disposable_email_blocklist.conf"
+TARGET="$(dirname "$0")/../pkg/entities/disposable_domains.txt"
+
+DOMAINS=$(curl -fsSL "${SOURCE}")
+
+{
+  # Keep the header of the current list.
+  sed -n '/^#/p' "${TARGET}"
+  echo "${DOMAINS}" | tr '[:upper:]' '[:lower:]' | sed -e 's/^[[:space:]]*//' -e 's/[[:space:]]*$//' \
+    | grep -v -e '^$' -e '^#' | LC_ALL=C sort -u
+} > "${TARGET}.tmp"
+
+mv "${TARGET}.tmp" "${TARGET}"